@@ -12,11 +12,6 @@ import (
 )
 
 func main() {
-	dsn := os.Getenv("DATABASE_URL")
-	if dsn == "" {
-		log.Fatal("DATABASE_URL environment variable is not set")
-	}
-
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = ":8080"
@@ -24,8 +19,13 @@ func main() {
 		port = ":" + port
 	}
 
+	storageConfig, err := cfg.StorageConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid storage configuration: %v", err)
+	}
+
 	ctx := context.Background()
-	repoContainer, err := cfg.NewPSQLRepositoryContainer(ctx, dsn)
+	repoContainer, err := cfg.NewRepositoryContainer(ctx, storageConfig)
 	if err != nil {
 		log.Fatalf("Failed to initialize repositories: %v", err)
 	}
@@ -35,20 +35,51 @@ func main() {
 		}
 	}()
 
-	serviceProvider, err := cfg.NewServiceContainer(repoContainer)
+	reviewerSelectionConfig, err := cfg.ReviewerSelectionConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid reviewer selection configuration: %v", err)
+	}
+
+	webhookConfig, err := cfg.WebhookConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid webhook configuration: %v", err)
+	}
+
+	forgeConfig, err := cfg.ForgeConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Invalid forge configuration: %v", err)
+	}
+
+	serviceProvider, err := cfg.NewServiceContainer(repoContainer, reviewerSelectionConfig, webhookConfig, forgeConfig)
 	if err != nil {
 		log.Fatalf("Failed to create service provider: %v", err)
 	}
 
+	integrationConfig := cfg.IntegrationConfigFromEnv()
+
+	dispatcherCtx, stopDispatcher := context.WithCancel(ctx)
+	defer stopDispatcher()
+	go serviceProvider.WebhookDispatcher.Run(dispatcherCtx)
+	go serviceProvider.ForgeReviewerSync.Run(dispatcherCtx)
+
 	e := echo.New()
+	e.HTTPErrorHandler = api.HTTPErrorHandler(e.DefaultHTTPErrorHandler)
 
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
+	e.Use(api.ActorMiddleware())
 
 	serverImpl, err := api.NewServer(
 		serviceProvider.TeamService,
 		serviceProvider.UserService,
 		serviceProvider.PullRequestService,
+		serviceProvider.StatsService,
+		serviceProvider.WebhookService,
+		serviceProvider.ForgeEventService,
+		api.IntegrationSecrets{
+			GitHubWebhookSecret: integrationConfig.GitHubWebhookSecret,
+			GitLabWebhookToken:  integrationConfig.GitLabWebhookToken,
+		},
 	)
 	if err != nil {
 		log.Fatal("Failed to create server:", err)
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/labstack/echo/v4"
+)
+
+// ActorHeader names initiator of changes made by request
+const ActorHeader = "X-Actor"
+
+// ActorMiddleware() attributes changes made by request to actor from ActorHeader
+func ActorMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			req := ctx.Request()
+			if actor := req.Header.Get(ActorHeader); actor != "" {
+				ctx.SetRequest(req.WithContext(domain.WithActor(req.Context(), actor)))
+			}
+			return next(ctx)
+		}
+	}
+}
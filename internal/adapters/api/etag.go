@@ -0,0 +1,58 @@
+package api
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/labstack/echo/v4"
+)
+
+// SourceTeamETagHeader exposes version of the team, which user left on move,
+// as ETag of the response describes the target team
+const SourceTeamETagHeader = "X-Source-Team-ETag"
+
+// setETag() exposes entity version as strong ETag of the response
+func setETag(ctx echo.Context, version int64) {
+	ctx.Response().Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+func setSourceTeamETag(ctx echo.Context, version int64) {
+	ctx.Response().Header().Set(SourceTeamETagHeader, strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// expectedVersions() converts If-Match header to versions, one of which modified entity must have.
+// Header is a comma separated list of ETags. Missing header and "*" do not restrict version.
+// ETags, which were not issued by the service, and weak ETags, as If-Match uses strong comparison,
+// cannot match any version, so app.ErrVersionConflict is returned if the list has no other ones.
+func expectedVersions(ifMatch *string) ([]int64, error) {
+	if ifMatch == nil {
+		return nil, nil
+	}
+
+	var versions []int64
+	for etag := range strings.SplitSeq(*ifMatch, ",") {
+		etag = strings.TrimSpace(etag)
+		switch {
+		case etag == "", strings.HasPrefix(etag, "W/"):
+			continue
+		case etag == "*":
+			return nil, nil
+		}
+
+		unquoted, err := strconv.Unquote(etag)
+		if err != nil {
+			continue
+		}
+		version, err := strconv.ParseInt(unquoted, 10, 64)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	if len(versions) == 0 {
+		return nil, app.ErrVersionConflict
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+)
+
+func TestExpectedVersions(t *testing.T) {
+	header := func(value string) *string { return &value }
+
+	tests := []struct {
+		name    string
+		ifMatch *string
+		want    []int64
+		wantErr error
+	}{
+		{name: "missing header"},
+		{name: "any version", ifMatch: header("*")},
+		{name: "single ETag", ifMatch: header(`"3"`), want: []int64{3}},
+		{name: "list of ETags", ifMatch: header(`"3", "4"`), want: []int64{3, 4}},
+		{name: "list without spaces", ifMatch: header(`"3","4"`), want: []int64{3, 4}},
+		{name: "list with foreign ETag", ifMatch: header(`"abc", "4"`), want: []int64{4}},
+		{name: "list with empty elements", ifMatch: header(`, "3",`), want: []int64{3}},
+		{name: "foreign ETag", ifMatch: header(`"abc"`), wantErr: app.ErrVersionConflict},
+		{name: "unquoted ETag", ifMatch: header("3"), wantErr: app.ErrVersionConflict},
+		{name: "empty header", ifMatch: header(""), wantErr: app.ErrVersionConflict},
+		{name: "weak ETag", ifMatch: header(`W/"3"`), wantErr: app.ErrVersionConflict},
+		{name: "list with weak ETag", ifMatch: header(`W/"3", "4"`), want: []int64{4}},
+		{name: "list of weak ETags", ifMatch: header(`W/"3", W/"4"`), wantErr: app.ErrVersionConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expectedVersions(tt.ifMatch)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expectedVersions() error = %v, want %v", err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expectedVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,56 +10,224 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for AssignmentEventType.
+const (
+	PULLREQUESTCLOSED   AssignmentEventType = "PULL_REQUEST_CLOSED"
+	PULLREQUESTCREATED  AssignmentEventType = "PULL_REQUEST_CREATED"
+	PULLREQUESTMERGED   AssignmentEventType = "PULL_REQUEST_MERGED"
+	PULLREQUESTREADY    AssignmentEventType = "PULL_REQUEST_READY"
+	PULLREQUESTREOPENED AssignmentEventType = "PULL_REQUEST_REOPENED"
+	REVIEWERASSIGNED    AssignmentEventType = "REVIEWER_ASSIGNED"
+	REVIEWERREASSIGNED  AssignmentEventType = "REVIEWER_REASSIGNED"
+	REVIEWERUNASSIGNED  AssignmentEventType = "REVIEWER_UNASSIGNED"
+	TEAMCREATED         AssignmentEventType = "TEAM_CREATED"
+	TEAMDELETED         AssignmentEventType = "TEAM_DELETED"
+	TEAMMEMBERADDED     AssignmentEventType = "TEAM_MEMBER_ADDED"
+	TEAMMEMBERREMOVED   AssignmentEventType = "TEAM_MEMBER_REMOVED"
+	TEAMPARENTCHANGED   AssignmentEventType = "TEAM_PARENT_CHANGED"
+	TEAMRENAMED         AssignmentEventType = "TEAM_RENAMED"
+	USERACTIVATED       AssignmentEventType = "USER_ACTIVATED"
+	USERDEACTIVATED     AssignmentEventType = "USER_DEACTIVATED"
 )
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	CONFLICT                ErrorResponseErrorCode = "CONFLICT"
+	INVALIDSTATUSTRANSITION ErrorResponseErrorCode = "INVALID_STATUS_TRANSITION"
+	NOCANDIDATE             ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTASSIGNED             ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTENOUGHAPPROVALS      ErrorResponseErrorCode = "NOT_ENOUGH_APPROVALS"
+	NOTFOUND                ErrorResponseErrorCode = "NOT_FOUND"
+	PRECONDITIONFAILED      ErrorResponseErrorCode = "PRECONDITION_FAILED"
+	PREXISTS                ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED                ErrorResponseErrorCode = "PR_MERGED"
+	PRNOTOPEN               ErrorResponseErrorCode = "PR_NOT_OPEN"
+	TEAMEXISTS              ErrorResponseErrorCode = "TEAM_EXISTS"
+	TEAMHASOPENPRS          ErrorResponseErrorCode = "TEAM_HAS_OPEN_PRS"
+	UNAUTHORIZED            ErrorResponseErrorCode = "UNAUTHORIZED"
+	VALIDATIONERROR         ErrorResponseErrorCode = "VALIDATION_ERROR"
+)
+
+// Defines values for IdentityProvider.
+const (
+	GITHUB IdentityProvider = "GITHUB"
+	GITLAB IdentityProvider = "GITLAB"
+)
+
+// Defines values for IntegrationEventResultStatus.
+const (
+	IGNORED   IntegrationEventResultStatus = "IGNORED"
+	PROCESSED IntegrationEventResultStatus = "PROCESSED"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for PullRequestStatsStatus.
+const (
+	CLOSED PullRequestStatsStatus = "CLOSED"
+	DRAFT  PullRequestStatsStatus = "DRAFT"
+	MERGED PullRequestStatsStatus = "MERGED"
+	OPEN   PullRequestStatsStatus = "OPEN"
+)
+
+// Defines values for ReviewState.
+const (
+	ReviewApproved         ReviewState = "APPROVED"
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	ReviewCommented        ReviewState = "COMMENTED"
+	ReviewPending          ReviewState = "PENDING"
+)
+
+// Defines values for TeamDeletionPolicy.
+const (
+	DeletionDeactivate TeamDeletionPolicy = "DEACTIVATE"
+	DeletionRefuse     TeamDeletionPolicy = "REFUSE"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	DEAD      WebhookDeliveryStatus = "DEAD"
+	DELIVERED WebhookDeliveryStatus = "DELIVERED"
+	PENDING   WebhookDeliveryStatus = "PENDING"
+)
+
+// AssignmentEvent defines model for AssignmentEvent.
+type AssignmentEvent struct {
+	// Actor Инициатор изменения из заголовка X-Actor
+	Actor string `json:"actor"`
+
+	// MissingApprovals Число недостающих одобрений для PR, слитого в GitHub или GitLab без required_approvals команды (для PULL_REQUEST_MERGED)
+	MissingApprovals *int `json:"missing_approvals,omitempty"`
+
+	// NewUserId Новый ревьювер (для REVIEWER_REASSIGNED)
+	NewUserId  *string   `json:"new_user_id,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+
+	// OldUserId Прежний ревьювер (для REVIEWER_REASSIGNED)
+	OldUserId *string             `json:"old_user_id,omitempty"`
+	Type      AssignmentEventType `json:"type"`
+
+	// UserId Автор созданного PR или назначенный/снятый ревьювер
+	UserId *string `json:"user_id,omitempty"`
+}
+
+// AssignmentEventType defines model for AssignmentEventType.
+type AssignmentEventType string
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
-		Code    ErrorResponseErrorCode `json:"code"`
-		Message string                 `json:"message"`
+		Code ErrorResponseErrorCode `json:"code"`
+
+		// Details Некорректные поля запроса (для VALIDATION_ERROR)
+		Details *[]FieldError `json:"details,omitempty"`
+		Message string        `json:"message"`
 	} `json:"error"`
 }
 
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// FailedReassignment defines model for FailedReassignment.
+type FailedReassignment struct {
+	PullRequestId string `json:"pull_request_id"`
+
+	// Reason Код причины (например, NO_CANDIDATE)
+	Reason string `json:"reason"`
+
+	// UserId user_id деактивированного или покинувшего команду ревьювера, оставшегося назначенным
+	UserId string `json:"user_id"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Имя поля запроса, например members[0].username
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// IdentityProvider defines model for IdentityProvider.
+type IdentityProvider string
+
+// IntegrationEventResult defines model for IntegrationEventResult.
+type IntegrationEventResult struct {
+	// PullRequestId Созданный или изменённый PR (для PROCESSED)
+	PullRequestId *string `json:"pull_request_id,omitempty"`
+
+	// Reason Почему событие не изменило PR (для IGNORED)
+	Reason *string                      `json:"reason,omitempty"`
+	Status IntegrationEventResultStatus `json:"status"`
+}
+
+// IntegrationEventResultStatus defines model for IntegrationEventResult.Status.
+type IntegrationEventResultStatus string
+
+// NewWebhook defines model for NewWebhook.
+type NewWebhook struct {
+	// EventTypes Типы доставляемых событий (пусто — все события)
+	EventTypes *[]AssignmentEventType `json:"event_types,omitempty"`
+
+	// Secret Ключ подписи тела запроса (генерируется, если не указан)
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Абсолютный http(s) URL подписчика
+	Url string `json:"url"`
+}
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (0..max_reviewers команды автора)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	CreatedAt         *time.Time `json:"createdAt"`
+	MergedAt          *time.Time `json:"mergedAt"`
+	PullRequestId     string     `json:"pull_request_id"`
+	PullRequestName   string     `json:"pull_request_name"`
+
+	// Reviews Состояние ревью каждого назначенного ревьювера
+	Reviews *[]PullRequestReview `json:"reviews,omitempty"`
+	Status  PullRequestStatus    `json:"status"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestHistory defines model for PullRequestHistory.
+type PullRequestHistory struct {
+	Events        []AssignmentEvent `json:"events"`
+	PullRequestId string            `json:"pull_request_id"`
+}
+
+// PullRequestReview defines model for PullRequestReview.
+type PullRequestReview struct {
+	AssignedAt time.Time `json:"assigned_at"`
+
+	// FallbackTeamName Команда-предок, из которой выбран ревьювер при нехватке кандидатов в команде PR
+	FallbackTeamName *string `json:"fallback_team_name,omitempty"`
+
+	// ReviewedAt Время последнего ревью (для состояний, отличных от PENDING)
+	ReviewedAt *time.Time  `json:"reviewed_at"`
+	State      ReviewState `json:"state"`
+	UserId     string      `json:"user_id"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
 	AuthorId        string                 `json:"author_id"`
@@ -71,12 +239,92 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// PullRequestStats defines model for PullRequestStats.
+type PullRequestStats struct {
+	CreatedAt         *time.Time             `json:"createdAt"`
+	MergedAt          *time.Time             `json:"mergedAt"`
+	PullRequestId     string                 `json:"pull_request_id"`
+	PullRequestName   string                 `json:"pull_request_name"`
+	Reassignments     int                    `json:"reassignments"`
+	ReviewersAssigned int                    `json:"reviewers_assigned"`
+	Status            PullRequestStatsStatus `json:"status"`
+
+	// TimeToMergeSeconds Время от createdAt до mergedAt в секундах (null для незамёрдженных PR)
+	TimeToMergeSeconds *int64 `json:"time_to_merge_seconds"`
+}
+
+// PullRequestStatsStatus defines model for PullRequestStats.Status.
+type PullRequestStatsStatus string
+
+// ReviewReassignment defines model for ReviewReassignment.
+type ReviewReassignment struct {
+	NewUserId     string `json:"new_user_id"`
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// ReviewState defines model for ReviewState.
+type ReviewState string
+
+// Stats defines model for Stats.
+type Stats struct {
+	PullRequests []PullRequestStats `json:"pull_requests"`
+
+	// TeamName Имя команды (только для статистики команды)
+	TeamName *string     `json:"team_name,omitempty"`
+	Users    []UserStats `json:"users"`
+}
+
 // Team defines model for Team.
 type Team struct {
-	Members  []TeamMember `json:"members"`
-	TeamName string       `json:"team_name"`
+	// MaxReviewers Число ревьюверов, назначаемых на PR при наличии кандидатов (по умолчанию 2)
+	MaxReviewers *int         `json:"max_reviewers,omitempty"`
+	Members      []TeamMember `json:"members"`
+
+	// MinReviewers Минимальное число ревьюверов, без которого PR не создаётся (по умолчанию 0)
+	MinReviewers *int `json:"min_reviewers,omitempty"`
+
+	// ParentTeamName Родительская команда, из которой добираются ревьюверы при нехватке кандидатов (задаётся через /team/setParent)
+	ParentTeamName *string `json:"parent_team_name"`
+
+	// RequiredApprovals Число одобрений, без которого PR нельзя пометить MERGED (по умолчанию 0 — без ограничения, не больше min_reviewers, чтобы на PR всегда назначалось достаточно ревьюверов)
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+	// TeamKey Постоянный ключ команды: совпадает с именем при создании и не меняется при переименовании (если имя занято ключом переименованной команды, ключ генерируется). Используется в REVIEWER_TEAM_STRATEGIES
+	TeamKey  *string `json:"team_key,omitempty"`
+	TeamName string  `json:"team_name"`
 }
 
+// TeamDeactivation defines model for TeamDeactivation.
+type TeamDeactivation struct {
+	DeactivatedUsers []TeamMember         `json:"deactivated_users"`
+	NotReassigned    []FailedReassignment `json:"not_reassigned"`
+	Reassigned       []ReviewReassignment `json:"reassigned"`
+	TeamName         string               `json:"team_name"`
+}
+
+// TeamDeletion defines model for TeamDeletion.
+type TeamDeletion struct {
+	// Deactivated Участники, деактивированные политикой DEACTIVATE (не состоявшие в других командах)
+	Deactivated []TeamMember `json:"deactivated"`
+
+	// Members Бывшие участники команды
+	Members       []TeamMember         `json:"members"`
+	NotReassigned []FailedReassignment `json:"not_reassigned"`
+
+	// Policy REFUSE — отказать, если у команды есть OPEN PR;
+	// DEACTIVATE — деактивировать участников, не состоящих в других командах, и переназначить
+	// их открытые ревью на активных участников команд PR
+	Policy     TeamDeletionPolicy   `json:"policy"`
+	Reassigned []ReviewReassignment `json:"reassigned"`
+	TeamName   string               `json:"team_name"`
+}
+
+// TeamDeletionPolicy REFUSE — отказать, если у команды есть OPEN PR;
+// DEACTIVATE — деактивировать участников, не состоящих в других командах, и переназначить
+// их открытые ревью на активных участников команд PR
+type TeamDeletionPolicy string
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
 	IsActive bool   `json:"is_active"`
@@ -84,25 +332,193 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// TeamMemberChange defines model for TeamMemberChange.
+type TeamMemberChange struct {
+	IsActive bool `json:"is_active"`
+
+	// PreviousIsActive Прежний флаг активности (если изменился)
+	PreviousIsActive *bool `json:"previous_is_active,omitempty"`
+
+	// PreviousUsername Прежнее имя (если изменилось)
+	PreviousUsername *string `json:"previous_username,omitempty"`
+	UserId           string  `json:"user_id"`
+	Username         string  `json:"username"`
+}
+
+// TeamMembership defines model for TeamMembership.
+type TeamMembership struct {
+	NotReassigned []FailedReassignment `json:"not_reassigned"`
+
+	// Reassigned Переназначенные ревью покинувшего команду пользователя
+	Reassigned []ReviewReassignment `json:"reassigned"`
+	Team       Team                 `json:"team"`
+}
+
+// TeamReconciliation defines model for TeamReconciliation.
+type TeamReconciliation struct {
+	Added []TeamMemberChange `json:"added"`
+
+	// Created Команда создана
+	Created bool `json:"created"`
+	DryRun  bool `json:"dry_run"`
+
+	// Removed Исключённые участники (остаются в других своих командах)
+	Removed []TeamMember `json:"removed"`
+
+	// SettingsUpdated Изменены настройки ревью существующей команды
+	SettingsUpdated bool `json:"settings_updated"`
+	Team            Team `json:"team"`
+
+	// Updated Участники с изменёнными именем или флагом активности
+	Updated []TeamMemberChange `json:"updated"`
+}
+
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
+	IsActive bool `json:"is_active"`
+
+	// TeamName Основная команда (пустая строка, если пользователь не состоит в командах)
 	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+
+	// Teams Все команды пользователя, основная — первая
+	Teams    []string `json:"teams"`
+	UserId   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// UserIdentity defines model for UserIdentity.
+type UserIdentity struct {
+	// Login Логин на хостинге кода (без учёта регистра)
+	Login    string           `json:"login"`
+	Provider IdentityProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// UserStats defines model for UserStats.
+type UserStats struct {
+	// MergedReviews Назначения на PR, которые уже MERGED
+	MergedReviews int `json:"merged_reviews"`
+
+	// OpenAssignments Текущие назначения на OPEN PR
+	OpenAssignments int `json:"open_assignments"`
+
+	// ReassignedAway Сколько раз ревью пользователя переназначалось на другого
+	ReassignedAway int `json:"reassigned_away"`
+
+	// TotalAssignments Все назначения, включая переназначенные на других
+	TotalAssignments int    `json:"total_assignments"`
+	UserId           string `json:"user_id"`
+	Username         string `json:"username"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// EventTypes Типы доставляемых событий (пусто — все события)
+	EventTypes []AssignmentEventType `json:"event_types"`
+	Url        string                `json:"url"`
+	WebhookId  openapi_types.UUID    `json:"webhook_id"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int                 `json:"attempts"`
+	CreatedAt   time.Time           `json:"created_at"`
+	DeliveredAt *time.Time          `json:"delivered_at"`
+	DeliveryId  openapi_types.UUID  `json:"delivery_id"`
+	EventId     openapi_types.UUID  `json:"event_id"`
+	EventType   AssignmentEventType `json:"event_type"`
+
+	// LastError Причина последней неудачной попытки
+	LastError *string `json:"last_error,omitempty"`
+
+	// NextAttemptAt Время следующей попытки (для PENDING)
+	NextAttemptAt time.Time             `json:"next_attempt_at"`
+	Status        WebhookDeliveryStatus `json:"status"`
+	WebhookId     openapi_types.UUID    `json:"webhook_id"`
 }
 
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// DeliveryStatusQuery defines model for DeliveryStatusQuery.
+type DeliveryStatusQuery = WebhookDeliveryStatus
+
+// IfMatchHeader defines model for IfMatchHeader.
+type IfMatchHeader = string
+
+// PullRequestIdQuery defines model for PullRequestIdQuery.
+type PullRequestIdQuery = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// WebhookIdQuery defines model for WebhookIdQuery.
+type WebhookIdQuery = openapi_types.UUID
+
+// PreconditionFailed defines model for PreconditionFailed.
+type PreconditionFailed = ErrorResponse
+
+// PostIntegrationsGithubWebhookJSONBody defines parameters for PostIntegrationsGithubWebhook.
+type PostIntegrationsGithubWebhookJSONBody = map[string]interface{}
+
+// PostIntegrationsGithubWebhookParams defines parameters for PostIntegrationsGithubWebhook.
+type PostIntegrationsGithubWebhookParams struct {
+	XGitHubEvent string `json:"X-GitHub-Event"`
+
+	// XHubSignature256 sha256=<hex(HMAC-SHA256(GITHUB_WEBHOOK_SECRET, body))>
+	XHubSignature256 string `json:"X-Hub-Signature-256"`
+
+	// XGitHubDelivery Идентификатор доставки; повторно доставленные события игнорируются
+	XGitHubDelivery *string `json:"X-GitHub-Delivery,omitempty"`
+}
+
+// PostIntegrationsGitlabWebhookJSONBody defines parameters for PostIntegrationsGitlabWebhook.
+type PostIntegrationsGitlabWebhookJSONBody = map[string]interface{}
+
+// PostIntegrationsGitlabWebhookParams defines parameters for PostIntegrationsGitlabWebhook.
+type PostIntegrationsGitlabWebhookParams struct {
+	XGitlabEvent string `json:"X-Gitlab-Event"`
+
+	// XGitlabToken Секретный токен вебхука (GITLAB_WEBHOOK_TOKEN)
+	XGitlabToken string `json:"X-Gitlab-Token"`
+
+	// XGitlabEventUUID Идентификатор доставки; повторно доставленные события игнорируются
+	XGitlabEventUUID *string `json:"X-Gitlab-Event-UUID,omitempty"`
+}
+
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestCloseParams defines parameters for PostPullRequestClose.
+type PostPullRequestCloseParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать черновик без ревьюверов (они назначаются через /pullRequest/ready)
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
+
+	// TeamName Команда, из которой назначаются ревьюверы (по умолчанию основная команда автора)
+	TeamName *string `json:"team_name,omitempty"`
+}
+
+// GetPullRequestHistoryParams defines parameters for GetPullRequestHistory.
+type GetPullRequestHistoryParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
@@ -110,18 +526,167 @@ type PostPullRequestMergeJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestMergeParams defines parameters for PostPullRequestMerge.
+type PostPullRequestMergeParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReadyParams defines parameters for PostPullRequestReady.
+type PostPullRequestReadyParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
 // PostPullRequestReassignJSONBody defines parameters for PostPullRequestReassign.
 type PostPullRequestReassignJSONBody struct {
 	OldUserId     string `json:"old_user_id"`
 	PullRequestId string `json:"pull_request_id"`
 }
 
+// PostPullRequestReassignParams defines parameters for PostPullRequestReassign.
+type PostPullRequestReassignParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
+// PostPullRequestReopenParams defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	PullRequestId string      `json:"pull_request_id"`
+	State         ReviewState `json:"state"`
+	UserId        string      `json:"user_id"`
+}
+
+// PostPullRequestReviewParams defines parameters for PostPullRequestReview.
+type PostPullRequestReviewParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PutTeamParams defines parameters for PutTeam.
+type PutTeamParams struct {
+	// DryRun Вернуть изменения без их сохранения
+	DryRun *bool `form:"dry_run,omitempty" json:"dry_run,omitempty"`
+
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostTeamAddMemberJSONBody defines parameters for PostTeamAddMember.
+type PostTeamAddMemberJSONBody struct {
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+}
+
+// PostTeamAddMemberParams defines parameters for PostTeamAddMember.
+type PostTeamAddMemberParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostTeamDeactivateUsersJSONBody defines parameters for PostTeamDeactivateUsers.
+type PostTeamDeactivateUsersJSONBody struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids"`
+}
+
+// PostTeamDeleteJSONBody defines parameters for PostTeamDelete.
+type PostTeamDeleteJSONBody struct {
+	// Policy REFUSE — отказать, если у команды есть OPEN PR;
+	// DEACTIVATE — деактивировать участников, не состоящих в других командах, и переназначить
+	// их открытые ревью на активных участников команд PR
+	Policy   TeamDeletionPolicy `json:"policy"`
+	TeamName string             `json:"team_name"`
+}
+
+// PostTeamDeleteParams defines parameters for PostTeamDelete.
+type PostTeamDeleteParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
 // GetTeamGetParams defines parameters for GetTeamGet.
 type GetTeamGetParams struct {
 	// TeamName Уникальное имя команды
 	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
+// PostTeamMoveMemberJSONBody defines parameters for PostTeamMoveMember.
+type PostTeamMoveMemberJSONBody struct {
+	FromTeamName string `json:"from_team_name"`
+
+	// ReassignReviews Переназначить открытые ревью пользователя на PR участников прежней команды
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"`
+	ToTeamName      string `json:"to_team_name"`
+	UserId          string `json:"user_id"`
+}
+
+// PostTeamMoveMemberParams defines parameters for PostTeamMoveMember.
+type PostTeamMoveMemberParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostTeamRemoveMemberJSONBody defines parameters for PostTeamRemoveMember.
+type PostTeamRemoveMemberJSONBody struct {
+	// ReassignReviews Переназначить открытые ревью пользователя на PR участников команды
+	ReassignReviews *bool  `json:"reassign_reviews,omitempty"`
+	TeamName        string `json:"team_name"`
+	UserId          string `json:"user_id"`
+}
+
+// PostTeamRemoveMemberParams defines parameters for PostTeamRemoveMember.
+type PostTeamRemoveMemberParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostTeamRenameJSONBody defines parameters for PostTeamRename.
+type PostTeamRenameJSONBody struct {
+	NewTeamName string `json:"new_team_name"`
+	TeamName    string `json:"team_name"`
+}
+
+// PostTeamRenameParams defines parameters for PostTeamRename.
+type PostTeamRenameParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// PostTeamSetParentJSONBody defines parameters for PostTeamSetParent.
+type PostTeamSetParentJSONBody struct {
+	ParentTeamName *string `json:"parent_team_name"`
+	TeamName       string  `json:"team_name"`
+}
+
+// PostTeamSetParentParams defines parameters for PostTeamSetParent.
+type PostTeamSetParentParams struct {
+	// IfMatch ETag версии, на основе которой выполняется изменение, или список ETag через запятую (достаточно совпадения одного из них); при несовпадении возвращается 412. Сравнение строгое, поэтому слабые ETag (`W/"3"`) не совпадают ни с одной версией
+	IfMatch *IfMatchHeader `json:"If-Match,omitempty"`
+}
+
+// GetTeamStatsParams defines parameters for GetTeamStats.
+type GetTeamStatsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
 // GetUsersGetReviewParams defines parameters for GetUsersGetReview.
 type GetUsersGetReviewParams struct {
 	// UserId Идентификатор пользователя
@@ -134,44 +699,187 @@ type PostUsersSetIsActiveJSONBody struct {
 	UserId   string `json:"user_id"`
 }
 
+// PostUsersSetPrimaryTeamJSONBody defines parameters for PostUsersSetPrimaryTeam.
+type PostUsersSetPrimaryTeamJSONBody struct {
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+}
+
+// PostWebhooksDeleteJSONBody defines parameters for PostWebhooksDelete.
+type PostWebhooksDeleteJSONBody struct {
+	WebhookId string `json:"webhook_id"`
+}
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	// WebhookId Идентификатор подписки
+	WebhookId *WebhookIdQuery `form:"webhook_id,omitempty" json:"webhook_id,omitempty"`
+
+	// Status Статус доставки
+	Status *DeliveryStatusQuery `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// PostIntegrationsGithubWebhookJSONRequestBody defines body for PostIntegrationsGithubWebhook for application/json ContentType.
+type PostIntegrationsGithubWebhookJSONRequestBody = PostIntegrationsGithubWebhookJSONBody
+
+// PostIntegrationsGitlabWebhookJSONRequestBody defines body for PostIntegrationsGitlabWebhook for application/json ContentType.
+type PostIntegrationsGitlabWebhookJSONRequestBody = PostIntegrationsGitlabWebhookJSONBody
+
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
 // PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
 type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
 
 // PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
 type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
 
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
 // PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
 type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
 
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
+// PutTeamJSONRequestBody defines body for PutTeam for application/json ContentType.
+type PutTeamJSONRequestBody = Team
+
 // PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
 type PostTeamAddJSONRequestBody = Team
 
+// PostTeamAddMemberJSONRequestBody defines body for PostTeamAddMember for application/json ContentType.
+type PostTeamAddMemberJSONRequestBody PostTeamAddMemberJSONBody
+
+// PostTeamDeactivateUsersJSONRequestBody defines body for PostTeamDeactivateUsers for application/json ContentType.
+type PostTeamDeactivateUsersJSONRequestBody PostTeamDeactivateUsersJSONBody
+
+// PostTeamDeleteJSONRequestBody defines body for PostTeamDelete for application/json ContentType.
+type PostTeamDeleteJSONRequestBody PostTeamDeleteJSONBody
+
+// PostTeamMoveMemberJSONRequestBody defines body for PostTeamMoveMember for application/json ContentType.
+type PostTeamMoveMemberJSONRequestBody PostTeamMoveMemberJSONBody
+
+// PostTeamRemoveMemberJSONRequestBody defines body for PostTeamRemoveMember for application/json ContentType.
+type PostTeamRemoveMemberJSONRequestBody PostTeamRemoveMemberJSONBody
+
+// PostTeamRenameJSONRequestBody defines body for PostTeamRename for application/json ContentType.
+type PostTeamRenameJSONRequestBody PostTeamRenameJSONBody
+
+// PostTeamSetParentJSONRequestBody defines body for PostTeamSetParent for application/json ContentType.
+type PostTeamSetParentJSONRequestBody PostTeamSetParentJSONBody
+
+// PostUsersSetIdentityJSONRequestBody defines body for PostUsersSetIdentity for application/json ContentType.
+type PostUsersSetIdentityJSONRequestBody = UserIdentity
+
 // PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
 type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
 
+// PostUsersSetPrimaryTeamJSONRequestBody defines body for PostUsersSetPrimaryTeam for application/json ContentType.
+type PostUsersSetPrimaryTeamJSONRequestBody PostUsersSetPrimaryTeamJSONBody
+
+// PostWebhooksAddJSONRequestBody defines body for PostWebhooksAdd for application/json ContentType.
+type PostWebhooksAddJSONRequestBody = NewWebhook
+
+// PostWebhooksDeleteJSONRequestBody defines body for PostWebhooksDelete for application/json ContentType.
+type PostWebhooksDeleteJSONRequestBody PostWebhooksDeleteJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+	// Принять событие вебхука GitHub
+	// (POST /integrations/github/webhook)
+	PostIntegrationsGithubWebhook(ctx echo.Context, params PostIntegrationsGithubWebhookParams) error
+	// Принять событие вебхука GitLab
+	// (POST /integrations/gitlab/webhook)
+	PostIntegrationsGitlabWebhook(ctx echo.Context, params PostIntegrationsGitlabWebhookParams) error
+	// Закрыть PR без merge (идемпотентная операция); ревьюверы сохраняются, но PR не учитывается в их нагрузке
+	// (POST /pullRequest/close)
+	PostPullRequestClose(ctx echo.Context, params PostPullRequestCloseParams) error
+	// Создать PR и автоматически назначить ревьюверов из команды автора (не более max_reviewers команды, кроме черновиков)
 	// (POST /pullRequest/create)
 	PostPullRequestCreate(ctx echo.Context) error
+	// Получить журнал назначений и изменений статуса PR
+	// (GET /pullRequest/history)
+	GetPullRequestHistory(ctx echo.Context, params GetPullRequestHistoryParams) error
 	// Пометить PR как MERGED (идемпотентная операция)
 	// (POST /pullRequest/merge)
-	PostPullRequestMerge(ctx echo.Context) error
+	PostPullRequestMerge(ctx echo.Context, params PostPullRequestMergeParams) error
+	// Перевести черновик в статус OPEN и назначить ревьюверов (идемпотентная операция)
+	// (POST /pullRequest/ready)
+	PostPullRequestReady(ctx echo.Context, params PostPullRequestReadyParams) error
 	// Переназначить конкретного ревьювера на другого из его команды
 	// (POST /pullRequest/reassign)
-	PostPullRequestReassign(ctx echo.Context) error
+	PostPullRequestReassign(ctx echo.Context, params PostPullRequestReassignParams) error
+	// Переоткрыть закрытый PR (идемпотентная операция); ревьюверы назначаются, только если их нет
+	// (POST /pullRequest/reopen)
+	PostPullRequestReopen(ctx echo.Context, params PostPullRequestReopenParams) error
+	// Отметить результат ревью назначенного ревьювера
+	// (POST /pullRequest/review)
+	PostPullRequestReview(ctx echo.Context, params PostPullRequestReviewParams) error
+	// Статистика назначений ревьюверов по всем пользователям и PR
+	// (GET /stats)
+	GetStats(ctx echo.Context) error
+	// Привести команду к переданному составу (идемпотентная операция)
+	// (PUT /team)
+	PutTeam(ctx echo.Context, params PutTeamParams) error
 	// Создать команду с участниками (создаёт/обновляет пользователей)
 	// (POST /team/add)
 	PostTeamAdd(ctx echo.Context) error
+	// Добавить в команду существующего пользователя (идемпотентная операция)
+	// (POST /team/addMember)
+	PostTeamAddMember(ctx echo.Context, params PostTeamAddMemberParams) error
+	// Деактивировать участников команды и переназначить их открытые ревью (в одной транзакции)
+	// (POST /team/deactivateUsers)
+	PostTeamDeactivateUsers(ctx echo.Context) error
+	// Удалить команду; обработка открытых PR задаётся политикой (в одной транзакции)
+	// (POST /team/delete)
+	PostTeamDelete(ctx echo.Context, params PostTeamDeleteParams) error
 	// Получить команду с участниками
 	// (GET /team/get)
 	GetTeamGet(ctx echo.Context, params GetTeamGetParams) error
+	// Перевести пользователя в другую команду и при необходимости переназначить его открытые ревью PR участников прежней команды (в одной транзакции)
+	// (POST /team/moveMember)
+	PostTeamMoveMember(ctx echo.Context, params PostTeamMoveMemberParams) error
+	// Исключить пользователя из команды и при необходимости переназначить его открытые ревью PR участников команды (в одной транзакции)
+	// (POST /team/removeMember)
+	PostTeamRemoveMember(ctx echo.Context, params PostTeamRemoveMemberParams) error
+	// Переименовать команду (идемпотентная операция)
+	// (POST /team/rename)
+	PostTeamRename(ctx echo.Context, params PostTeamRenameParams) error
+	// Задать родительскую команду (идемпотентная операция)
+	// (POST /team/setParent)
+	PostTeamSetParent(ctx echo.Context, params PostTeamSetParentParams) error
+	// Статистика назначений участников команды на PR команды и по PR команды
+	// (GET /team/stats)
+	GetTeamStats(ctx echo.Context, params GetTeamStatsParams) error
 	// Получить PR'ы, где пользователь назначен ревьювером
 	// (GET /users/getReview)
 	GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error
+	// Связать пользователя с аккаунтом на хостинге кода
+	// (POST /users/setIdentity)
+	PostUsersSetIdentity(ctx echo.Context) error
 	// Установить флаг активности пользователя
 	// (POST /users/setIsActive)
 	PostUsersSetIsActive(ctx echo.Context) error
+	// Сделать команду основной для её участника
+	// (POST /users/setPrimaryTeam)
+	PostUsersSetPrimaryTeam(ctx echo.Context) error
+	// Подписать URL на события
+	// (POST /webhooks/add)
+	PostWebhooksAdd(ctx echo.Context) error
+	// Удалить подписку вместе с её доставками
+	// (POST /webhooks/delete)
+	PostWebhooksDelete(ctx echo.Context) error
+	// Получить доставки событий, в том числе исчерпавшие попытки (DEAD)
+	// (GET /webhooks/deliveries)
+	GetWebhooksDeliveries(ctx echo.Context, params GetWebhooksDeliveriesParams) error
+	// Получить подписки
+	// (GET /webhooks/list)
+	GetWebhooksList(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -179,6 +887,161 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// PostIntegrationsGithubWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIntegrationsGithubWebhook(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIntegrationsGithubWebhookParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-GitHub-Event" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-GitHub-Event")]; found {
+		var XGitHubEvent string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-GitHub-Event, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-GitHub-Event", valueList[0], &XGitHubEvent, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-GitHub-Event: %s", err))
+		}
+
+		params.XGitHubEvent = XGitHubEvent
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-GitHub-Event is required, but not found"))
+	}
+	// ------------- Required header parameter "X-Hub-Signature-256" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Hub-Signature-256")]; found {
+		var XHubSignature256 string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Hub-Signature-256, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Hub-Signature-256", valueList[0], &XHubSignature256, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Hub-Signature-256: %s", err))
+		}
+
+		params.XHubSignature256 = XHubSignature256
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Hub-Signature-256 is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-GitHub-Delivery" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-GitHub-Delivery")]; found {
+		var XGitHubDelivery string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-GitHub-Delivery, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-GitHub-Delivery", valueList[0], &XGitHubDelivery, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-GitHub-Delivery: %s", err))
+		}
+
+		params.XGitHubDelivery = &XGitHubDelivery
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIntegrationsGithubWebhook(ctx, params)
+	return err
+}
+
+// PostIntegrationsGitlabWebhook converts echo context to params.
+func (w *ServerInterfaceWrapper) PostIntegrationsGitlabWebhook(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostIntegrationsGitlabWebhookParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Gitlab-Event" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Gitlab-Event")]; found {
+		var XGitlabEvent string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Gitlab-Event, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Gitlab-Event", valueList[0], &XGitlabEvent, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Gitlab-Event: %s", err))
+		}
+
+		params.XGitlabEvent = XGitlabEvent
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Gitlab-Event is required, but not found"))
+	}
+	// ------------- Required header parameter "X-Gitlab-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Gitlab-Token")]; found {
+		var XGitlabToken string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Gitlab-Token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Gitlab-Token", valueList[0], &XGitlabToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Gitlab-Token: %s", err))
+		}
+
+		params.XGitlabToken = XGitlabToken
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Gitlab-Token is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-Gitlab-Event-UUID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Gitlab-Event-UUID")]; found {
+		var XGitlabEventUUID string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Gitlab-Event-UUID, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Gitlab-Event-UUID", valueList[0], &XGitlabEventUUID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Gitlab-Event-UUID: %s", err))
+		}
+
+		params.XGitlabEventUUID = &XGitlabEventUUID
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostIntegrationsGitlabWebhook(ctx, params)
+	return err
+}
+
+// PostPullRequestClose converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestClose(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestCloseParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestClose(ctx, params)
+	return err
+}
+
 // PostPullRequestCreate converts echo context to params.
 func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
 	var err error
@@ -188,12 +1051,79 @@ func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
 	return err
 }
 
+// GetPullRequestHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestHistory(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestHistoryParams
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", ctx.QueryParams(), &params.PullRequestId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pull_request_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestHistory(ctx, params)
+	return err
+}
+
 // PostPullRequestMerge converts echo context to params.
 func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestMergeParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestMerge(ctx, params)
+	return err
+}
+
+// PostPullRequestReady converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReady(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReadyParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPullRequestMerge(ctx)
+	err = w.Handler.PostPullRequestReady(ctx, params)
 	return err
 }
 
@@ -201,8 +1131,130 @@ func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostPullRequestReassign(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReassignParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReassign(ctx, params)
+	return err
+}
+
+// PostPullRequestReopen converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReopen(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReopenParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReopen(ctx, params)
+	return err
+}
+
+// PostPullRequestReview converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReview(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostPullRequestReviewParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReview(ctx, params)
+	return err
+}
+
+// GetStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStats(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStats(ctx)
+	return err
+}
+
+// PutTeam converts echo context to params.
+func (w *ServerInterfaceWrapper) PutTeam(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutTeamParams
+	// ------------- Optional query parameter "dry_run" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "dry_run", ctx.QueryParams(), &params.DryRun)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter dry_run: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPullRequestReassign(ctx)
+	err = w.Handler.PutTeam(ctx, params)
 	return err
 }
 
@@ -215,6 +1267,73 @@ func (w *ServerInterfaceWrapper) PostTeamAdd(ctx echo.Context) error {
 	return err
 }
 
+// PostTeamAddMember converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamAddMember(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamAddMemberParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamAddMember(ctx, params)
+	return err
+}
+
+// PostTeamDeactivateUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamDeactivateUsers(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamDeactivateUsers(ctx)
+	return err
+}
+
+// PostTeamDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamDelete(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamDeleteParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamDelete(ctx, params)
+	return err
+}
+
 // GetTeamGet converts echo context to params.
 func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	var err error
@@ -233,6 +1352,140 @@ func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	return err
 }
 
+// PostTeamMoveMember converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamMoveMember(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamMoveMemberParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamMoveMember(ctx, params)
+	return err
+}
+
+// PostTeamRemoveMember converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamRemoveMember(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamRemoveMemberParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamRemoveMember(ctx, params)
+	return err
+}
+
+// PostTeamRename converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamRename(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamRenameParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamRename(ctx, params)
+	return err
+}
+
+// PostTeamSetParent converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamSetParent(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTeamSetParentParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatchHeader
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamSetParent(ctx, params)
+	return err
+}
+
+// GetTeamStats converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTeamStats(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamStatsParams
+	// ------------- Required query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTeamStats(ctx, params)
+	return err
+}
+
 // GetUsersGetReview converts echo context to params.
 func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 	var err error
@@ -251,6 +1504,15 @@ func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 	return err
 }
 
+// PostUsersSetIdentity converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersSetIdentity(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersSetIdentity(ctx)
+	return err
+}
+
 // PostUsersSetIsActive converts echo context to params.
 func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 	var err error
@@ -260,6 +1522,67 @@ func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 	return err
 }
 
+// PostUsersSetPrimaryTeam converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersSetPrimaryTeam(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersSetPrimaryTeam(ctx)
+	return err
+}
+
+// PostWebhooksAdd converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksAdd(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksAdd(ctx)
+	return err
+}
+
+// PostWebhooksDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksDelete(ctx)
+	return err
+}
+
+// GetWebhooksDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksDeliveries(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksDeliveriesParams
+	// ------------- Optional query parameter "webhook_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "webhook_id", ctx.QueryParams(), &params.WebhookId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter webhook_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksDeliveries(ctx, params)
+	return err
+}
+
+// GetWebhooksList converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksList(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksList(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -288,12 +1611,35 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 		Handler: si,
 	}
 
+	router.POST(baseURL+"/integrations/github/webhook", wrapper.PostIntegrationsGithubWebhook)
+	router.POST(baseURL+"/integrations/gitlab/webhook", wrapper.PostIntegrationsGitlabWebhook)
+	router.POST(baseURL+"/pullRequest/close", wrapper.PostPullRequestClose)
 	router.POST(baseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
+	router.GET(baseURL+"/pullRequest/history", wrapper.GetPullRequestHistory)
 	router.POST(baseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
+	router.POST(baseURL+"/pullRequest/ready", wrapper.PostPullRequestReady)
 	router.POST(baseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
+	router.POST(baseURL+"/pullRequest/reopen", wrapper.PostPullRequestReopen)
+	router.POST(baseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
+	router.GET(baseURL+"/stats", wrapper.GetStats)
+	router.PUT(baseURL+"/team", wrapper.PutTeam)
 	router.POST(baseURL+"/team/add", wrapper.PostTeamAdd)
+	router.POST(baseURL+"/team/addMember", wrapper.PostTeamAddMember)
+	router.POST(baseURL+"/team/deactivateUsers", wrapper.PostTeamDeactivateUsers)
+	router.POST(baseURL+"/team/delete", wrapper.PostTeamDelete)
 	router.GET(baseURL+"/team/get", wrapper.GetTeamGet)
+	router.POST(baseURL+"/team/moveMember", wrapper.PostTeamMoveMember)
+	router.POST(baseURL+"/team/removeMember", wrapper.PostTeamRemoveMember)
+	router.POST(baseURL+"/team/rename", wrapper.PostTeamRename)
+	router.POST(baseURL+"/team/setParent", wrapper.PostTeamSetParent)
+	router.GET(baseURL+"/team/stats", wrapper.GetTeamStats)
 	router.GET(baseURL+"/users/getReview", wrapper.GetUsersGetReview)
+	router.POST(baseURL+"/users/setIdentity", wrapper.PostUsersSetIdentity)
 	router.POST(baseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
+	router.POST(baseURL+"/users/setPrimaryTeam", wrapper.PostUsersSetPrimaryTeam)
+	router.POST(baseURL+"/webhooks/add", wrapper.PostWebhooksAdd)
+	router.POST(baseURL+"/webhooks/delete", wrapper.PostWebhooksDelete)
+	router.GET(baseURL+"/webhooks/deliveries", wrapper.GetWebhooksDeliveries)
+	router.GET(baseURL+"/webhooks/list", wrapper.GetWebhooksList)
 
 }
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/alphameo/pr-reviewnager/internal/adapters/github"
+	"github.com/alphameo/pr-reviewnager/internal/adapters/gitlab"
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/labstack/echo/v4"
+)
+
+// maxWebhookBodySize is a limit of webhook payload size, matching the one of GitHub
+const maxWebhookBodySize = 25 << 20
+
+// IntegrationSecrets authenticate webhooks of code hosting.
+// Webhooks of provider with empty secret are rejected.
+type IntegrationSecrets struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+func (s *Server) PostIntegrationsGithubWebhook(ctx echo.Context, params PostIntegrationsGithubWebhookParams) error {
+	body, err := readWebhookBody(ctx)
+	if err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	if err := github.VerifySignature(s.integrations.GitHubWebhookSecret, body, params.XHubSignature256); err != nil {
+		return ctx.JSON(http.StatusUnauthorized, newErrorResponse(UNAUTHORIZED, err.Error()))
+	}
+
+	event, err := github.ParsePullRequestEvent(params.XGitHubEvent, body)
+	if err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	return s.handleForgeEvent(ctx, event, params.XGitHubDelivery)
+}
+
+func (s *Server) PostIntegrationsGitlabWebhook(ctx echo.Context, params PostIntegrationsGitlabWebhookParams) error {
+	if err := gitlab.VerifyToken(s.integrations.GitLabWebhookToken, params.XGitlabToken); err != nil {
+		return ctx.JSON(http.StatusUnauthorized, newErrorResponse(UNAUTHORIZED, err.Error()))
+	}
+	body, err := readWebhookBody(ctx)
+	if err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	event, err := gitlab.ParseMergeRequestEvent(params.XGitlabEvent, body)
+	if err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	return s.handleForgeEvent(ctx, event, params.XGitlabEventUUID)
+}
+
+// handleForgeEvent() applies parsed event of code hosting. Nil event is acknowledged as ignored.
+func (s *Server) handleForgeEvent(ctx echo.Context, event *app.ForgePullRequestEventDTO, deliveryID *string) error {
+	if event == nil {
+		return ctx.JSON(http.StatusOK, ToAPIIntegrationEventResult(app.ForgeEventResultDTO{
+			Reason: "event is not supported",
+		}))
+	}
+	if deliveryID != nil {
+		event.DeliveryID = *deliveryID
+	}
+
+	result, err := s.forgeService.HandlePullRequestEvent(ctx.Request().Context(), event)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIIntegrationEventResult(*result))
+}
+
+// readWebhookBody() returns raw request body, which signatures are computed of
+func readWebhookBody(ctx echo.Context) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxWebhookBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxWebhookBodySize {
+		return nil, errors.New("payload is too large")
+	}
+
+	return body, nil
+}
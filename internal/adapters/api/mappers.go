@@ -1,10 +1,13 @@
 package api
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/alphameo/pr-reviewnager/internal/app"
 	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/google/uuid"
 )
 
 func ToAPITeam(d app.TeamWithUsersDTO) Team {
@@ -14,10 +17,25 @@ func ToAPITeam(d app.TeamWithUsersDTO) Team {
 		members[i] = member
 	}
 
-	return Team{
-		TeamName: d.TeamName,
-		Members:  members,
+	team := Team{
+		TeamName:       d.TeamName,
+		Members:        members,
+		ParentTeamName: d.ParentTeamName,
 	}
+	if d.TeamKey != "" {
+		teamKey := d.TeamKey
+		team.TeamKey = &teamKey
+	}
+	if d.Settings != nil {
+		minReviewers := d.Settings.MinReviewers
+		maxReviewers := d.Settings.MaxReviewers
+		requiredApprovals := d.Settings.RequiredApprovals
+		team.MinReviewers = &minReviewers
+		team.MaxReviewers = &maxReviewers
+		team.RequiredApprovals = &requiredApprovals
+	}
+
+	return team
 }
 
 func FromAPITeam(t Team) app.TeamWithUsersDTO {
@@ -27,43 +45,211 @@ func FromAPITeam(t Team) app.TeamWithUsersDTO {
 		members[i] = &member
 	}
 
+	var settings *app.TeamSettingsDTO
+	if t.MinReviewers != nil || t.MaxReviewers != nil || t.RequiredApprovals != nil {
+		settings = &app.TeamSettingsDTO{
+			MinReviewers:      domain.DefaultMinReviewersCount,
+			MaxReviewers:      domain.DefaultMaxReviewersCount,
+			RequiredApprovals: domain.DefaultRequiredApprovals,
+		}
+		if t.MinReviewers != nil {
+			settings.MinReviewers = *t.MinReviewers
+		}
+		if t.MaxReviewers != nil {
+			settings.MaxReviewers = *t.MaxReviewers
+		}
+		if t.RequiredApprovals != nil {
+			settings.RequiredApprovals = *t.RequiredApprovals
+		}
+	}
+
 	return app.TeamWithUsersDTO{
 		TeamName:  t.TeamName,
 		TeamUsers: members,
+		Settings:  settings,
 	}
 }
 
 func ToAPITeamMember(m app.UserDTO) TeamMember {
 	return TeamMember{
-		UserId:   m.ID.String(),
+		UserId:   m.Key,
 		Username: m.Name,
 		IsActive: m.Active,
 	}
 }
 
 func FromAPITeamMember(m TeamMember) app.UserDTO {
-	id, _ := domain.ParseID(m.UserId)
-
 	return app.UserDTO{
-		ID:     id,
+		Key:    m.UserId,
 		Name:   m.Username,
 		Active: m.IsActive,
 	}
 }
 
+func ToAPITeamReconciliation(d app.TeamReconciliationDTO) TeamReconciliation {
+	removed := make([]TeamMember, len(d.Removed))
+	for i, u := range d.Removed {
+		removed[i] = ToAPITeamMember(*u)
+	}
+
+	return TeamReconciliation{
+		Team:            ToAPITeam(*d.Team),
+		DryRun:          d.DryRun,
+		Created:         d.Created,
+		SettingsUpdated: d.SettingsUpdated,
+		Added:           toAPITeamMemberChanges(d.Added),
+		Updated:         toAPITeamMemberChanges(d.Updated),
+		Removed:         removed,
+	}
+}
+
+func toAPITeamMemberChanges(changes []*app.MemberChangeDTO) []TeamMemberChange {
+	result := make([]TeamMemberChange, len(changes))
+	for i, c := range changes {
+		result[i] = TeamMemberChange{
+			UserId:           c.User.Key,
+			Username:         c.User.Name,
+			IsActive:         c.User.Active,
+			PreviousUsername: c.PreviousName,
+			PreviousIsActive: c.PreviousActive,
+		}
+	}
+
+	return result
+}
+
+func ToAPITeamDeactivation(d app.DeactivationResultDTO) TeamDeactivation {
+	deactivated := make([]TeamMember, len(d.DeactivatedUsers))
+	for i, u := range d.DeactivatedUsers {
+		deactivated[i] = ToAPITeamMember(*u)
+	}
+	reassigned, notReassigned := toAPIReassignments(d.Reassigned, d.NotReassigned)
+
+	return TeamDeactivation{
+		TeamName:         d.TeamName,
+		DeactivatedUsers: deactivated,
+		Reassigned:       reassigned,
+		NotReassigned:    notReassigned,
+	}
+}
+
+func ToAPITeamMembership(d app.MembershipResultDTO) TeamMembership {
+	reassigned, notReassigned := toAPIReassignments(d.Reassigned, d.NotReassigned)
+
+	return TeamMembership{
+		Team:          ToAPITeam(*d.Team),
+		Reassigned:    reassigned,
+		NotReassigned: notReassigned,
+	}
+}
+
+func ToAPITeamDeletion(d app.TeamDeletionResultDTO) TeamDeletion {
+	members := make([]TeamMember, len(d.Members))
+	for i, u := range d.Members {
+		members[i] = ToAPITeamMember(*u)
+	}
+	deactivated := make([]TeamMember, len(d.Deactivated))
+	for i, u := range d.Deactivated {
+		deactivated[i] = ToAPITeamMember(*u)
+	}
+	reassigned, notReassigned := toAPIReassignments(d.Reassigned, d.NotReassigned)
+
+	return TeamDeletion{
+		TeamName:      d.TeamName,
+		Policy:        TeamDeletionPolicy(strings.ToUpper(d.Policy)),
+		Members:       members,
+		Deactivated:   deactivated,
+		Reassigned:    reassigned,
+		NotReassigned: notReassigned,
+	}
+}
+
+func toAPIReassignments(reassignments []*app.ReassignmentDTO, failures []*app.FailedReassignmentDTO) ([]ReviewReassignment, []FailedReassignment) {
+	reassigned := make([]ReviewReassignment, len(reassignments))
+	for i, r := range reassignments {
+		reassigned[i] = ReviewReassignment{
+			PullRequestId: r.PullRequestKey,
+			OldUserId:     r.OldReviewerKey,
+			NewUserId:     r.NewReviewerKey,
+		}
+	}
+
+	notReassigned := make([]FailedReassignment, len(failures))
+	for i, f := range failures {
+		notReassigned[i] = FailedReassignment{
+			PullRequestId: f.PullRequestKey,
+			UserId:        f.ReviewerKey,
+			Reason:        toAPIReason(f.Reason),
+		}
+	}
+
+	return reassigned, notReassigned
+}
+
+// toAPIReason() converts application error to error code used by API
+func toAPIReason(err error) string {
+	if errors.Is(err, app.ErrNoCandidate) {
+		return string(NOCANDIDATE)
+	}
+
+	return "INTERNAL"
+}
+
 func ToAPIUser(u app.UserWithTeamNameDTO) User {
 	return User{
-		UserId:   u.User.ID.String(),
+		UserId:   u.User.Key,
 		Username: u.User.Name,
 		TeamName: u.TeamName,
+		Teams:    u.TeamNames,
 		IsActive: u.User.Active,
 	}
 }
 
+func FromAPIUserIdentity(i UserIdentity) *app.UserIdentityDTO {
+	return &app.UserIdentityDTO{
+		UserKey:  i.UserId,
+		Provider: string(i.Provider),
+		Login:    i.Login,
+	}
+}
+
+func ToAPIUserIdentity(d app.UserIdentityDTO) UserIdentity {
+	return UserIdentity{
+		UserId:   d.UserKey,
+		Provider: IdentityProvider(strings.ToUpper(d.Provider)),
+		Login:    d.Login,
+	}
+}
+
+func ToAPIIntegrationEventResult(d app.ForgeEventResultDTO) IntegrationEventResult {
+	if !d.Processed {
+		return IntegrationEventResult{
+			Status: IGNORED,
+			Reason: optionalString(d.Reason),
+		}
+	}
+
+	result := IntegrationEventResult{Status: PROCESSED}
+	if d.PullRequest != nil {
+		result.PullRequestId = optionalString(d.PullRequest.Key)
+	}
+
+	return result
+}
+
 func ToAPIPullRequest(d app.PullRequestDTO) PullRequest {
-	reviewers := make([]string, len(d.ReviewerIDs))
-	for i, rid := range d.ReviewerIDs {
-		reviewers[i] = rid.String()
+	reviewers := make([]string, len(d.ReviewerKeys))
+	copy(reviewers, d.ReviewerKeys)
+
+	reviews := make([]PullRequestReview, len(d.Reviewers))
+	for i, r := range d.Reviewers {
+		reviews[i] = PullRequestReview{
+			UserId:           r.UserKey,
+			State:            ReviewState(strings.ToUpper(r.State)),
+			AssignedAt:       r.AssignedAt,
+			ReviewedAt:       r.ReviewedAt,
+			FallbackTeamName: r.FallbackTeamName,
+		}
 	}
 
 	var mergedAt *time.Time
@@ -72,11 +258,12 @@ func ToAPIPullRequest(d app.PullRequestDTO) PullRequest {
 	}
 
 	return PullRequest{
-		PullRequestId:     d.ID.String(),
+		PullRequestId:     d.Key,
 		PullRequestName:   d.Title,
-		AuthorId:          d.AuthorID.String(),
-		Status:            PullRequestStatus(d.Status),
+		AuthorId:          d.AuthorKey,
+		Status:            PullRequestStatus(toAPIStatus(d.Status)),
 		AssignedReviewers: reviewers,
+		Reviews:           &reviews,
 		CreatedAt:         &d.CreatedAt,
 		MergedAt:          mergedAt,
 	}
@@ -84,10 +271,10 @@ func ToAPIPullRequest(d app.PullRequestDTO) PullRequest {
 
 func ToAPIPullRequestShort(d app.PullRequestDTO) PullRequestShort {
 	return PullRequestShort{
-		PullRequestId:   d.ID.String(),
+		PullRequestId:   d.Key,
 		PullRequestName: d.Title,
-		AuthorId:        d.AuthorID.String(),
-		Status:          PullRequestShortStatus(d.Status),
+		AuthorId:        d.AuthorKey,
+		Status:          PullRequestShortStatus(toAPIStatus(d.Status)),
 	}
 }
 
@@ -98,3 +285,142 @@ func ToAPIPullRequestShortList(list []*app.PullRequestDTO) []PullRequestShort {
 	}
 	return out
 }
+
+func ToAPIStats(d app.StatsDTO) Stats {
+	users := make([]UserStats, len(d.Users))
+	for i, u := range d.Users {
+		users[i] = UserStats{
+			UserId:           u.UserKey,
+			Username:         u.UserName,
+			TotalAssignments: u.TotalAssignments,
+			OpenAssignments:  u.OpenAssignments,
+			MergedReviews:    u.MergedReviews,
+			ReassignedAway:   u.ReassignedAway,
+		}
+	}
+
+	pullRequests := make([]PullRequestStats, len(d.PullRequests))
+	for i, pr := range d.PullRequests {
+		createdAt := pr.CreatedAt
+		var timeToMerge *int64
+		if pr.TimeToMerge != nil {
+			seconds := int64(pr.TimeToMerge.Seconds())
+			timeToMerge = &seconds
+		}
+
+		pullRequests[i] = PullRequestStats{
+			PullRequestId:      pr.PullRequestKey,
+			PullRequestName:    pr.Title,
+			Status:             PullRequestStatsStatus(toAPIStatus(pr.Status)),
+			ReviewersAssigned:  pr.ReviewersAssigned,
+			Reassignments:      pr.Reassignments,
+			CreatedAt:          &createdAt,
+			MergedAt:           pr.MergedAt,
+			TimeToMergeSeconds: timeToMerge,
+		}
+	}
+
+	stats := Stats{
+		Users:        users,
+		PullRequests: pullRequests,
+	}
+	if d.TeamName != "" {
+		teamName := d.TeamName
+		stats.TeamName = &teamName
+	}
+
+	return stats
+}
+
+func ToAPIPullRequestHistory(d app.PullRequestHistoryDTO) PullRequestHistory {
+	events := make([]AssignmentEvent, len(d.Events))
+	for i, e := range d.Events {
+		events[i] = AssignmentEvent{
+			Type:             AssignmentEventType(strings.ToUpper(e.Type)),
+			Actor:            e.Actor,
+			OccurredAt:       e.OccurredAt,
+			UserId:           optionalString(e.UserKey),
+			OldUserId:        optionalString(e.OldReviewerKey),
+			NewUserId:        optionalString(e.NewReviewerKey),
+			MissingApprovals: e.MissingApprovals,
+		}
+	}
+
+	return PullRequestHistory{
+		PullRequestId: d.PullRequestKey,
+		Events:        events,
+	}
+}
+
+func FromAPINewWebhook(w NewWebhook) *app.NewWebhookDTO {
+	dto := &app.NewWebhookDTO{URL: w.Url}
+	if w.Secret != nil {
+		dto.Secret = *w.Secret
+	}
+	if w.EventTypes != nil {
+		dto.EventTypes = make([]string, len(*w.EventTypes))
+		for i, t := range *w.EventTypes {
+			dto.EventTypes[i] = string(t)
+		}
+	}
+
+	return dto
+}
+
+func ToAPIWebhook(d app.WebhookDTO) Webhook {
+	eventTypes := make([]AssignmentEventType, len(d.EventTypes))
+	for i, t := range d.EventTypes {
+		eventTypes[i] = AssignmentEventType(strings.ToUpper(t))
+	}
+
+	return Webhook{
+		WebhookId:  uuid.MustParse(d.ID),
+		Url:        d.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func ToAPIWebhookList(list []*app.WebhookDTO) []Webhook {
+	out := make([]Webhook, len(list))
+	for i, w := range list {
+		out[i] = ToAPIWebhook(*w)
+	}
+	return out
+}
+
+func ToAPIWebhookDelivery(d app.WebhookDeliveryDTO) WebhookDelivery {
+	return WebhookDelivery{
+		DeliveryId:    uuid.MustParse(d.ID),
+		WebhookId:     uuid.MustParse(d.WebhookID),
+		EventId:       uuid.MustParse(d.EventID),
+		EventType:     AssignmentEventType(strings.ToUpper(d.EventType)),
+		Status:        WebhookDeliveryStatus(strings.ToUpper(d.Status)),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastError:     optionalString(d.LastError),
+		CreatedAt:     d.CreatedAt,
+		DeliveredAt:   d.DeliveredAt,
+	}
+}
+
+func ToAPIWebhookDeliveryList(list []*app.WebhookDeliveryDTO) []WebhookDelivery {
+	out := make([]WebhookDelivery, len(list))
+	for i, d := range list {
+		out[i] = ToAPIWebhookDelivery(*d)
+	}
+	return out
+}
+
+// optionalString() converts empty string to nil
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// toAPIStatus() converts domain status to the upper-cased form used by API
+func toAPIStatus(status string) string {
+	return strings.ToUpper(status)
+}
@@ -2,20 +2,32 @@ package api
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/alphameo/pr-reviewnager/internal/app"
-	"github.com/alphameo/pr-reviewnager/internal/domain"
 	"github.com/labstack/echo/v4"
 )
 
 type Server struct {
-	teamService app.TeamService
-	userService app.UserService
-	prService   app.PullRequestService
+	teamService  app.TeamService
+	userService  app.UserService
+	prService    app.PullRequestService
+	statsService app.StatsService
+	hookService  app.WebhookService
+	forgeService app.ForgeEventService
+	integrations IntegrationSecrets
 }
 
-func NewServer(teamService app.TeamService, userService app.UserService, pullRequestService app.PullRequestService) (*Server, error) {
+func NewServer(
+	teamService app.TeamService,
+	userService app.UserService,
+	pullRequestService app.PullRequestService,
+	statsService app.StatsService,
+	webhookService app.WebhookService,
+	forgeEventService app.ForgeEventService,
+	integrations IntegrationSecrets,
+) (*Server, error) {
 	if teamService == nil {
 		return nil, errors.New("teamService cannot be nil")
 	}
@@ -25,103 +37,206 @@ func NewServer(teamService app.TeamService, userService app.UserService, pullReq
 	if pullRequestService == nil {
 		return nil, errors.New("pullRequestService cannot be nil")
 	}
+	if statsService == nil {
+		return nil, errors.New("statsService cannot be nil")
+	}
+	if webhookService == nil {
+		return nil, errors.New("webhookService cannot be nil")
+	}
+	if forgeEventService == nil {
+		return nil, errors.New("forgeEventService cannot be nil")
+	}
 
 	return &Server{
-		teamService: teamService,
-		userService: userService,
-		prService:   pullRequestService,
+		teamService:  teamService,
+		userService:  userService,
+		prService:    pullRequestService,
+		statsService: statsService,
+		hookService:  webhookService,
+		forgeService: forgeEventService,
+		integrations: integrations,
 	}, nil
 }
 
 func (s Server) PostPullRequestCreate(ctx echo.Context) error {
 	var input PostPullRequestCreateJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
-		return err
-	}
-
-	prID, err := domain.ParseID(input.PullRequestId)
-	if err != nil {
-		return err
-	}
-	authorID, err := domain.ParseID(input.AuthorId)
-	if err != nil {
-		return err
+		return invalidRequestBody(ctx, err)
 	}
 
 	req := app.NewPullRequestDTO{
-		ID:       prID,
-		Title:    input.PullRequestName,
-		AuthorID: authorID,
+		Key:       input.PullRequestId,
+		Title:     input.PullRequestName,
+		AuthorKey: input.AuthorId,
+		TeamName:  input.TeamName,
+	}
+	if input.Draft != nil {
+		req.Draft = *input.Draft
 	}
 
-	createdPR, err := s.prService.CreatePullRequest(&req)
+	createdPR, err := s.prService.CreatePullRequest(ctx.Request().Context(), &req)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
+	setETag(ctx, createdPR.Version)
 	return ctx.JSON(http.StatusCreated, map[string]PullRequest{
 		"pr": ToAPIPullRequest(*createdPR),
 	})
 }
 
-func (s *Server) PostPullRequestMerge(ctx echo.Context) error {
+func (s *Server) PostPullRequestMerge(ctx echo.Context, params PostPullRequestMergeParams) error {
 	var input PostPullRequestMergeJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
-		return err
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	prID, err := domain.ParseID(input.PullRequestId)
+	dtoPR, err := s.prService.MarkAsMerged(ctx.Request().Context(), input.PullRequestId, versions)
 	if err != nil {
-		return err
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	dtoPR, err := s.prService.MarkAsMerged(prID)
+	setETag(ctx, dtoPR.Version)
+	return ctx.JSON(http.StatusOK, map[string]PullRequest{
+		"pr": ToAPIPullRequest(*dtoPR),
+	})
+}
+
+func (s *Server) PostPullRequestReady(ctx echo.Context, params PostPullRequestReadyParams) error {
+	var input PostPullRequestReadyJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	dtoPR, err := s.prService.MarkAsReady(ctx.Request().Context(), input.PullRequestId, versions)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
+	setETag(ctx, dtoPR.Version)
 	return ctx.JSON(http.StatusOK, map[string]PullRequest{
 		"pr": ToAPIPullRequest(*dtoPR),
 	})
 }
 
-func (s *Server) PostPullRequestReassign(ctx echo.Context) error {
-	var input PostPullRequestReassignJSONRequestBody
+func (s *Server) PostPullRequestClose(ctx echo.Context, params PostPullRequestCloseParams) error {
+	var input PostPullRequestCloseJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	dtoPR, err := s.prService.Close(ctx.Request().Context(), input.PullRequestId, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, dtoPR.Version)
+	return ctx.JSON(http.StatusOK, map[string]PullRequest{
+		"pr": ToAPIPullRequest(*dtoPR),
+	})
+}
+
+func (s *Server) PostPullRequestReopen(ctx echo.Context, params PostPullRequestReopenParams) error {
+	var input PostPullRequestReopenJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
-		return err
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	prID, err := domain.ParseID(input.PullRequestId)
+	dtoPR, err := s.prService.Reopen(ctx.Request().Context(), input.PullRequestId, versions)
 	if err != nil {
-		return err
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
-	oldID, err := domain.ParseID(input.OldUserId)
+
+	setETag(ctx, dtoPR.Version)
+	return ctx.JSON(http.StatusOK, map[string]PullRequest{
+		"pr": ToAPIPullRequest(*dtoPR),
+	})
+}
+
+func (s *Server) PostPullRequestReassign(ctx echo.Context, params PostPullRequestReassignParams) error {
+	var input PostPullRequestReassignJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
 	if err != nil {
-		return err
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	resp, err := s.prService.ReassignReviewer(prID, oldID)
+	resp, err := s.prService.ReassignReviewer(ctx.Request().Context(), input.OldUserId, input.PullRequestId, versions)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 	updatedPR := resp.PullRequest
-	replacedBy := resp.NewReviewerUserID
+	replacedBy := resp.NewReviewerUserKey
 
+	setETag(ctx, updatedPR.Version)
 	return ctx.JSON(http.StatusOK, map[string]any{
 		"pr":          ToAPIPullRequest(*updatedPR),
-		"replaced_by": replacedBy.String(),
+		"replaced_by": replacedBy,
 	})
 }
 
+func (s *Server) PostPullRequestReview(ctx echo.Context, params PostPullRequestReviewParams) error {
+	var input PostPullRequestReviewJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	review := &app.ReviewDTO{
+		PullRequestKey: input.PullRequestId,
+		UserKey:        input.UserId,
+		State:          string(input.State),
+	}
+	updatedPR, err := s.prService.SubmitReview(ctx.Request().Context(), review, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, updatedPR.Version)
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"pr": ToAPIPullRequest(*updatedPR),
+	})
+}
+
+func (s *Server) GetPullRequestHistory(ctx echo.Context, params GetPullRequestHistoryParams) error {
+	history, err := s.prService.FindHistory(ctx.Request().Context(), params.PullRequestId)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIPullRequestHistory(*history))
+}
+
 func (s *Server) PostTeamAdd(ctx echo.Context) error {
 	var team Team
 	if err := ctx.Bind(&team); err != nil {
-		return err
+		return invalidRequestBody(ctx, err)
 	}
 
 	teamDTO := FromAPITeam(team)
 
-	err := s.teamService.CreateTeamWithUsers(&teamDTO)
+	err := s.teamService.CreateTeamWithUsers(ctx.Request().Context(), &teamDTO)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
@@ -131,27 +246,199 @@ func (s *Server) PostTeamAdd(ctx echo.Context) error {
 	})
 }
 
+func (s *Server) PutTeam(ctx echo.Context, params PutTeamParams) error {
+	var team Team
+	if err := ctx.Bind(&team); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+	dryRun := params.DryRun != nil && *params.DryRun
+
+	teamDTO := FromAPITeam(team)
+
+	result, err := s.teamService.ReconcileTeam(ctx.Request().Context(), &teamDTO, dryRun, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	if !dryRun {
+		setETag(ctx, result.Team.Version)
+	}
+	return ctx.JSON(http.StatusOK, ToAPITeamReconciliation(*result))
+}
+
+func (s *Server) PostTeamDeactivateUsers(ctx echo.Context) error {
+	var input PostTeamDeactivateUsersJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	result, err := s.teamService.DeactivateUsers(ctx.Request().Context(), input.TeamName, input.UserIds)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamDeactivation(*result))
+}
+
+func (s *Server) PostTeamRename(ctx echo.Context, params PostTeamRenameParams) error {
+	var input PostTeamRenameJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	dtoTeam, err := s.teamService.RenameTeam(ctx.Request().Context(), input.TeamName, input.NewTeamName, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, dtoTeam.Version)
+	return ctx.JSON(http.StatusOK, map[string]Team{
+		"team": ToAPITeam(*dtoTeam),
+	})
+}
+
+func (s *Server) PostTeamSetParent(ctx echo.Context, params PostTeamSetParentParams) error {
+	var input PostTeamSetParentJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	dtoTeam, err := s.teamService.SetParentTeam(ctx.Request().Context(), input.TeamName, input.ParentTeamName, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, dtoTeam.Version)
+	return ctx.JSON(http.StatusOK, map[string]Team{
+		"team": ToAPITeam(*dtoTeam),
+	})
+}
+
+func (s *Server) PostTeamDelete(ctx echo.Context, params PostTeamDeleteParams) error {
+	var input PostTeamDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	result, err := s.teamService.DeleteTeam(ctx.Request().Context(), input.TeamName, string(input.Policy), versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamDeletion(*result))
+}
+
+func (s *Server) PostTeamAddMember(ctx echo.Context, params PostTeamAddMemberParams) error {
+	var input PostTeamAddMemberJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	result, err := s.teamService.AddMember(ctx.Request().Context(), input.TeamName, input.UserId, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, result.Team.Version)
+	return ctx.JSON(http.StatusOK, ToAPITeamMembership(*result))
+}
+
+func (s *Server) PostTeamRemoveMember(ctx echo.Context, params PostTeamRemoveMemberParams) error {
+	var input PostTeamRemoveMemberJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+	reassign := input.ReassignReviews != nil && *input.ReassignReviews
+
+	result, err := s.teamService.RemoveMember(ctx.Request().Context(), input.TeamName, input.UserId, reassign, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, result.Team.Version)
+	return ctx.JSON(http.StatusOK, ToAPITeamMembership(*result))
+}
+
+func (s *Server) PostTeamMoveMember(ctx echo.Context, params PostTeamMoveMemberParams) error {
+	var input PostTeamMoveMemberJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+	versions, err := expectedVersions(params.IfMatch)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+	reassign := input.ReassignReviews != nil && *input.ReassignReviews
+
+	result, err := s.teamService.MoveMember(ctx.Request().Context(), input.FromTeamName, input.ToTeamName, input.UserId, reassign, versions)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	setETag(ctx, result.Team.Version)
+	if result.SourceTeamVersion != nil {
+		setSourceTeamETag(ctx, *result.SourceTeamVersion)
+	}
+	return ctx.JSON(http.StatusOK, ToAPITeamMembership(*result))
+}
+
 func (s *Server) GetTeamGet(ctx echo.Context, params GetTeamGetParams) error {
-	dtoTeam, err := s.teamService.FindTeamByName(params.TeamName)
+	dtoTeam, err := s.teamService.FindTeamByName(ctx.Request().Context(), params.TeamName)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
+	setETag(ctx, dtoTeam.Version)
 	return ctx.JSON(http.StatusOK, ToAPITeam(*dtoTeam))
 }
 
 func (s *Server) PostUsersSetIsActive(ctx echo.Context) error {
 	var input PostUsersSetIsActiveJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
-		return err
+		return invalidRequestBody(ctx, err)
 	}
 
-	userID, err := domain.ParseID(input.UserId)
+	updated, err := s.teamService.SetUserActiveByKey(ctx.Request().Context(), input.UserId, input.IsActive)
 	if err != nil {
-		return err
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	updated, err := s.teamService.SetUserActiveByID(userID, input.IsActive)
+	return ctx.JSON(http.StatusOK, map[string]User{
+		"user": ToAPIUser(*updated),
+	})
+}
+
+func (s *Server) PostUsersSetPrimaryTeam(ctx echo.Context) error {
+	var input PostUsersSetPrimaryTeamJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	updated, err := s.teamService.SetPrimaryTeam(ctx.Request().Context(), input.UserId, input.TeamName)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
@@ -161,13 +448,24 @@ func (s *Server) PostUsersSetIsActive(ctx echo.Context) error {
 	})
 }
 
-func (s *Server) GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error {
-	userID, err := domain.ParseID(params.UserId)
+func (s *Server) PostUsersSetIdentity(ctx echo.Context) error {
+	var input PostUsersSetIdentityJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	identity, err := s.userService.LinkIdentity(ctx.Request().Context(), FromAPIUserIdentity(input))
 	if err != nil {
-		return err
+		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	list, err := s.prService.FindPullRequestsByReviewer(userID)
+	return ctx.JSON(http.StatusOK, map[string]UserIdentity{
+		"identity": ToAPIUserIdentity(*identity),
+	})
+}
+
+func (s *Server) GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error {
+	list, err := s.prService.FindPullRequestsByReviewer(ctx.Request().Context(), params.UserId)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
@@ -178,82 +476,174 @@ func (s *Server) GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewPar
 	})
 }
 
+func (s *Server) GetStats(ctx echo.Context) error {
+	stats, err := s.statsService.GetStats(ctx.Request().Context())
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIStats(*stats))
+}
+
+func (s *Server) GetTeamStats(ctx echo.Context, params GetTeamStatsParams) error {
+	stats, err := s.statsService.GetTeamStats(ctx.Request().Context(), params.TeamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIStats(*stats))
+}
+
+func (s *Server) PostWebhooksAdd(ctx echo.Context) error {
+	var input PostWebhooksAddJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	registered, err := s.hookService.RegisterWebhook(ctx.Request().Context(), FromAPINewWebhook(input))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]any{
+		"webhook": ToAPIWebhook(*registered.Webhook),
+		"secret":  registered.Secret,
+	})
+}
+
+func (s *Server) GetWebhooksList(ctx echo.Context) error {
+	webhooks, err := s.hookService.FindWebhooks(ctx.Request().Context())
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string][]Webhook{
+		"webhooks": ToAPIWebhookList(webhooks),
+	})
+}
+
+func (s *Server) PostWebhooksDelete(ctx echo.Context) error {
+	var input PostWebhooksDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return invalidRequestBody(ctx, err)
+	}
+
+	if err := s.hookService.DeleteWebhook(ctx.Request().Context(), input.WebhookId); err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) GetWebhooksDeliveries(ctx echo.Context, params GetWebhooksDeliveriesParams) error {
+	var webhookID, status string
+	if params.WebhookId != nil {
+		webhookID = params.WebhookId.String()
+	}
+	if params.Status != nil {
+		status = string(*params.Status)
+	}
+
+	deliveries, err := s.hookService.FindDeliveries(ctx.Request().Context(), webhookID, status)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string][]WebhookDelivery{
+		"deliveries": ToAPIWebhookDeliveryList(deliveries),
+	})
+}
+
 func mapAppErrorToEchoResponse(ctx echo.Context, err error) error {
+	var validationErr *app.ValidationError
+
 	switch {
+	case errors.As(err, &validationErr):
+		details := make([]FieldError, len(validationErr.Violations))
+		for i, v := range validationErr.Violations {
+			details[i] = FieldError{Field: v.Field, Reason: v.Reason}
+		}
+		return ctx.JSON(http.StatusBadRequest, newErrorResponse(VALIDATIONERROR, "invalid input", details...))
+
 	case errors.Is(err, app.ErrTeamExists):
-		return ctx.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    TEAMEXISTS,
-				Message: "team_name already exists",
-			},
-		})
+		return ctx.JSON(http.StatusBadRequest, newErrorResponse(TEAMEXISTS, "team_name already exists"))
 
 	case errors.Is(err, app.ErrPRExists):
-		return ctx.JSON(http.StatusConflict, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    PREXISTS,
-				Message: "PR id already exists",
-			},
-		})
+		return ctx.JSON(http.StatusConflict, newErrorResponse(PREXISTS, "PR id already exists"))
 
 	case errors.Is(err, app.ErrPRAlreadyMerged):
-		return ctx.JSON(http.StatusConflict, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    PRMERGED,
-				Message: "cannot reassign on merged PR",
-			},
-		})
+		return ctx.JSON(http.StatusConflict, newErrorResponse(PRMERGED, "PR is already merged"))
 
 	case errors.Is(err, app.ErrNotAssigned):
-		return ctx.JSON(http.StatusConflict, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    NOTASSIGNED,
-				Message: "reviewer is not assigned to this PR",
-			},
-		})
+		return ctx.JSON(http.StatusConflict, newErrorResponse(NOTASSIGNED, "reviewer is not assigned to this PR"))
+
+	case errors.Is(err, app.ErrPRNotOpen):
+		return ctx.JSON(http.StatusConflict, newErrorResponse(PRNOTOPEN, "PR is not open"))
+
+	case errors.Is(err, app.ErrInvalidStatusTransition):
+		return ctx.JSON(http.StatusConflict, newErrorResponse(INVALIDSTATUSTRANSITION, "PR cannot move to requested status"))
+
+	case errors.Is(err, app.ErrNotEnoughApprovals):
+		return ctx.JSON(http.StatusConflict, newErrorResponse(NOTENOUGHAPPROVALS, "not enough approvals to merge PR"))
+
+	case errors.Is(err, app.ErrTeamHasOpenPRs):
+		return ctx.JSON(http.StatusConflict, newErrorResponse(TEAMHASOPENPRS, "team has open PRs"))
 
 	case errors.Is(err, app.ErrNoCandidate):
-		return ctx.JSON(http.StatusConflict, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    NOCANDIDATE,
-				Message: "no active replacement candidate in team",
-			},
-		})
+		return ctx.JSON(http.StatusConflict, newErrorResponse(NOCANDIDATE, "no active replacement candidate in team"))
 
 	case errors.Is(err, app.ErrNotFound):
-		return ctx.JSON(http.StatusNotFound, ErrorResponse{
-			Error: struct {
-				Code    ErrorResponseErrorCode `json:"code"`
-				Message string                 `json:"message"`
-			}{
-				Code:    NOTFOUND,
-				Message: "resource not found",
-			},
-		})
-	}
-
-	return ctx.JSON(http.StatusInternalServerError, ErrorResponse{
-		Error: struct {
-			Code    ErrorResponseErrorCode `json:"code"`
-			Message string                 `json:"message"`
-		}{
-			Code:    "INTERNAL",
-			Message: "internal server error",
-		},
-	})
+		return ctx.JSON(http.StatusNotFound, newErrorResponse(NOTFOUND, "resource not found"))
+
+	case errors.Is(err, app.ErrRotationConflict):
+		return ctx.JSON(http.StatusConflict, newErrorResponse(CONFLICT, "reviewers were concurrently assigned in team, retry request"))
+
+	case errors.Is(err, app.ErrVersionConflict):
+		return ctx.JSON(http.StatusPreconditionFailed, newErrorResponse(PRECONDITIONFAILED, "resource version does not match If-Match"))
+	}
+
+	return ctx.JSON(http.StatusInternalServerError, newErrorResponse("INTERNAL", "internal server error"))
+}
+
+// invalidRequestBody() responds with VALIDATION_ERROR if request body cannot be decoded
+func invalidRequestBody(ctx echo.Context, err error) error {
+	message := err.Error()
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		message = fmt.Sprint(httpErr.Message)
+	}
+
+	return ctx.JSON(http.StatusBadRequest, newErrorResponse(
+		VALIDATIONERROR,
+		"malformed request body",
+		FieldError{Field: "body", Reason: message},
+	))
+}
+
+func newErrorResponse(code ErrorResponseErrorCode, message string, details ...FieldError) ErrorResponse {
+	var resp ErrorResponse
+	resp.Error.Code = code
+	resp.Error.Message = message
+	if len(details) > 0 {
+		resp.Error.Details = &details
+	}
+
+	return resp
+}
+
+// HTTPErrorHandler writes bad request errors of generated parameter binding in ErrorResponse format.
+// Other errors are passed to fallback handler.
+func HTTPErrorHandler(fallback echo.HTTPErrorHandler) echo.HTTPErrorHandler {
+	return func(err error, ctx echo.Context) {
+		var httpErr *echo.HTTPError
+		if ctx.Response().Committed || !errors.As(err, &httpErr) || httpErr.Code != http.StatusBadRequest {
+			fallback(err, ctx)
+			return
+		}
+
+		resp := newErrorResponse(VALIDATIONERROR, fmt.Sprint(httpErr.Message))
+		if err := ctx.JSON(http.StatusBadRequest, resp); err != nil {
+			ctx.Logger().Error(err)
+		}
+	}
 }
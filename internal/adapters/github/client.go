@@ -0,0 +1,106 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+)
+
+const (
+	DefaultAPIURL = "https://api.github.com"
+
+	apiVersion = "2022-11-28"
+	// maxErrorBodySize limits part of response body kept in error
+	maxErrorBodySize = 512
+)
+
+// Client requests reviewers of pull requests through GitHub REST API.
+// Base URL may point to GitHub Enterprise or to a local fake.
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient() creates client of API at baseURL, DefaultAPIURL if empty,
+// authenticated with given token
+func NewClient(baseURL string, token string, timeout time.Duration) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("token cannot be empty")
+	}
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type reviewersRequest struct {
+	Reviewers []string `json:"reviewers"`
+}
+
+func (c *Client) RequestReviewers(ctx context.Context, pullRequest app.ForgePullRequestRef, logins []string) error {
+	return c.changeReviewers(ctx, http.MethodPost, pullRequest, logins)
+}
+
+func (c *Client) UnrequestReviewers(ctx context.Context, pullRequest app.ForgePullRequestRef, logins []string) error {
+	return c.changeReviewers(ctx, http.MethodDelete, pullRequest, logins)
+}
+
+func (c *Client) changeReviewers(ctx context.Context, method string, pullRequest app.ForgePullRequestRef, logins []string) error {
+	body, err := json.Marshal(reviewersRequest{Reviewers: logins})
+	if err != nil {
+		return err
+	}
+
+	segments := strings.Split(pullRequest.Repository, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	endpoint := fmt.Sprintf("%s/repos/%s/pulls/%d/requested_reviewers", c.baseURL, strings.Join(segments, "/"), pullRequest.Number)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", apiVersion)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		if snippet = bytes.TrimSpace(snippet); len(snippet) == 0 {
+			return fmt.Errorf("github responded with %s", resp.Status)
+		}
+		return fmt.Errorf("github responded with %s: %s", resp.Status, snippet)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// Package github provides integration with GitHub webhooks and REST API
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+const (
+	EventHeader     = "X-GitHub-Event"
+	DeliveryHeader  = "X-GitHub-Delivery"
+	SignatureHeader = "X-Hub-Signature-256"
+
+	pullRequestEvent = "pull_request"
+	signaturePrefix  = "sha256="
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrMalformedPayload = errors.New("malformed webhook payload")
+)
+
+// VerifySignature() checks that signature header value is HMAC-SHA256 of body
+// computed with webhook secret
+func VerifySignature(secret string, body []byte, signature string) error {
+	if secret == "" || !strings.HasPrefix(signature, signaturePrefix) {
+		return ErrInvalidSignature
+	}
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(received, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+type pullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Merged bool   `json:"merged"`
+		Draft  bool   `json:"draft"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// ParsePullRequestEvent() converts payload of given event type to pull request event.
+// It returns nil if event is not related to opening, merging, closing pull requests
+// or marking them as ready for review.
+func ParsePullRequestEvent(eventType string, body []byte) (*app.ForgePullRequestEventDTO, error) {
+	if eventType != pullRequestEvent {
+		return nil, nil
+	}
+
+	var payload pullRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedPayload, err)
+	}
+	if payload.Repository.FullName == "" || payload.PullRequest.Number == 0 {
+		return nil, fmt.Errorf("%w: repository and pull request number are required", ErrMalformedPayload)
+	}
+
+	var action app.ForgePullRequestAction
+	switch payload.Action {
+	case "opened":
+		action = app.ForgePROpened
+	case "reopened":
+		action = app.ForgePRReopened
+	case "ready_for_review":
+		action = app.ForgePRReady
+	case "closed":
+		action = app.ForgePRClosed
+		if payload.PullRequest.Merged {
+			action = app.ForgePRMerged
+		}
+	default:
+		return nil, nil
+	}
+
+	return &app.ForgePullRequestEventDTO{
+		Provider:    domain.ProviderGitHub.String(),
+		Action:      action,
+		Key:         PullRequestKey(payload.Repository.FullName, payload.PullRequest.Number),
+		Title:       payload.PullRequest.Title,
+		AuthorLogin: payload.PullRequest.User.Login,
+		Draft:       payload.PullRequest.Draft,
+		SenderLogin: payload.Sender.Login,
+	}, nil
+}
+
+// PullRequestKey() returns key of GitHub pull request in the service, e.g. "octo-org/app#42"
+func PullRequestKey(repository string, number int) string {
+	return fmt.Sprintf("%s#%d", repository, number)
+}
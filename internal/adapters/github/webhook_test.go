@@ -0,0 +1,204 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return body
+}
+
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := readFixture(t, "pull_request_opened.json")
+
+	tests := []struct {
+		name      string
+		secret    string
+		body      []byte
+		signature string
+		wantErr   error
+	}{
+		{
+			name:      "valid signature",
+			secret:    "s3cret",
+			body:      body,
+			signature: sign("s3cret", body),
+		},
+		{
+			name:      "signature with other secret",
+			secret:    "s3cret",
+			body:      body,
+			signature: sign("other", body),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature of other body",
+			secret:    "s3cret",
+			body:      body,
+			signature: sign("s3cret", readFixture(t, "pull_request_closed.json")),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature without prefix",
+			secret:    "s3cret",
+			body:      body,
+			signature: sign("s3cret", body)[len(signaturePrefix):],
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature is not hex",
+			secret:    "s3cret",
+			body:      body,
+			signature: signaturePrefix + "not-hex",
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "empty signature",
+			secret:    "s3cret",
+			body:      body,
+			signature: "",
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "empty secret",
+			secret:    "",
+			body:      body,
+			signature: sign("", body),
+			wantErr:   ErrInvalidSignature,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.body, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifySignature() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParsePullRequestEvent(t *testing.T) {
+	event := func(action app.ForgePullRequestAction, senderLogin string) *app.ForgePullRequestEventDTO {
+		return &app.ForgePullRequestEventDTO{
+			Provider:    domain.ProviderGitHub.String(),
+			Action:      action,
+			Key:         "octo-org/payments#42",
+			Title:       "Add refund endpoint",
+			AuthorLogin: "Alice-Dev",
+			SenderLogin: senderLogin,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      *app.ForgePullRequestEventDTO
+	}{
+		{
+			name:      "opened",
+			eventType: "pull_request",
+			fixture:   "pull_request_opened.json",
+			want:      event(app.ForgePROpened, "Alice-Dev"),
+		},
+		{
+			name:      "reopened",
+			eventType: "pull_request",
+			fixture:   "pull_request_reopened.json",
+			want:      event(app.ForgePRReopened, "Alice-Dev"),
+		},
+		{
+			name:      "ready for review",
+			eventType: "pull_request",
+			fixture:   "pull_request_ready_for_review.json",
+			want:      event(app.ForgePRReady, "Alice-Dev"),
+		},
+		{
+			name:      "closed",
+			eventType: "pull_request",
+			fixture:   "pull_request_closed.json",
+			want:      event(app.ForgePRClosed, "Alice-Dev"),
+		},
+		{
+			name:      "closed merged",
+			eventType: "pull_request",
+			fixture:   "pull_request_closed_merged.json",
+			want:      event(app.ForgePRMerged, "bob"),
+		},
+		{
+			name:      "ignored action",
+			eventType: "pull_request",
+			fixture:   "pull_request_synchronize.json",
+		},
+		{
+			name:      "ignored event type",
+			eventType: "ping",
+			fixture:   "ping.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePullRequestEvent(tt.eventType, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParsePullRequestEvent() error = %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParsePullRequestEvent() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("ParsePullRequestEvent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePullRequestEventMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "not JSON", body: "action=opened"},
+		{name: "truncated JSON", body: `{"action": "opened", "pull_request": {`},
+		{name: "wrong field type", body: `{"action": "opened", "pull_request": {"number": "42"}}`},
+		{name: "missing repository", body: `{"action": "opened", "pull_request": {"number": 42}}`},
+		{name: "missing number", body: `{"action": "opened", "repository": {"full_name": "octo-org/payments"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePullRequestEvent("pull_request", []byte(tt.body))
+			if !errors.Is(err, ErrMalformedPayload) {
+				t.Errorf("ParsePullRequestEvent() error = %v, want %v", err, ErrMalformedPayload)
+			}
+			if got != nil {
+				t.Errorf("ParsePullRequestEvent() = %+v, want nil", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+)
+
+const (
+	DefaultAPIURL = "https://gitlab.com/api/v4"
+
+	// maxErrorBodySize limits part of response body kept in error
+	maxErrorBodySize = 512
+)
+
+// Client changes reviewers of merge requests through GitLab REST API.
+// Base URL may point to self-managed instance or to a local fake.
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient() creates client of API at baseURL, DefaultAPIURL if empty,
+// authenticated with given access token
+func NewClient(baseURL string, token string, timeout time.Duration) (*Client, error) {
+	if token == "" {
+		return nil, errors.New("token cannot be empty")
+	}
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: timeout},
+	}, nil
+}
+
+type apiUser struct {
+	ID       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+type mergeRequest struct {
+	Reviewers []apiUser `json:"reviewers"`
+}
+
+type updateReviewersRequest struct {
+	ReviewerIDs []int64 `json:"reviewer_ids"`
+}
+
+// RequestReviewers() adds reviewers to the ones already set, because GitLab
+// replaces the whole list of reviewers of merge request
+func (c *Client) RequestReviewers(ctx context.Context, pullRequest app.ForgePullRequestRef, logins []string) error {
+	mr, err := c.getMergeRequest(ctx, pullRequest)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs := make([]int64, 0, len(mr.Reviewers)+len(logins))
+	for _, reviewer := range mr.Reviewers {
+		reviewerIDs = append(reviewerIDs, reviewer.ID)
+	}
+	added := false
+	for _, login := range logins {
+		if slices.ContainsFunc(mr.Reviewers, func(reviewer apiUser) bool {
+			return strings.EqualFold(reviewer.Username, login)
+		}) {
+			continue
+		}
+		id, err := c.findUserID(ctx, login)
+		if err != nil {
+			return err
+		}
+		reviewerIDs = append(reviewerIDs, id)
+		added = true
+	}
+	if !added {
+		return nil
+	}
+
+	return c.updateReviewers(ctx, pullRequest, reviewerIDs)
+}
+
+// UnrequestReviewers() removes reviewers from the ones set for merge request
+func (c *Client) UnrequestReviewers(ctx context.Context, pullRequest app.ForgePullRequestRef, logins []string) error {
+	mr, err := c.getMergeRequest(ctx, pullRequest)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs := make([]int64, 0, len(mr.Reviewers))
+	for _, reviewer := range mr.Reviewers {
+		if !slices.ContainsFunc(logins, func(login string) bool {
+			return strings.EqualFold(reviewer.Username, login)
+		}) {
+			reviewerIDs = append(reviewerIDs, reviewer.ID)
+		}
+	}
+	if len(reviewerIDs) == len(mr.Reviewers) {
+		return nil
+	}
+
+	return c.updateReviewers(ctx, pullRequest, reviewerIDs)
+}
+
+func (c *Client) getMergeRequest(ctx context.Context, pullRequest app.ForgePullRequestRef) (*mergeRequest, error) {
+	mr := &mergeRequest{}
+	if err := c.do(ctx, http.MethodGet, mergeRequestPath(pullRequest), nil, mr); err != nil {
+		return nil, err
+	}
+
+	return mr, nil
+}
+
+func (c *Client) updateReviewers(ctx context.Context, pullRequest app.ForgePullRequestRef, reviewerIDs []int64) error {
+	return c.do(ctx, http.MethodPut, mergeRequestPath(pullRequest), updateReviewersRequest{ReviewerIDs: reviewerIDs}, nil)
+}
+
+func (c *Client) findUserID(ctx context.Context, login string) (int64, error) {
+	users := make([]apiUser, 0, 1)
+	if err := c.do(ctx, http.MethodGet, "/users?username="+url.QueryEscape(login), nil, &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, fmt.Errorf("gitlab user %q not found", login)
+	}
+
+	return users[0].ID, nil
+}
+
+func mergeRequestPath(pullRequest app.ForgePullRequestRef) string {
+	return fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(pullRequest.Repository), pullRequest.Number)
+}
+
+// do() sends request with JSON body, if it is not nil, and decodes JSON response into out, if it is not nil
+func (c *Client) do(ctx context.Context, method string, path string, in any, out any) error {
+	var body io.Reader
+	if in != nil {
+		encoded, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		if snippet = bytes.TrimSpace(snippet); len(snippet) == 0 {
+			return fmt.Errorf("gitlab responded with %s", resp.Status)
+		}
+		return fmt.Errorf("gitlab responded with %s: %s", resp.Status, snippet)
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("malformed gitlab response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+// Package gitlab provides integration with GitLab webhooks and REST API
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+const (
+	EventHeader    = "X-Gitlab-Event"
+	TokenHeader    = "X-Gitlab-Token"
+	DeliveryHeader = "X-Gitlab-Event-UUID"
+
+	mergeRequestEvent = "Merge Request Hook"
+)
+
+var (
+	ErrInvalidToken     = errors.New("invalid webhook token")
+	ErrMalformedPayload = errors.New("malformed webhook payload")
+)
+
+// VerifyToken() checks that token header value equals secret token of webhook
+func VerifyToken(secret string, token string) error {
+	if secret == "" || subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return ErrInvalidToken
+	}
+
+	return nil
+}
+
+type mergeRequestPayload struct {
+	ObjectKind string `json:"object_kind"`
+	// User triggered the event
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Title  string `json:"title"`
+		Action string `json:"action"`
+		Draft  bool   `json:"draft"`
+	} `json:"object_attributes"`
+	// Changes lists attributes changed by update, the only one needed is draft flag
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+	} `json:"changes"`
+}
+
+// ParseMergeRequestEvent() converts payload of given event type to pull request event.
+// It returns nil if event is not related to opening, merging, closing merge requests
+// or marking them as ready.
+//
+// Payload contains only id of merge request author, so user, who opened or reopened
+// merge request, is taken as its author.
+func ParseMergeRequestEvent(eventType string, body []byte) (*app.ForgePullRequestEventDTO, error) {
+	if eventType != mergeRequestEvent {
+		return nil, nil
+	}
+
+	var payload mergeRequestPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedPayload, err)
+	}
+	if payload.Project.PathWithNamespace == "" || payload.ObjectAttributes.IID == 0 {
+		return nil, fmt.Errorf("%w: project and merge request iid are required", ErrMalformedPayload)
+	}
+
+	var action app.ForgePullRequestAction
+	switch payload.ObjectAttributes.Action {
+	case "open":
+		action = app.ForgePROpened
+	case "reopen":
+		action = app.ForgePRReopened
+	case "merge":
+		action = app.ForgePRMerged
+	case "close":
+		action = app.ForgePRClosed
+	case "update":
+		draft := payload.Changes.Draft
+		if draft == nil || !draft.Previous || draft.Current {
+			return nil, nil
+		}
+		action = app.ForgePRReady
+	default:
+		return nil, nil
+	}
+
+	return &app.ForgePullRequestEventDTO{
+		Provider:    domain.ProviderGitLab.String(),
+		Action:      action,
+		Key:         MergeRequestKey(payload.Project.PathWithNamespace, payload.ObjectAttributes.IID),
+		Title:       payload.ObjectAttributes.Title,
+		AuthorLogin: payload.User.Username,
+		Draft:       payload.ObjectAttributes.Draft,
+		SenderLogin: payload.User.Username,
+	}, nil
+}
+
+// MergeRequestKey() returns key of GitLab merge request in the service
+// in GitLab reference format, e.g. "group/app!42"
+func MergeRequestKey(project string, iid int) string {
+	return fmt.Sprintf("%s!%d", project, iid)
+}
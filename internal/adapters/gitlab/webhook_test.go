@@ -0,0 +1,154 @@
+package gitlab
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	return body
+}
+
+func TestVerifyToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		secret  string
+		token   string
+		wantErr error
+	}{
+		{name: "valid token", secret: "s3cret", token: "s3cret"},
+		{name: "other token", secret: "s3cret", token: "other", wantErr: ErrInvalidToken},
+		{name: "token prefix", secret: "s3cret", token: "s3c", wantErr: ErrInvalidToken},
+		{name: "empty token", secret: "s3cret", token: "", wantErr: ErrInvalidToken},
+		{name: "empty secret", secret: "", token: "", wantErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyToken(tt.secret, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyToken() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseMergeRequestEvent(t *testing.T) {
+	event := func(action app.ForgePullRequestAction, login string) *app.ForgePullRequestEventDTO {
+		return &app.ForgePullRequestEventDTO{
+			Provider:    domain.ProviderGitLab.String(),
+			Action:      action,
+			Key:         "platform/billing!7",
+			Title:       "Retry failed invoices",
+			AuthorLogin: login,
+			SenderLogin: login,
+		}
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      *app.ForgePullRequestEventDTO
+	}{
+		{
+			name:      "open",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_open.json",
+			want:      event(app.ForgePROpened, "carol"),
+		},
+		{
+			name:      "reopen",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_reopen.json",
+			want:      event(app.ForgePRReopened, "carol"),
+		},
+		{
+			name:      "close",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_close.json",
+			want:      event(app.ForgePRClosed, "carol"),
+		},
+		{
+			name:      "merge",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_merge.json",
+			want:      event(app.ForgePRMerged, "dave"),
+		},
+		{
+			name:      "update from draft to ready",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_ready.json",
+			want:      event(app.ForgePRReady, "carol"),
+		},
+		{
+			name:      "update from ready to draft",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_draft.json",
+		},
+		{
+			name:      "update without draft change",
+			eventType: "Merge Request Hook",
+			fixture:   "merge_request_update.json",
+		},
+		{
+			name:      "ignored event type",
+			eventType: "Push Hook",
+			fixture:   "merge_request_open.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMergeRequestEvent(tt.eventType, readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("ParseMergeRequestEvent() error = %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseMergeRequestEvent() = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || *got != *tt.want {
+				t.Errorf("ParseMergeRequestEvent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMergeRequestEventMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "not JSON", body: "action=open"},
+		{name: "truncated JSON", body: `{"object_attributes": {`},
+		{name: "wrong field type", body: `{"object_attributes": {"iid": "7", "action": "open"}}`},
+		{name: "missing project", body: `{"object_attributes": {"iid": 7, "action": "open"}}`},
+		{name: "missing iid", body: `{"project": {"path_with_namespace": "platform/billing"}, "object_attributes": {"action": "open"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMergeRequestEvent("Merge Request Hook", []byte(tt.body))
+			if !errors.Is(err, ErrMalformedPayload) {
+				t.Errorf("ParseMergeRequestEvent() error = %v, want %v", err, ErrMalformedPayload)
+			}
+			if got != nil {
+				t.Errorf("ParseMergeRequestEvent() = %+v, want nil", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Package webhook provides delivery of events to webhook subscribers over HTTP
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	SignatureHeader = "X-Webhook-Signature-256"
+
+	signaturePrefix = "sha256="
+	// maxErrorBodySize limits part of response body kept as delivery error
+	maxErrorBodySize = 512
+)
+
+// HTTPSender posts deliveries as JSON. Body is signed with HMAC-SHA256
+// of subscription secret and sent in SignatureHeader as "sha256=<hex>".
+type HTTPSender struct {
+	client *http.Client
+}
+
+func NewHTTPSender(timeout time.Duration) (*HTTPSender, error) {
+	if timeout <= 0 {
+		return nil, errors.New("timeout must be positive")
+	}
+
+	return &HTTPSender{
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (s *HTTPSender) Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) error {
+	body := delivery.Payload()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, strings.ToUpper(delivery.EventType().String()))
+	req.Header.Set(DeliveryHeader, delivery.ID().String())
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret(), body))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		snippet, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		if snippet = bytes.TrimSpace(snippet); len(snippet) == 0 {
+			return fmt.Errorf("subscriber responded with %s", resp.Status)
+		}
+		return fmt.Errorf("subscriber responded with %s: %s", resp.Status, snippet)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
+// Sign() returns value of SignatureHeader for given body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,22 @@
+package app
+
+import (
+	"time"
+)
+
+type AssignmentEventDTO struct {
+	Type       string
+	Actor      string
+	OccurredAt time.Time
+	// empty if event does not refer to user
+	UserKey        string
+	OldReviewerKey string
+	NewReviewerKey string
+	// set for pull request merged on code hosting without required approvals
+	MissingApprovals *int
+}
+
+type PullRequestHistoryDTO struct {
+	PullRequestKey string
+	Events         []*AssignmentEventDTO
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+// ForgePullRequestRef identifies pull request at code hosting
+type ForgePullRequestRef struct {
+	Provider domain.IdentityProvider
+	// Repository is "owner/repo" at GitHub and path of project at GitLab
+	Repository string
+	// Number is number of pull request at GitHub and iid of merge request at GitLab
+	Number int64
+}
+
+// ForgeClient changes requested reviewers of pull requests at code hosting
+type ForgeClient interface {
+	// RequestReviewers() adds reviewers with given logins to pull request
+	RequestReviewers(ctx context.Context, pullRequest ForgePullRequestRef, logins []string) error
+	// UnrequestReviewers() removes reviewers with given logins from pull request
+	UnrequestReviewers(ctx context.Context, pullRequest ForgePullRequestRef, logins []string) error
+}
+
+// ParseForgePullRequestKey() recognizes keys of pull requests received from code hosting:
+// "owner/repo#number" of GitHub and "group/project!iid" of GitLab.
+// Returns false for other keys.
+func ParseForgePullRequestKey(key string) (ForgePullRequestRef, bool) {
+	for _, format := range []struct {
+		provider  domain.IdentityProvider
+		separator string
+	}{
+		{provider: domain.ProviderGitHub, separator: "#"},
+		{provider: domain.ProviderGitLab, separator: "!"},
+	} {
+		repository, number, ok := cutLast(key, format.separator)
+		if !ok || !strings.Contains(repository, "/") {
+			continue
+		}
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n <= 0 {
+			continue
+		}
+
+		return ForgePullRequestRef{
+			Provider:   format.provider,
+			Repository: repository,
+			Number:     n,
+		}, true
+	}
+
+	return ForgePullRequestRef{}, false
+}
+
+func cutLast(s string, separator string) (before string, after string, found bool) {
+	i := strings.LastIndex(s, separator)
+	if i < 0 {
+		return s, "", false
+	}
+
+	return s[:i], s[i+len(separator):], true
+}
@@ -0,0 +1,38 @@
+package app
+
+type ForgePullRequestAction string
+
+const (
+	ForgePROpened   ForgePullRequestAction = "opened"
+	ForgePRReopened ForgePullRequestAction = "reopened"
+	ForgePRMerged   ForgePullRequestAction = "merged"
+	// ForgePRClosed means pull request was closed without merging
+	ForgePRClosed ForgePullRequestAction = "closed"
+	// ForgePRReady means draft was marked as ready for review
+	ForgePRReady ForgePullRequestAction = "ready"
+)
+
+// ForgePullRequestEventDTO is a change of pull request on code hosting
+// in a form independent of its webhook format
+type ForgePullRequestEventDTO struct {
+	Provider string
+	// DeliveryID identifies webhook delivery, retried deliveries have the same one.
+	// Empty if provider does not send it.
+	DeliveryID string
+	Action     ForgePullRequestAction
+	// Key identifies pull request in the service, e.g. "owner/repo#42"
+	Key         string
+	Title       string
+	AuthorLogin string
+	// Draft is set if opened pull request is not ready for review
+	Draft bool
+	// SenderLogin is an account, which triggered the event, recorded as actor
+	SenderLogin string
+}
+
+type ForgeEventResultDTO struct {
+	// Processed is false if event did not change anything, Reason explains why
+	Processed   bool
+	Reason      string
+	PullRequest *PullRequestDTO
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+// ForgeEventService applies pull request events received from code hosting
+type ForgeEventService interface {
+	// HandlePullRequestEvent() creates pull request or changes its status. Repeated events
+	// and retried deliveries are not processed again, so webhook retries are safe.
+	HandlePullRequestEvent(ctx context.Context, event *ForgePullRequestEventDTO) (*ForgeEventResultDTO, error)
+}
+
+type DefaultForgeEventService struct {
+	prService    PullRequestService
+	userRepo     domain.UserRepository
+	identityRepo domain.UserIdentityRepository
+	deliveryRepo domain.InboundDeliveryRepository
+	transactor   domain.Transactor
+}
+
+func NewDefaultForgeEventService(
+	pullRequestService PullRequestService,
+	userRepository domain.UserRepository,
+	userIdentityRepository domain.UserIdentityRepository,
+	inboundDeliveryRepository domain.InboundDeliveryRepository,
+	transactor domain.Transactor,
+) (*DefaultForgeEventService, error) {
+	if pullRequestService == nil {
+		return nil, errors.New("pullRequestService cannot be nil")
+	}
+	if userRepository == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if userIdentityRepository == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
+	if inboundDeliveryRepository == nil {
+		return nil, errors.New("inboundDeliveryRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+
+	return &DefaultForgeEventService{
+		prService:    pullRequestService,
+		userRepo:     userRepository,
+		identityRepo: userIdentityRepository,
+		deliveryRepo: inboundDeliveryRepository,
+		transactor:   transactor,
+	}, nil
+}
+
+func (s *DefaultForgeEventService) HandlePullRequestEvent(ctx context.Context, event *ForgePullRequestEventDTO) (*ForgeEventResultDTO, error) {
+	provider, err := domain.NewIdentityProvider(event.Provider)
+	if err := invalidField("provider", err); err != nil {
+		return nil, err
+	}
+	if event.SenderLogin != "" {
+		ctx = domain.WithActor(ctx, provider.String()+":"+domain.NormalizeLogin(event.SenderLogin))
+	}
+
+	if event.DeliveryID == "" {
+		return s.applyPullRequestEvent(ctx, provider, event)
+	}
+
+	var result *ForgeEventResultDTO
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		// delivery is claimed before the event is applied, so concurrent retries are not applied twice
+		claimed, err := s.deliveryRepo.Claim(ctx, provider, event.DeliveryID, time.Now())
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			result = ignored("delivery was already processed")
+			return nil
+		}
+
+		// claim is rolled back on failure, so provider's retry is processed
+		result, err = s.applyPullRequestEvent(ctx, provider, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *DefaultForgeEventService) applyPullRequestEvent(ctx context.Context, provider domain.IdentityProvider, event *ForgePullRequestEventDTO) (*ForgeEventResultDTO, error) {
+	switch event.Action {
+	case ForgePROpened:
+		return s.createPullRequest(ctx, provider, event)
+	case ForgePRReopened:
+		result, err := s.changeStatus(ctx, event, s.prService.Reopen)
+		if errors.Is(err, ErrNotFound) {
+			// pull request was opened before integration was set up
+			return s.createPullRequest(ctx, provider, event)
+		}
+		return result, err
+	case ForgePRReady:
+		return ignoreUntracked(s.changeStatus(ctx, event, s.prService.MarkAsReady))
+	case ForgePRClosed:
+		return ignoreUntracked(s.changeStatus(ctx, event, s.prService.Close))
+	case ForgePRMerged:
+		return s.mergePullRequest(ctx, event)
+	default:
+		return ignored(fmt.Sprintf("action %q is not supported", event.Action)), nil
+	}
+}
+
+func (s *DefaultForgeEventService) createPullRequest(ctx context.Context, provider domain.IdentityProvider, event *ForgePullRequestEventDTO) (*ForgeEventResultDTO, error) {
+	author, err := s.findUserByLogin(ctx, provider, event.AuthorLogin)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prService.CreatePullRequest(ctx, &NewPullRequestDTO{
+		Key:       event.Key,
+		Title:     event.Title,
+		AuthorKey: author.Key().Value(),
+		Draft:     event.Draft,
+	})
+	if errors.Is(err, ErrPRExists) {
+		return ignored("pull request already exists"), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &ForgeEventResultDTO{Processed: true, PullRequest: pr}, nil
+}
+
+func (s *DefaultForgeEventService) mergePullRequest(ctx context.Context, event *ForgePullRequestEventDTO) (*ForgeEventResultDTO, error) {
+	// merge has already happened on code hosting, so approvals required by team cannot prevent it
+	pr, err := s.prService.MarkAsMergedUpstream(ctx, event.Key)
+	if errors.Is(err, ErrNotFound) {
+		// pull request was opened before integration was set up
+		return ignored("pull request is not tracked"), nil
+	} else if errors.Is(err, ErrInvalidStatusTransition) {
+		return ignored("pull request is not open"), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &ForgeEventResultDTO{Processed: true, PullRequest: pr}, nil
+}
+
+// changeStatus() applies status transition to tracked pull request.
+// ErrNotFound is returned as is, so caller decides how to handle untracked one.
+func (s *DefaultForgeEventService) changeStatus(
+	ctx context.Context,
+	event *ForgePullRequestEventDTO,
+	transition func(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error),
+) (*ForgeEventResultDTO, error) {
+	pr, err := transition(ctx, event.Key, nil)
+	if errors.Is(err, ErrPRAlreadyMerged) {
+		return ignored("pull request is already merged"), nil
+	} else if errors.Is(err, ErrInvalidStatusTransition) {
+		return ignored(fmt.Sprintf("action %q is not allowed in current status", event.Action)), nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &ForgeEventResultDTO{Processed: true, PullRequest: pr}, nil
+}
+
+// ignoreUntracked() converts ErrNotFound into ignored event
+func ignoreUntracked(result *ForgeEventResultDTO, err error) (*ForgeEventResultDTO, error) {
+	if errors.Is(err, ErrNotFound) {
+		// pull request was opened before integration was set up
+		return ignored("pull request is not tracked"), nil
+	}
+	return result, err
+}
+
+// findUserByLogin() resolves account on code hosting to user linked to it
+func (s *DefaultForgeEventService) findUserByLogin(ctx context.Context, provider domain.IdentityProvider, login string) (*domain.User, error) {
+	identity, err := s.identityRepo.FindByLogin(ctx, provider, domain.NormalizeLogin(login))
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		return nil, fmt.Errorf("%w: no user linked to %s login=%s", ErrNotFound, provider, login)
+	}
+
+	user, err := s.userRepo.FindByID(ctx, identity.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: no such user with id=%s", ErrNotFound, identity.UserID())
+	}
+
+	return user, nil
+}
+
+func ignored(reason string) *ForgeEventResultDTO {
+	return &ForgeEventResultDTO{Processed: false, Reason: reason}
+}
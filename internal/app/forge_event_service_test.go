@@ -0,0 +1,208 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/alphameo/pr-reviewnager/internal/infra/db/memory"
+)
+
+type forgeFixture struct {
+	teamService  app.TeamService
+	userService  app.UserService
+	forgeService app.ForgeEventService
+}
+
+func newForgeFixture(t *testing.T) *forgeFixture {
+	t.Helper()
+
+	store := memory.NewStore()
+	userRepo, err := memory.NewUserRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identityRepo, err := memory.NewUserIdentityRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deliveryRepo, err := memory.NewInboundDeliveryRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamRepo, err := memory.NewTeamRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prRepo, err := memory.NewPullRequestRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotationRepo, err := memory.NewReviewerRotationRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventRepo, err := memory.NewAssignmentEventRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forgeCallRepo, err := memory.NewForgeCallRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactor, err := memory.NewTransactor(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, err := domain.NewRoundRobinReviewerSelector(rotationRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selectors, err := domain.NewTeamReviewerSelectorProvider(selector, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prDomainServ, err := domain.NewDefaultPullRequestDomainService(userRepo, prRepo, teamRepo, selectors, eventRepo, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamDomainServ, err := domain.NewDefaultTeamDomainService(userRepo, prRepo, teamRepo, selectors, eventRepo, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := &forgeFixture{}
+	if f.teamService, err = app.NewDefaultTeamService(teamDomainServ, teamRepo, userRepo, eventRepo, transactor); err != nil {
+		t.Fatal(err)
+	}
+	if f.userService, err = app.NewDefaultUserService(userRepo, identityRepo); err != nil {
+		t.Fatal(err)
+	}
+	// without clients reviewers are not pushed to code hosting
+	forgeSync, err := app.NewForgeReviewerSync(nil, forgeCallRepo, prRepo, identityRepo, app.ForgeReviewerSyncConfig{
+		PollInterval: time.Second,
+		BatchSize:    1,
+		Lease:        time.Second,
+		Retry:        domain.RetryPolicy{MaxAttempts: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	prService, err := app.NewDefaultPullRequestService(prDomainServ, prRepo, userRepo, teamRepo, eventRepo, forgeSync, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.forgeService, err = app.NewDefaultForgeEventService(prService, userRepo, identityRepo, deliveryRepo, transactor); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *forgeFixture) link(t *testing.T, userKey string, login string) {
+	t.Helper()
+
+	_, err := f.userService.LinkIdentity(context.Background(), &app.UserIdentityDTO{
+		UserKey:  userKey,
+		Provider: domain.ProviderGitHub.String(),
+		Login:    login,
+	})
+	if err != nil {
+		t.Fatalf("LinkIdentity() error = %v", err)
+	}
+}
+
+func TestHandlePullRequestEventDeduplicatesDeliveries(t *testing.T) {
+	event := func(deliveryID string, action app.ForgePullRequestAction, key string, login string) *app.ForgePullRequestEventDTO {
+		return &app.ForgePullRequestEventDTO{
+			Provider:    domain.ProviderGitHub.String(),
+			DeliveryID:  deliveryID,
+			Action:      action,
+			Key:         key,
+			Title:       "title",
+			AuthorLogin: login,
+			SenderLogin: login,
+		}
+	}
+
+	// steps are handled in order by the same service
+	steps := []struct {
+		name string
+		// linkLogin is linked to user u3 before the event is handled
+		linkLogin     string
+		event         *app.ForgePullRequestEventDTO
+		wantErr       error
+		wantProcessed bool
+	}{
+		{
+			name:          "new delivery",
+			event:         event("d-1", app.ForgePROpened, "org/app#1", "alice"),
+			wantProcessed: true,
+		},
+		{
+			name:  "replayed delivery",
+			event: event("d-1", app.ForgePROpened, "org/app#1", "alice"),
+		},
+		{
+			name:  "other delivery of the same change",
+			event: event("d-2", app.ForgePROpened, "org/app#1", "alice"),
+		},
+		{
+			name:    "failed delivery",
+			event:   event("d-3", app.ForgePROpened, "org/app#2", "carol"),
+			wantErr: app.ErrNotFound,
+		},
+		{
+			name:          "retry of failed delivery",
+			linkLogin:     "carol",
+			event:         event("d-3", app.ForgePROpened, "org/app#2", "carol"),
+			wantProcessed: true,
+		},
+		{
+			name:          "merge delivery",
+			event:         event("d-4", app.ForgePRMerged, "org/app#1", "bob"),
+			wantProcessed: true,
+		},
+		{
+			name:  "replayed merge delivery",
+			event: event("d-4", app.ForgePRMerged, "org/app#1", "bob"),
+		},
+	}
+
+	ctx := context.Background()
+	f := newForgeFixture(t)
+	err := f.teamService.CreateTeamWithUsers(ctx, &app.TeamWithUsersDTO{
+		TeamName: "backend",
+		TeamUsers: []*app.UserDTO{
+			{Key: "u1", Name: "Alice", Active: true},
+			{Key: "u2", Name: "Bob", Active: true},
+			{Key: "u3", Name: "Carol", Active: true},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.link(t, "u1", "alice")
+	f.link(t, "u2", "bob")
+
+	for _, step := range steps {
+		if step.linkLogin != "" {
+			f.link(t, "u3", step.linkLogin)
+		}
+
+		got, err := f.forgeService.HandlePullRequestEvent(ctx, step.event)
+		if !errors.Is(err, step.wantErr) {
+			t.Fatalf("%s: HandlePullRequestEvent() error = %v, want %v", step.name, err, step.wantErr)
+		}
+		if step.wantErr != nil {
+			continue
+		}
+		if got.Processed != step.wantProcessed {
+			t.Errorf("%s: HandlePullRequestEvent() processed = %t (%s), want %t",
+				step.name, got.Processed, got.Reason, step.wantProcessed)
+		}
+	}
+}
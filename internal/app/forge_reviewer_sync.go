@@ -0,0 +1,209 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type ForgeReviewerSyncConfig struct {
+	PollInterval time.Duration
+	// BatchSize limits number of calls pushed per poll
+	BatchSize int
+	// Lease is time during which claimed call is not retried by other workers
+	Lease time.Duration
+	Retry domain.RetryPolicy
+}
+
+// ForgeReviewerSync requests and unrequests assigned reviewers at code hosting.
+// Only pull requests with keys recognized by ParseForgePullRequestKey() are synchronized,
+// and only for providers with configured client. Calls are queued in transaction
+// of the change and pushed by Run(), so code hosting is never called in request path.
+type ForgeReviewerSync struct {
+	clients      map[domain.IdentityProvider]ForgeClient
+	callRepo     domain.ForgeCallRepository
+	prRepo       domain.PullRequestRepository
+	identityRepo domain.UserIdentityRepository
+	config       ForgeReviewerSyncConfig
+}
+
+// NewForgeReviewerSync() creates synchronization with given clients by provider.
+// Without clients nothing is synchronized.
+func NewForgeReviewerSync(
+	clients map[domain.IdentityProvider]ForgeClient,
+	forgeCallRepository domain.ForgeCallRepository,
+	pullRequestRepository domain.PullRequestRepository,
+	userIdentityRepository domain.UserIdentityRepository,
+	config ForgeReviewerSyncConfig,
+) (*ForgeReviewerSync, error) {
+	for provider, client := range clients {
+		if client == nil {
+			return nil, fmt.Errorf("client of %s cannot be nil", provider)
+		}
+	}
+	if forgeCallRepository == nil {
+		return nil, errors.New("forgeCallRepository cannot be nil")
+	}
+	if pullRequestRepository == nil {
+		return nil, errors.New("pullRequestRepository cannot be nil")
+	}
+	if userIdentityRepository == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
+	if config.PollInterval <= 0 || config.BatchSize <= 0 || config.Lease <= 0 || config.Retry.MaxAttempts <= 0 {
+		return nil, errors.New("poll interval, batch size, lease and max attempts must be positive")
+	}
+
+	return &ForgeReviewerSync{
+		clients:      clients,
+		callRepo:     forgeCallRepository,
+		prRepo:       pullRequestRepository,
+		identityRepo: userIdentityRepository,
+		config:       config,
+	}, nil
+}
+
+// RequestReviewers() queues request of given reviewers of pull request at code hosting.
+// It must be called in transaction, which changes reviewers, so the call is queued only if change is committed.
+func (s *ForgeReviewerSync) RequestReviewers(ctx context.Context, pullRequest *domain.PullRequest, reviewerIDs ...domain.ID) error {
+	return s.enqueue(ctx, pullRequest, domain.ForgeRequestReviewers, reviewerIDs)
+}
+
+// UnrequestReviewers() queues removal of review requests of given reviewers at code hosting.
+// It must be called in transaction, which changes reviewers, so the call is queued only if change is committed.
+func (s *ForgeReviewerSync) UnrequestReviewers(ctx context.Context, pullRequest *domain.PullRequest, reviewerIDs ...domain.ID) error {
+	return s.enqueue(ctx, pullRequest, domain.ForgeUnrequestReviewers, reviewerIDs)
+}
+
+func (s *ForgeReviewerSync) enqueue(ctx context.Context, pullRequest *domain.PullRequest, action domain.ForgeCallAction, reviewerIDs []domain.ID) error {
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+	ref, ok := ParseForgePullRequestKey(pullRequest.Key().Value())
+	if !ok || s.clients[ref.Provider] == nil {
+		return nil
+	}
+
+	return s.callRepo.Create(ctx, domain.NewForgeCall(pullRequest.ID(), action, reviewerIDs, time.Now()))
+}
+
+// call() resolves logins of reviewers at provider and sends them to code hosting.
+// Reviewers without linked account are skipped.
+func (s *ForgeReviewerSync) call(ctx context.Context, ref ForgePullRequestRef, action domain.ForgeCallAction, reviewerIDs []domain.ID) error {
+	identities, err := s.identityRepo.FindByUserIDs(ctx, ref.Provider, reviewerIDs)
+	if err != nil {
+		return err
+	}
+	if len(identities) == 0 {
+		return nil
+	}
+	logins := make([]string, len(identities))
+	for i, identity := range identities {
+		logins[i] = identity.Login()
+	}
+	slices.Sort(logins)
+
+	client := s.clients[ref.Provider]
+	switch action {
+	case domain.ForgeRequestReviewers:
+		return client.RequestReviewers(ctx, ref, logins)
+	case domain.ForgeUnrequestReviewers:
+		return client.UnrequestReviewers(ctx, ref, logins)
+	default:
+		return fmt.Errorf("unknown forge call action %q", action)
+	}
+}
+
+// Run() pushes queued calls every poll interval until ctx is cancelled
+func (s *ForgeReviewerSync) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.PushOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Forge call push failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// PushOnce() pushes queued calls, which are due, and retries failed ones. Calls are pushed
+// one by one in order of scheduling, so changes of the same pull request are not reordered.
+func (s *ForgeReviewerSync) PushOnce(ctx context.Context) error {
+	calls, err := s.callRepo.ClaimDue(ctx, time.Now(), s.config.Lease, s.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	errs := make([]error, 0)
+	for _, call := range calls {
+		if err := s.push(ctx, call); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *ForgeReviewerSync) push(ctx context.Context, call *domain.ForgeCall) error {
+	pr, err := s.prRepo.FindByID(ctx, call.PullRequestID())
+	if err != nil {
+		return err
+	}
+	if pr == nil {
+		// pull request was deleted, nothing to synchronize
+		call.RecordSuccess(time.Now())
+		return s.callRepo.Update(ctx, call)
+	}
+
+	ref, ok := ParseForgePullRequestKey(pr.Key().Value())
+	if !ok || s.clients[ref.Provider] == nil {
+		call.RecordFailure("forge client is not configured", time.Now(), s.config.Retry)
+		return s.callRepo.Update(ctx, call)
+	}
+
+	if err := s.call(ctx, ref, call.Action(), s.stillRelevant(pr, call)); err != nil {
+		if ctx.Err() != nil {
+			// call is retried after lease expires
+			return nil
+		}
+		log.Printf("Failed to %s of %s at %s, retrying later: %v", call.Action(), pr.Key(), ref.Provider, err)
+		call.RecordFailure(err.Error(), time.Now(), s.config.Retry)
+	} else {
+		call.RecordSuccess(time.Now())
+	}
+
+	return s.callRepo.Update(ctx, call)
+}
+
+// stillRelevant() returns reviewers of call, which were not changed after it was queued:
+// requested ones, which are still assigned to open pull request,
+// and unrequested ones, which were not assigned again
+func (s *ForgeReviewerSync) stillRelevant(pullRequest *domain.PullRequest, call *domain.ForgeCall) []domain.ID {
+	assigned := pullRequest.ReviewerIDs()
+	relevant := make([]domain.ID, 0, len(call.ReviewerIDs()))
+	for _, id := range call.ReviewerIDs() {
+		switch call.Action() {
+		case domain.ForgeRequestReviewers:
+			if pullRequest.Status() == domain.PROpen && slices.Contains(assigned, id) {
+				relevant = append(relevant, id)
+			}
+		case domain.ForgeUnrequestReviewers:
+			if !slices.Contains(assigned, id) {
+				relevant = append(relevant, id)
+			}
+		}
+	}
+
+	return relevant
+}
@@ -45,14 +45,30 @@ func PullRequestToDTO(entity *domain.PullRequest) (*PullRequestDTO, error) {
 		return nil, ErrNilDomainObj
 	}
 
+	reviewers := make([]ReviewerDTO, 0, len(entity.Reviewers()))
+	for _, reviewer := range entity.Reviewers() {
+		reviewers = append(reviewers, ReviewerDTO{
+			UserID:         reviewer.UserID,
+			State:          reviewer.State.String(),
+			AssignedAt:     reviewer.AssignedAt,
+			ReviewedAt:     reviewer.ReviewedAt,
+			FallbackTeamID: reviewer.FallbackTeamID,
+		})
+	}
+
 	return &PullRequestDTO{
-		ID:          entity.ID(),
-		Title:       entity.Title().String(),
-		AuthorID:    entity.AuthorID(),
-		CreatedAt:   entity.CreatedAt(),
-		Status:      entity.Status().String(),
-		MergedAt:    entity.MergedAt(),
-		ReviewerIDs: entity.ReviewerIDs(),
+		ID:           entity.ID(),
+		Key:          entity.Key().Value(),
+		Title:        entity.Title().String(),
+		AuthorID:     entity.AuthorID(),
+		TeamID:       entity.TeamID(),
+		CreatedAt:    entity.CreatedAt(),
+		Status:       entity.Status().String(),
+		MergedAt:     entity.MergedAt(),
+		ReviewerIDs:  entity.ReviewerIDs(),
+		Reviewers:    reviewers,
+		MaxReviewers: entity.MaxReviewers(),
+		Version:      entity.Version(),
 	}, nil
 }
 
@@ -66,12 +82,24 @@ func TeamToDTO(entity *domain.Team) (*TeamDTO, error) {
 	}
 
 	return &TeamDTO{
-		ID:      entity.ID(),
-		Name:    entity.Name().Value(),
-		UserIDs: entity.UserIDs(),
+		ID:       entity.ID(),
+		Key:      entity.Key().Value(),
+		Name:     entity.Name().Value(),
+		ParentID: entity.ParentID(),
+		UserIDs:  entity.UserIDs(),
+		Settings: TeamSettingsToDTO(entity.Settings()),
+		Version:  entity.Version(),
 	}, nil
 }
 
+func TeamSettingsToDTO(settings domain.TeamSettings) TeamSettingsDTO {
+	return TeamSettingsDTO{
+		MinReviewers:      settings.MinReviewers(),
+		MaxReviewers:      settings.MaxReviewers(),
+		RequiredApprovals: settings.RequiredApprovals(),
+	}
+}
+
 func TeamsToDTOs(entities []*domain.Team) ([]*TeamDTO, error) {
 	return EntitiesToDTOs(entities, TeamToDTO)
 }
@@ -83,6 +111,7 @@ func UserToDTO(user *domain.User) (*UserDTO, error) {
 
 	return &UserDTO{
 		ID:     user.ID(),
+		Key:    user.Key().Value(),
 		Name:   user.Name().Value(),
 		Active: user.Active(),
 	}, nil
@@ -92,6 +121,35 @@ func UsersToDTOs(users []*domain.User) ([]*UserDTO, error) {
 	return EntitiesToDTOs(users, UserToDTO)
 }
 
+func WebhookToDTO(s *domain.WebhookSubscription) *WebhookDTO {
+	eventTypes := make([]string, len(s.EventTypes()))
+	for i, t := range s.EventTypes() {
+		eventTypes[i] = t.String()
+	}
+
+	return &WebhookDTO{
+		ID:         s.ID().String(),
+		URL:        s.URL(),
+		EventTypes: eventTypes,
+		CreatedAt:  s.CreatedAt(),
+	}
+}
+
+func WebhookDeliveryToDTO(d *domain.WebhookDelivery) *WebhookDeliveryDTO {
+	return &WebhookDeliveryDTO{
+		ID:            d.ID().String(),
+		WebhookID:     d.SubscriptionID().String(),
+		EventID:       d.EventID().String(),
+		EventType:     d.EventType().String(),
+		Status:        d.Status().String(),
+		Attempts:      d.Attempts(),
+		NextAttemptAt: d.NextAttemptAt(),
+		LastError:     d.LastError(),
+		CreatedAt:     d.CreatedAt(),
+		DeliveredAt:   d.DeliveredAt(),
+	}
+}
+
 // To Domain
 
 func PullRequestToDomain(dto *PullRequestDTO) (*domain.PullRequest, error) {
@@ -107,15 +165,37 @@ func PullRequestToDomain(dto *PullRequestDTO) (*domain.PullRequest, error) {
 	if err != nil {
 		return nil, err
 	}
+	key, err := domain.NewExternalKey(dto.Key)
+	if err != nil {
+		return nil, err
+	}
+	reviewers := make([]domain.Reviewer, len(dto.Reviewers))
+	for i, reviewer := range dto.Reviewers {
+		state, err := domain.NewReviewState(reviewer.State)
+		if err != nil {
+			return nil, err
+		}
+		reviewers[i] = domain.Reviewer{
+			UserID:         reviewer.UserID,
+			State:          state,
+			AssignedAt:     reviewer.AssignedAt,
+			ReviewedAt:     reviewer.ReviewedAt,
+			FallbackTeamID: reviewer.FallbackTeamID,
+		}
+	}
 
 	pr := domain.ExistingPullRequest(
 		dto.ID,
+		key,
 		title,
 		dto.AuthorID,
+		dto.TeamID,
 		dto.CreatedAt,
 		status,
 		dto.MergedAt,
-		dto.ReviewerIDs,
+		reviewers,
+		dto.MaxReviewers,
+		dto.Version,
 	)
 	if err := pr.Validate(); err != nil {
 		return nil, err
@@ -137,8 +217,17 @@ func TeamToDomain(dto *TeamDTO) (*domain.Team, error) {
 	if err != nil {
 		return nil, err
 	}
+	key, err := domain.NewExternalKey(dto.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := TeamSettingsToDomain(&dto.Settings)
+	if err != nil {
+		return nil, err
+	}
 
-	team := domain.ExistingTeam(dto.ID, name, dto.UserIDs)
+	team := domain.ExistingTeam(dto.ID, key, name, dto.ParentID, dto.UserIDs, settings, dto.Version)
 	if err := team.Validate(); err != nil {
 		return nil, err
 	}
@@ -146,6 +235,14 @@ func TeamToDomain(dto *TeamDTO) (*domain.Team, error) {
 	return team, nil
 }
 
+func TeamSettingsToDomain(dto *TeamSettingsDTO) (domain.TeamSettings, error) {
+	if dto == nil {
+		return domain.TeamSettings{}, ErrNilDTO
+	}
+
+	return domain.NewTeamSettings(dto.MinReviewers, dto.MaxReviewers, dto.RequiredApprovals)
+}
+
 func TeamsToEntities(dtos []*TeamDTO) ([]*domain.Team, error) {
 	return DTOsToDomain(dtos, TeamToDomain)
 }
@@ -159,8 +256,12 @@ func UserToDomain(dto *UserDTO) (*domain.User, error) {
 	if err != nil {
 		return nil, err
 	}
+	key, err := domain.NewExternalKey(dto.Key)
+	if err != nil {
+		return nil, err
+	}
 
-	user := domain.ExistingUser(dto.ID, name, dto.Active)
+	user := domain.ExistingUser(dto.ID, key, name, dto.Active)
 	if err := user.Validate(); err != nil {
 		return nil, err
 	}
@@ -7,17 +7,47 @@ import (
 )
 
 type PullRequestDTO struct {
-	ID          domain.ID
-	Title       string
-	AuthorID    domain.ID
-	CreatedAt   time.Time
-	Status      string
-	MergedAt    *time.Time
-	ReviewerIDs []domain.ID
+	ID           domain.ID
+	Key          string
+	Title        string
+	AuthorID     domain.ID
+	AuthorKey    string
+	TeamID       *domain.ID
+	CreatedAt    time.Time
+	Status       string
+	MergedAt     *time.Time
+	ReviewerIDs  []domain.ID
+	ReviewerKeys []string
+	// Reviewers are in the same order as ReviewerIDs
+	Reviewers    []ReviewerDTO
+	MaxReviewers int
+	Version      int64
+}
+
+type ReviewerDTO struct {
+	UserID     domain.ID
+	UserKey    string
+	State      string
+	AssignedAt time.Time
+	ReviewedAt *time.Time
+	// FallbackTeamID is set if reviewer was drawn from ancestor of the team of pull request
+	FallbackTeamID   *domain.ID
+	FallbackTeamName *string
+}
+
+type ReviewDTO struct {
+	PullRequestKey string
+	UserKey        string
+	State          string
 }
 
 type NewPullRequestDTO struct {
-	ID       domain.ID
-	Title    string
-	AuthorID domain.ID
+	Key       string
+	Title     string
+	AuthorKey string
+	// TeamName is a team, which pool reviewers are drawn from.
+	// Primary team of the author is used if it is nil.
+	TeamName *string
+	// Draft pull request gets reviewers only when it is ready for review
+	Draft bool
 }
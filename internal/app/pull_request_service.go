@@ -2,31 +2,62 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 )
 
 type PullRequestService interface {
-	CreatePullRequest(pullRequest *NewPullRequestDTO) (*PullRequestDTO, error)
-	MarkAsMerged(pullRequestID domain.ID) (*PullRequestDTO, error)
-	ReassignReviewer(userID domain.ID, pullRequestID domain.ID) (*PullRequestWithNewReviewerIDDTO, error)
-	FindPullRequestsByReviewer(userID domain.ID) ([]*PullRequestDTO, error)
+	// CreatePullRequest() draws reviewers from the team with given name, which author must be a member of,
+	// or from primary team of the author if team is not given
+	CreatePullRequest(ctx context.Context, pullRequest *NewPullRequestDTO) (*PullRequestDTO, error)
+	// MarkAsMerged() and ReassignReviewer() fail with ErrVersionConflict
+	// if expectedVersions are set and pull request has none of them
+	MarkAsMerged(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error)
+	// MarkAsMergedUpstream() marks pull request merged on code hosting regardless of approvals required by team
+	MarkAsMergedUpstream(ctx context.Context, pullRequestKey string) (*PullRequestDTO, error)
+	ReassignReviewer(ctx context.Context, userKey string, pullRequestKey string, expectedVersions []int64) (*PullRequestWithNewReviewerIDDTO, error)
+	// SubmitReview() sets state of review of assigned reviewer. Fails with ErrVersionConflict
+	// if expectedVersions are set and pull request has none of them
+	SubmitReview(ctx context.Context, review *ReviewDTO, expectedVersions []int64) (*PullRequestDTO, error)
+	// MarkAsReady(), Close() and Reopen() move pull request to another status.
+	// Ready and reopened pull requests without reviewers get them as on creation.
+	// They fail with ErrVersionConflict if expectedVersions are set and pull request has none of them
+	MarkAsReady(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error)
+	Close(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error)
+	Reopen(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error)
+	FindPullRequestsByReviewer(ctx context.Context, userKey string) ([]*PullRequestDTO, error)
+	// FindHistory() returns assignment events of pull request in order of occurrence
+	FindHistory(ctx context.Context, pullRequestKey string) (*PullRequestHistoryDTO, error)
 }
 
 type PullRequestWithNewReviewerIDDTO struct {
-	PullRequest       *PullRequestDTO
-	NewReviewerUserID domain.ID
+	PullRequest        *PullRequestDTO
+	NewReviewerUserID  domain.ID
+	NewReviewerUserKey string
 }
 
 type DefaultPullRequestService struct {
 	prDomainServ domain.PullRequestDomainService
 	prRepo       domain.PullRequestRepository
+	userRepo     domain.UserRepository
+	teamRepo     domain.TeamRepository
+	eventRepo    domain.AssignmentEventRepository
+	forgeSync    *ForgeReviewerSync
+	transactor   domain.Transactor
 }
 
 func NewDefaultPullRequestService(
 	pullRequestDomainService domain.PullRequestDomainService,
 	pullRequestRepository domain.PullRequestRepository,
+	userRepository domain.UserRepository,
+	teamRepository domain.TeamRepository,
+	assignmentEventRepository domain.AssignmentEventRepository,
+	forgeReviewerSync *ForgeReviewerSync,
+	transactor domain.Transactor,
 ) (*DefaultPullRequestService, error) {
 	if pullRequestDomainService == nil {
 		return nil, errors.New("pullRequestDomainService cannot bi nil")
@@ -34,46 +65,154 @@ func NewDefaultPullRequestService(
 	if pullRequestRepository == nil {
 		return nil, errors.New("PullRequestRepository cannot be nil")
 	}
+	if userRepository == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if teamRepository == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if assignmentEventRepository == nil {
+		return nil, errors.New("assignmentEventRepository cannot be nil")
+	}
+	if forgeReviewerSync == nil {
+		return nil, errors.New("forgeReviewerSync cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
 
 	return &DefaultPullRequestService{
 		prDomainServ: pullRequestDomainService,
 		prRepo:       pullRequestRepository,
+		userRepo:     userRepository,
+		teamRepo:     teamRepository,
+		eventRepo:    assignmentEventRepository,
+		forgeSync:    forgeReviewerSync,
+		transactor:   transactor,
 	}, nil
 }
 
-func (s *DefaultPullRequestService) CreatePullRequest(pullRequest *NewPullRequestDTO) (*PullRequestDTO, error) {
+func (s *DefaultPullRequestService) CreatePullRequest(ctx context.Context, pullRequest *NewPullRequestDTO) (*PullRequestDTO, error) {
+	verr := &ValidationError{}
 	title, err := domain.NewPRTitle(pullRequest.Title)
+	if err := verr.collect("pull_request_name", err); err != nil {
+		return nil, err
+	}
+	key, err := domain.NewExternalKey(pullRequest.Key)
+	if err := verr.collect("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	authorKey, err := domain.NewExternalKey(pullRequest.AuthorKey)
+	if err := verr.collect("author_id", err); err != nil {
+		return nil, err
+	}
+	var teamName domain.TeamName
+	if pullRequest.TeamName != nil {
+		teamName, err = domain.NewTeamName(*pullRequest.TeamName)
+		if err := verr.collect("team_name", err); err != nil {
+			return nil, err
+		}
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	author, err := s.findUserByKey(ctx, authorKey)
 	if err != nil {
 		return nil, err
 	}
+	var team *domain.Team
+	if pullRequest.TeamName != nil {
+		team, err = s.teamRepo.FindByName(ctx, teamName.Value())
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+		}
+	}
 
-	entity, err := domain.NewPullRequestWithID(
-		pullRequest.ID,
+	newPullRequest := domain.NewPullRequest
+	if pullRequest.Draft {
+		newPullRequest = domain.NewDraftPullRequest
+	}
+	entity, err := newPullRequest(
+		key,
 		title,
-		pullRequest.AuthorID,
+		author.ID(),
 	)
 	if err != nil {
 		return nil, err
 	}
+	if team != nil {
+		entity.SetTeamID(team.ID())
+	}
 
-	pr, err := s.prDomainServ.CreateAndAssignReviewers(entity)
+	var pr *domain.PullRequest
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err = s.prDomainServ.CreateAndAssignReviewers(ctx, entity)
+		if err != nil {
+			return err
+		}
+		return s.forgeSync.RequestReviewers(ctx, pr, pr.ReviewerIDs()...)
+	})
 	if errors.Is(err, domain.ErrAuthorNotFound) || errors.Is(err, domain.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	} else if errors.Is(err, domain.ErrNotTeamMember) {
+		return nil, NewValidationError("team_name", "author is not a team member")
 	} else if errors.Is(err, domain.ErrPRAlreadyExists) {
 		return nil, ErrPRExists
+	} else if errors.Is(err, domain.ErrNoReviewCandidates) {
+		return nil, ErrNoCandidate
+	} else if errors.Is(err, domain.ErrRotationConflict) {
+		return nil, ErrRotationConflict
 	} else if err != nil {
 		return nil, err
 	}
+
 	dto, err := PullRequestToDTO(pr)
 	if err != nil {
 		return nil, err
 	}
+	if _, err := s.fillUserKeys(ctx, dto); err != nil {
+		return nil, err
+	}
 	return dto, nil
 }
 
-func (s *DefaultPullRequestService) MarkAsMerged(pullRequestID domain.ID) (*PullRequestDTO, error) {
-	pr, err := s.prDomainServ.MarkAsMerged(pullRequestID)
+func (s *DefaultPullRequestService) MarkAsMerged(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error) {
+	return s.merge(ctx, pullRequestKey, func(ctx context.Context, pullRequestID domain.ID) (*domain.PullRequest, error) {
+		return s.prDomainServ.MarkAsMerged(ctx, pullRequestID, expectedVersions)
+	})
+}
+
+func (s *DefaultPullRequestService) MarkAsMergedUpstream(ctx context.Context, pullRequestKey string) (*PullRequestDTO, error) {
+	return s.merge(ctx, pullRequestKey, s.prDomainServ.MarkAsMergedUpstream)
+}
+
+func (s *DefaultPullRequestService) merge(
+	ctx context.Context,
+	pullRequestKey string,
+	markAsMerged func(ctx context.Context, pullRequestID domain.ID) (*domain.PullRequest, error),
+) (*PullRequestDTO, error) {
+	key, err := domain.NewExternalKey(pullRequestKey)
+	if err := invalidField("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	existing, err := s.findPullRequestByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := markAsMerged(ctx, existing.ID())
 	if errors.Is(err, domain.ErrPRNotFound) {
 		return nil, ErrNotFound
+	} else if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if errors.Is(err, domain.ErrNotEnoughApprovals) {
+		return nil, ErrNotEnoughApprovals
+	} else if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return nil, ErrInvalidStatusTransition
 	} else if err != nil {
 		return nil, err
 	}
@@ -81,38 +220,372 @@ func (s *DefaultPullRequestService) MarkAsMerged(pullRequestID domain.ID) (*Pull
 	if err != nil {
 		return nil, err
 	}
+	if _, err := s.fillUserKeys(ctx, dto); err != nil {
+		return nil, err
+	}
 	return dto, nil
 }
 
-func (s *DefaultPullRequestService) ReassignReviewer(userID domain.ID, pullRequestID domain.ID) (*PullRequestWithNewReviewerIDDTO, error) {
-	newReviewer, err := s.prDomainServ.ReassignReviewer(userID, pullRequestID)
+func (s *DefaultPullRequestService) ReassignReviewer(ctx context.Context, userKey string, pullRequestKey string, expectedVersions []int64) (*PullRequestWithNewReviewerIDDTO, error) {
+	verr := &ValidationError{}
+	prKey, err := domain.NewExternalKey(pullRequestKey)
+	if err := verr.collect("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	key, err := domain.NewExternalKey(userKey)
+	if err := verr.collect("old_user_id", err); err != nil {
+		return nil, err
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	pr, err := s.findPullRequestByKey(ctx, prKey)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var newReviewer *domain.ReassignReviewerResponse
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		newReviewer, err = s.prDomainServ.ReassignReviewer(ctx, user.ID(), pr.ID(), expectedVersions)
+		if err != nil {
+			return err
+		}
+		if err := s.forgeSync.UnrequestReviewers(ctx, &newReviewer.PullRequest, user.ID()); err != nil {
+			return err
+		}
+		return s.forgeSync.RequestReviewers(ctx, &newReviewer.PullRequest, newReviewer.NewReviewerID)
+	})
 	if errors.Is(err, domain.ErrPRNotFound) || errors.Is(err, domain.ErrUserNotFound) {
 		return nil, ErrNotFound
 	} else if errors.Is(err, domain.ErrPRAlreadyMerged) {
 		return nil, ErrPRAlreadyMerged
+	} else if errors.Is(err, domain.ErrPRNotOpen) {
+		return nil, ErrPRNotOpen
 	} else if errors.Is(err, domain.ErrUserNotReviewer) {
 		return nil, ErrNotAssigned
 	} else if errors.Is(err, domain.ErrNoReviewCandidates) {
 		return nil, ErrNoCandidate
+	} else if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if errors.Is(err, domain.ErrRotationConflict) {
+		return nil, ErrRotationConflict
 	} else if err != nil {
 		return nil, err
 	}
+
 	d, err := PullRequestToDTO(&newReviewer.PullRequest)
 	if err != nil {
 		return nil, err
 	}
+	keys, err := s.fillUserKeys(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
 	return &PullRequestWithNewReviewerIDDTO{
-		PullRequest:       d,
-		NewReviewerUserID: newReviewer.NewReviewerID,
+		PullRequest:        d,
+		NewReviewerUserID:  newReviewer.NewReviewerID,
+		NewReviewerUserKey: keys[newReviewer.NewReviewerID],
+	}, nil
+}
+
+func (s *DefaultPullRequestService) SubmitReview(ctx context.Context, review *ReviewDTO, expectedVersions []int64) (*PullRequestDTO, error) {
+	verr := &ValidationError{}
+	prKey, err := domain.NewExternalKey(review.PullRequestKey)
+	if err := verr.collect("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	userKey, err := domain.NewExternalKey(review.UserKey)
+	if err := verr.collect("user_id", err); err != nil {
+		return nil, err
+	}
+	state, err := domain.NewReviewState(review.State)
+	if err := verr.collect("state", err); err != nil {
+		return nil, err
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.findPullRequestByKey(ctx, prKey)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.findUserByKey(ctx, userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := s.prDomainServ.SubmitReview(ctx, user.ID(), existing.ID(), state, expectedVersions)
+	if errors.Is(err, domain.ErrPRNotFound) {
+		return nil, ErrNotFound
+	} else if errors.Is(err, domain.ErrPRAlreadyMerged) {
+		return nil, ErrPRAlreadyMerged
+	} else if errors.Is(err, domain.ErrPRNotOpen) {
+		return nil, ErrPRNotOpen
+	} else if errors.Is(err, domain.ErrUserNotReviewer) {
+		return nil, ErrNotAssigned
+	} else if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if err != nil {
+		return nil, err
+	}
+	dto, err := PullRequestToDTO(pr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.fillUserKeys(ctx, dto); err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
+func (s *DefaultPullRequestService) MarkAsReady(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error) {
+	return s.changeStatus(ctx, pullRequestKey, expectedVersions, s.prDomainServ.MarkAsReady)
+}
+
+func (s *DefaultPullRequestService) Close(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error) {
+	return s.changeStatus(ctx, pullRequestKey, expectedVersions, s.prDomainServ.Close)
+}
+
+func (s *DefaultPullRequestService) Reopen(ctx context.Context, pullRequestKey string, expectedVersions []int64) (*PullRequestDTO, error) {
+	return s.changeStatus(ctx, pullRequestKey, expectedVersions, s.prDomainServ.Reopen)
+}
+
+// changeStatus() applies status transition of domain service to pull request
+// and requests review of reviewers assigned by the transition
+func (s *DefaultPullRequestService) changeStatus(
+	ctx context.Context,
+	pullRequestKey string,
+	expectedVersions []int64,
+	transition func(ctx context.Context, pullRequestID domain.ID, expectedVersions []int64) (*domain.PullRequest, error),
+) (*PullRequestDTO, error) {
+	key, err := domain.NewExternalKey(pullRequestKey)
+	if err := invalidField("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	existing, err := s.findPullRequestByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	var pr *domain.PullRequest
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err = transition(ctx, existing.ID(), expectedVersions)
+		if err != nil {
+			return err
+		}
+
+		assigned := make([]domain.ID, 0, len(pr.ReviewerIDs()))
+		for _, id := range pr.ReviewerIDs() {
+			if !slices.Contains(existing.ReviewerIDs(), id) {
+				assigned = append(assigned, id)
+			}
+		}
+		return s.forgeSync.RequestReviewers(ctx, pr, assigned...)
+	})
+	if errors.Is(err, domain.ErrPRNotFound) || errors.Is(err, domain.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	} else if errors.Is(err, domain.ErrPRAlreadyMerged) {
+		return nil, ErrPRAlreadyMerged
+	} else if errors.Is(err, domain.ErrInvalidStatusTransition) {
+		return nil, ErrInvalidStatusTransition
+	} else if errors.Is(err, domain.ErrNoReviewCandidates) {
+		return nil, ErrNoCandidate
+	} else if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if errors.Is(err, domain.ErrRotationConflict) {
+		return nil, ErrRotationConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	dto, err := PullRequestToDTO(pr)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.fillUserKeys(ctx, dto); err != nil {
+		return nil, err
+	}
+	return dto, nil
+}
+
+func (s *DefaultPullRequestService) FindPullRequestsByReviewer(ctx context.Context, userKey string) ([]*PullRequestDTO, error) {
+	key, err := domain.NewExternalKey(userKey)
+	if err := invalidField("user_id", err); err != nil {
+		return nil, err
+	}
+	user, err := s.userRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return []*PullRequestDTO{}, nil
+	}
+
+	prs, err := s.prRepo.FindPullRequestsByReviewer(ctx, user.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	dtos, err := PullRequestsToDTOs(prs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.fillUserKeys(ctx, dtos...); err != nil {
+		return nil, err
+	}
+	return dtos, nil
+}
+
+func (s *DefaultPullRequestService) FindHistory(ctx context.Context, pullRequestKey string) (*PullRequestHistoryDTO, error) {
+	key, err := domain.NewExternalKey(pullRequestKey)
+	if err := invalidField("pull_request_id", err); err != nil {
+		return nil, err
+	}
+	pr, err := s.findPullRequestByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.FindByPullRequestID(ctx, pr.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]domain.ID, 0, len(events))
+	for _, e := range events {
+		for _, id := range []*domain.ID{e.UserID, e.OldReviewerID, e.NewReviewerID} {
+			if id != nil {
+				ids = append(ids, *id)
+			}
+		}
+	}
+	users, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[domain.ID]string, len(users))
+	for _, user := range users {
+		keys[user.ID()] = user.Key().Value()
+	}
+	keyOf := func(id *domain.ID) string {
+		if id == nil {
+			return ""
+		}
+		return keys[*id]
+	}
+
+	dtos := make([]*AssignmentEventDTO, len(events))
+	for i, e := range events {
+		dtos[i] = &AssignmentEventDTO{
+			Type:             e.Type.String(),
+			Actor:            e.Actor,
+			OccurredAt:       e.OccurredAt,
+			UserKey:          keyOf(e.UserID),
+			OldReviewerKey:   keyOf(e.OldReviewerID),
+			NewReviewerKey:   keyOf(e.NewReviewerID),
+			MissingApprovals: e.MissingApprovals,
+		}
+	}
+
+	return &PullRequestHistoryDTO{
+		PullRequestKey: pr.Key().Value(),
+		Events:         dtos,
 	}, nil
 }
 
-func (s *DefaultPullRequestService) FindPullRequestsByReviewer(userID domain.ID) ([]*PullRequestDTO, error) {
-	prs, err := s.prRepo.FindPullRequestsByReviewer(userID)
+func (s *DefaultPullRequestService) findUserByKey(ctx context.Context, key domain.ExternalKey) (*domain.User, error) {
+	user, err := s.userRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: no such user with key=%s", ErrNotFound, key)
+	}
+
+	return user, nil
+}
+
+func (s *DefaultPullRequestService) findPullRequestByKey(ctx context.Context, key domain.ExternalKey) (*domain.PullRequest, error) {
+	pr, err := s.prRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if pr == nil {
+		return nil, fmt.Errorf("%w: no such pull request with key=%s", ErrNotFound, key)
+	}
+
+	return pr, nil
+}
+
+// fillUserKeys() sets external keys of authors and reviewers of given pull requests
+// with names of fallback teams of reviewers and returns resolved keys by user ids
+func (s *DefaultPullRequestService) fillUserKeys(ctx context.Context, dtos ...*PullRequestDTO) (map[domain.ID]string, error) {
+	ids := make([]domain.ID, 0, len(dtos)*(1+domain.DefaultMaxReviewersCount))
+	for _, dto := range dtos {
+		ids = append(ids, dto.AuthorID)
+		ids = append(ids, dto.ReviewerIDs...)
+	}
+
+	users, err := s.userRepo.FindByIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
+	keys := make(map[domain.ID]string, len(users))
+	for _, user := range users {
+		keys[user.ID()] = user.Key().Value()
+	}
+
+	for _, dto := range dtos {
+		dto.AuthorKey = keys[dto.AuthorID]
+		dto.ReviewerKeys = make([]string, len(dto.ReviewerIDs))
+		for i, reviewerID := range dto.ReviewerIDs {
+			dto.ReviewerKeys[i] = keys[reviewerID]
+		}
+		for i := range dto.Reviewers {
+			dto.Reviewers[i].UserKey = keys[dto.Reviewers[i].UserID]
+		}
+	}
+
+	if err := s.fillFallbackTeamNames(ctx, dtos...); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// fillFallbackTeamNames() sets names of ancestor teams, which reviewers of given pull requests
+// were drawn from
+func (s *DefaultPullRequestService) fillFallbackTeamNames(ctx context.Context, dtos ...*PullRequestDTO) error {
+	names := make(map[domain.ID]*string)
+	for _, dto := range dtos {
+		for i := range dto.Reviewers {
+			teamID := dto.Reviewers[i].FallbackTeamID
+			if teamID == nil {
+				continue
+			}
+
+			name, ok := names[*teamID]
+			if !ok {
+				team, err := s.teamRepo.FindByID(ctx, *teamID)
+				if err != nil {
+					return err
+				}
+				if team != nil {
+					value := team.Name().Value()
+					name = &value
+				}
+				names[*teamID] = name
+			}
+			dto.Reviewers[i].FallbackTeamName = name
+		}
+	}
 
-	return PullRequestsToDTOs(prs)
+	return nil
 }
@@ -0,0 +1,30 @@
+package app
+
+import "time"
+
+type UserStatsDTO struct {
+	UserKey          string
+	UserName         string
+	TotalAssignments int
+	OpenAssignments  int
+	MergedReviews    int
+	ReassignedAway   int
+}
+
+type PullRequestStatsDTO struct {
+	PullRequestKey    string
+	Title             string
+	Status            string
+	CreatedAt         time.Time
+	MergedAt          *time.Time
+	TimeToMerge       *time.Duration
+	ReviewersAssigned int
+	Reassignments     int
+}
+
+type StatsDTO struct {
+	// empty for stats of all teams
+	TeamName     string
+	Users        []*UserStatsDTO
+	PullRequests []*PullRequestStatsDTO
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type StatsService interface {
+	// GetStats() returns review assignment stats of all users and pull requests
+	GetStats(ctx context.Context) (*StatsDTO, error)
+	// GetTeamStats() returns review assignment stats of team members and pull requests authored by them
+	GetTeamStats(ctx context.Context, teamName string) (*StatsDTO, error)
+}
+
+type DefaultStatsService struct {
+	statsRepo domain.StatsRepository
+	teamRepo  domain.TeamRepository
+}
+
+func NewDefaultStatsService(
+	statsRepository domain.StatsRepository,
+	teamRepository domain.TeamRepository,
+) (*DefaultStatsService, error) {
+	if statsRepository == nil {
+		return nil, errors.New("statsRepository cannot be nil")
+	}
+	if teamRepository == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+
+	return &DefaultStatsService{
+		statsRepo: statsRepository,
+		teamRepo:  teamRepository,
+	}, nil
+}
+
+func (s *DefaultStatsService) GetStats(ctx context.Context) (*StatsDTO, error) {
+	return s.collectStats(ctx, nil)
+}
+
+func (s *DefaultStatsService) GetTeamStats(ctx context.Context, teamName string) (*StatsDTO, error) {
+	name, err := domain.NewTeamName(teamName)
+	if err := invalidField("team_name", err); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.FindByName(ctx, name.Value())
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, name)
+	}
+
+	teamID := team.ID()
+	stats, err := s.collectStats(ctx, &teamID)
+	if err != nil {
+		return nil, err
+	}
+	stats.TeamName = team.Name().Value()
+
+	return stats, nil
+}
+
+func (s *DefaultStatsService) collectStats(ctx context.Context, teamID *domain.ID) (*StatsDTO, error) {
+	userStats, err := s.statsRepo.FindUserReviewStats(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	prStats, err := s.statsRepo.FindPullRequestReviewStats(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*UserStatsDTO, len(userStats))
+	for i, us := range userStats {
+		users[i] = &UserStatsDTO{
+			UserKey:          us.UserKey.Value(),
+			UserName:         us.UserName.Value(),
+			TotalAssignments: us.TotalAssignments,
+			OpenAssignments:  us.OpenAssignments,
+			MergedReviews:    us.MergedReviews,
+			ReassignedAway:   us.ReassignedAway,
+		}
+	}
+
+	pullRequests := make([]*PullRequestStatsDTO, len(prStats))
+	for i, ps := range prStats {
+		pullRequests[i] = &PullRequestStatsDTO{
+			PullRequestKey:    ps.PullRequestKey.Value(),
+			Title:             ps.Title.Value(),
+			Status:            ps.Status.String(),
+			CreatedAt:         ps.CreatedAt,
+			MergedAt:          ps.MergedAt,
+			TimeToMerge:       ps.TimeToMerge(),
+			ReviewersAssigned: ps.ReviewersAssigned,
+			Reassignments:     ps.Reassignments,
+		}
+	}
+
+	return &StatsDTO{
+		Users:        users,
+		PullRequests: pullRequests,
+	}, nil
+}
@@ -3,12 +3,90 @@ package app
 import "github.com/alphameo/pr-reviewnager/internal/domain"
 
 type TeamDTO struct {
-	ID      domain.ID
-	Name    string
-	UserIDs []domain.ID
+	ID       domain.ID
+	Key      string
+	Name     string
+	ParentID *domain.ID
+	UserIDs  []domain.ID
+	Settings TeamSettingsDTO
+	Version  int64
+}
+
+type TeamSettingsDTO struct {
+	MinReviewers      int
+	MaxReviewers      int
+	RequiredApprovals int
 }
 
 type TeamWithUsersDTO struct {
-	TeamName  string
+	TeamName string
+	// ignored on creation, key is given to team by the service
+	TeamKey   string
 	TeamUsers []*UserDTO
+	// nil settings mean defaults on creation
+	Settings *TeamSettingsDTO
+	// nil if team has no parent, ignored on creation
+	ParentTeamName *string
+	// ignored on creation
+	Version int64
+}
+
+type ReassignmentDTO struct {
+	PullRequestKey string
+	OldReviewerKey string
+	NewReviewerKey string
+}
+
+type FailedReassignmentDTO struct {
+	PullRequestKey string
+	ReviewerKey    string
+	Reason         error
+}
+
+type DeactivationResultDTO struct {
+	TeamName         string
+	DeactivatedUsers []*UserDTO
+	Reassigned       []*ReassignmentDTO
+	NotReassigned    []*FailedReassignmentDTO
+}
+
+// MembershipResultDTO is a team after membership change with reviews of the user,
+// who left another team
+type MembershipResultDTO struct {
+	Team *TeamWithUsersDTO
+	// version of the team, which the user left on move, nil for other changes
+	SourceTeamVersion *int64
+	Reassigned        []*ReassignmentDTO
+	NotReassigned     []*FailedReassignmentDTO
+}
+
+type TeamDeletionResultDTO struct {
+	TeamName string
+	Policy   string
+	// former members of the team
+	Members []*UserDTO
+	// members deactivated by policy, because they have no other teams
+	Deactivated   []*UserDTO
+	Reassigned    []*ReassignmentDTO
+	NotReassigned []*FailedReassignmentDTO
+}
+
+// MemberChangeDTO is a member added to team or updated by reconciliation
+type MemberChangeDTO struct {
+	User *UserDTO
+	// previous values of changed fields, nil for unchanged fields and new users
+	PreviousName   *string
+	PreviousActive *bool
+}
+
+// TeamReconciliationDTO is a diff between stored team and posted one
+type TeamReconciliationDTO struct {
+	// resulting team, not stored on dry run
+	Team            *TeamWithUsersDTO
+	DryRun          bool
+	Created         bool
+	SettingsUpdated bool
+	Added           []*MemberChangeDTO
+	Updated         []*MemberChangeDTO
+	Removed         []*UserDTO
 }
@@ -1,16 +1,40 @@
 package app
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 )
 
 type TeamService interface {
-	CreateTeamWithUsers(teamDTO *TeamWithUsersDTO) error
-	FindTeamByName(name string) (*TeamWithUsersDTO, error)
-	SetUserActiveByID(userID domain.ID, active bool) (*UserWithTeamNameDTO, error)
+	CreateTeamWithUsers(ctx context.Context, teamDTO *TeamWithUsersDTO) error
+	// ReconcileTeam() creates or updates the team so that it consists of given members:
+	// adds new members, updates names and activity of users and removes missing members.
+	// Other teams of the members are not changed. Nothing is stored on dry run.
+	ReconcileTeam(ctx context.Context, teamDTO *TeamWithUsersDTO, dryRun bool, expectedVersions []int64) (*TeamReconciliationDTO, error)
+	FindTeamByName(ctx context.Context, name string) (*TeamWithUsersDTO, error)
+	SetUserActiveByKey(ctx context.Context, userKey string, active bool) (*UserWithTeamNameDTO, error)
+	// SetPrimaryTeam() makes the team primary for its member
+	SetPrimaryTeam(ctx context.Context, userKey string, teamName string) (*UserWithTeamNameDTO, error)
+	// DeactivateUsers() deactivates team members and reassigns their open reviews
+	DeactivateUsers(ctx context.Context, teamName string, userKeys []string) (*DeactivationResultDTO, error)
+	// AddMember() adds existing user to the team, keeping other teams of the user
+	AddMember(ctx context.Context, teamName string, userKey string, expectedVersions []int64) (*MembershipResultDTO, error)
+	// RemoveMember() removes user from the team, optionally reassigning open reviews of the user on team pull requests
+	RemoveMember(ctx context.Context, teamName string, userKey string, reassignReviews bool, expectedVersions []int64) (*MembershipResultDTO, error)
+	// MoveMember() moves user to another team in a single transaction. Expected versions are checked against the source team
+	MoveMember(ctx context.Context, fromTeamName string, toTeamName string, userKey string, reassignReviews bool, expectedVersions []int64) (*MembershipResultDTO, error)
+	// RenameTeam() changes name of the team, renaming to the current name changes nothing.
+	// Key of the team is not changed
+	RenameTeam(ctx context.Context, teamName string, newTeamName string, expectedVersions []int64) (*TeamWithUsersDTO, error)
+	// SetParentTeam() attaches the team to parent team, which reviewers are drawn from when the team lacks
+	// candidates, or detaches it from hierarchy if parentTeamName is nil. Setting of the current parent changes nothing
+	SetParentTeam(ctx context.Context, teamName string, parentTeamName *string, expectedVersions []int64) (*TeamWithUsersDTO, error)
+	// DeleteTeam() deletes the team with handling of open pull requests defined by policy
+	DeleteTeam(ctx context.Context, teamName string, policy string, expectedVersions []int64) (*TeamDeletionResultDTO, error)
 }
 
 var (
@@ -20,67 +44,452 @@ var (
 	ErrNoCandidate     error = errors.New("no active candidate for assigning")
 	ErrNotFound        error = errors.New("resource not found")
 	ErrNotAssigned     error = errors.New("reviewer is not assigned to PR")
+	ErrVersionConflict error = errors.New("resource was modified concurrently")
+	// ErrNotEnoughApprovals is returned on merge of pull request lacking approvals required by team
+	ErrNotEnoughApprovals error = errors.New("not enough approvals")
+	// ErrPRNotOpen is returned on change of reviewers of draft or closed pull request
+	ErrPRNotOpen error = errors.New("pull request is not open")
+	// ErrInvalidStatusTransition is returned when pull request cannot move to requested status
+	ErrInvalidStatusTransition error = errors.New("invalid pull request status transition")
+	// ErrTeamHasOpenPRs is returned on deletion of team, which has open pull requests
+	ErrTeamHasOpenPRs error = errors.New("team has open pull requests")
+	// ErrRotationConflict is returned when reviewers cannot be selected, as team rotation
+	// is advanced by concurrent requests on every attempt
+	ErrRotationConflict error = errors.New("reviewer rotation was advanced concurrently")
 )
 
 type DefaultTeamService struct {
-	teamRepo domain.TeamRepository
-	userRepo domain.UserRepository
+	teamDomainServ domain.TeamDomainService
+	teamRepo       domain.TeamRepository
+	userRepo       domain.UserRepository
+	eventRepo      domain.AssignmentEventRepository
+	transactor     domain.Transactor
 }
 
 func NewDefaultTeamService(
+	teamDomainService domain.TeamDomainService,
 	teamRepository domain.TeamRepository,
 	userRepository domain.UserRepository,
+	assignmentEventRepository domain.AssignmentEventRepository,
+	transactor domain.Transactor,
 ) (*DefaultTeamService, error) {
+	if teamDomainService == nil {
+		return nil, errors.New("teamDomainService cannot be nil")
+	}
 	if teamRepository == nil {
 		return nil, errors.New("teamRepository cannot be nil")
 	}
 	if userRepository == nil {
 		return nil, errors.New("userRepository cannot be nil")
 	}
+	if assignmentEventRepository == nil {
+		return nil, errors.New("assignmentEventRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
 
 	return &DefaultTeamService{
-		teamRepo: teamRepository,
-		userRepo: userRepository,
+		teamDomainServ: teamDomainService,
+		teamRepo:       teamRepository,
+		userRepo:       userRepository,
+		eventRepo:      assignmentEventRepository,
+		transactor:     transactor,
 	}, nil
 }
 
-func (s *DefaultTeamService) CreateTeamWithUsers(teamDTO *TeamWithUsersDTO) error {
+func (s *DefaultTeamService) CreateTeamWithUsers(ctx context.Context, teamDTO *TeamWithUsersDTO) error {
 	if teamDTO == nil {
 		return errors.New("dto cannot be nil")
 	}
-	existingTeam, err := s.teamRepo.FindByName(teamDTO.TeamName)
+	existingTeam, err := s.teamRepo.FindByName(ctx, teamDTO.TeamName)
 	if err != nil {
-		return ErrTeamExists
+		return err
 	}
 	if existingTeam != nil {
-		return fmt.Errorf("team with name=%s already exists", teamDTO.TeamName)
+		return fmt.Errorf("%w: name=%s", ErrTeamExists, teamDTO.TeamName)
 	}
 
-	name, err := domain.NewTeamName(teamDTO.TeamName)
+	input, err := s.parseTeam(ctx, teamDTO)
 	if err != nil {
 		return err
 	}
 
-	team, err := domain.NewTeam(name)
+	key, err := s.newTeamKey(ctx, input.key)
 	if err != nil {
 		return err
 	}
-
-	users, err := UsersToDomain(teamDTO.TeamUsers)
+	team, err := domain.NewTeam(key, input.name)
 	if err != nil {
 		return err
 	}
+	if input.settings != nil {
+		if err := team.SetSettings(*input.settings); err != nil {
+			return err
+		}
+	}
+	for _, user := range input.users {
+		if err := team.AddUser(user.ID()); err != nil {
+			return err
+		}
+	}
+
+	return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.CreateTeamAndModifyUsers(ctx, team, input.users); err != nil {
+			return err
+		}
+		return s.eventRepo.Append(ctx, domain.TeamCreatedEvent(ctx, team))
+	})
+}
+
+// newTeamKey() returns key for new team. Keys do not change on rename, so the key made of name
+// may be kept by renamed team, then new team gets generated key.
+func (s *DefaultTeamService) newTeamKey(ctx context.Context, key domain.ExternalKey) (domain.ExternalKey, error) {
+	existing, err := s.teamRepo.FindByKey(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	if existing == nil {
+		return key, nil
+	}
+
+	return domain.ExistingExternalKey(domain.NewID().String()), nil
+}
+
+// teamInput is a validated team dto with resolved members
+type teamInput struct {
+	name domain.TeamName
+	key  domain.ExternalKey
+	// nil if settings are not given
+	settings *domain.TeamSettings
+	users    []*domain.User
+}
+
+// parseTeam() validates dto and maps its members to users, collecting all invalid fields
+func (s *DefaultTeamService) parseTeam(ctx context.Context, teamDTO *TeamWithUsersDTO) (*teamInput, error) {
+	if teamDTO == nil {
+		return nil, ErrNilDTO
+	}
+
+	verr := &ValidationError{}
+	name, err := domain.NewTeamName(teamDTO.TeamName)
+	if err := verr.collect("team_name", err); err != nil {
+		return nil, err
+	}
+	// API identifies teams by their names, so name becomes external key of new team
+	key, err := domain.NewExternalKey(teamDTO.TeamName)
+	if name != "" {
+		if err := verr.collect("team_name", err); err != nil {
+			return nil, err
+		}
+	}
+
+	input := &teamInput{name: name, key: key}
+	if teamDTO.Settings != nil {
+		settings, err := TeamSettingsToDomain(teamDTO.Settings)
+		field := "min_reviewers"
+		if errors.Is(err, domain.ErrInvalidRequiredApprovals) {
+			field = "required_approvals"
+		}
+		if err := verr.collect(field, err); err != nil {
+			return nil, err
+		}
+		input.settings = &settings
+	}
+
+	input.users = make([]*domain.User, 0, len(teamDTO.TeamUsers))
+	for i, userDTO := range teamDTO.TeamUsers {
+		field := fmt.Sprintf("members[%d]", i)
+		user, err := s.resolveUser(ctx, field, userDTO, verr)
+		if err != nil {
+			return nil, err
+		}
+		if user == nil {
+			continue
+		}
+		duplicated := slices.ContainsFunc(input.users, func(u *domain.User) bool {
+			return u.Key() == user.Key()
+		})
+		if duplicated {
+			verr.Violations = append(verr.Violations, FieldViolation{
+				Field:  field + ".user_id",
+				Reason: "duplicated member",
+			})
+			continue
+		}
+		input.users = append(input.users, user)
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	return input, nil
+}
+
+func (s *DefaultTeamService) ReconcileTeam(ctx context.Context, teamDTO *TeamWithUsersDTO, dryRun bool, expectedVersions []int64) (*TeamReconciliationDTO, error) {
+	input, err := s.parseTeam(ctx, teamDTO)
+	if err != nil {
+		return nil, err
+	}
+
+	var plan *reconciliationPlan
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		plan, err = s.planReconciliation(ctx, input, expectedVersions)
+		if err != nil || dryRun || !plan.changed() {
+			return err
+		}
+
+		return s.applyReconciliation(ctx, plan)
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	dto, err := plan.toDTO(input.users, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	dto.Team.ParentTeamName, err = s.parentTeamName(ctx, plan.team)
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+type memberChange struct {
+	user *domain.User
+	// stored user, nil for new one
+	previous *domain.User
+}
+
+type reconciliationPlan struct {
+	team            *domain.Team
+	created         bool
+	settingsUpdated bool
+	added           []memberChange
+	updated         []memberChange
+	removed         []*domain.User
+	// new and changed users
+	users []*domain.User
+}
+
+func (p *reconciliationPlan) changed() bool {
+	return p.created || p.settingsUpdated || len(p.added)+len(p.updated)+len(p.removed) > 0
+}
+
+// planReconciliation() changes the team and its members in memory.
+// Stored team must have one of expectedVersions if they are set.
+func (s *DefaultTeamService) planReconciliation(ctx context.Context, input *teamInput, expectedVersions []int64) (*reconciliationPlan, error) {
+	team, err := s.teamRepo.FindByName(ctx, input.name.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &reconciliationPlan{team: team}
+	if team == nil {
+		if expectedVersions != nil {
+			return nil, fmt.Errorf("%w: no such team with name=%s", domain.ErrVersionConflict, input.name)
+		}
+		plan.created = true
+		key, err := s.newTeamKey(ctx, input.key)
+		if err != nil {
+			return nil, err
+		}
+		plan.team, err = domain.NewTeam(key, input.name)
+		if err != nil {
+			return nil, err
+		}
+	} else if err := domain.CheckVersion(team.Version(), expectedVersions); err != nil {
+		return nil, err
+	}
+
+	if input.settings != nil && *input.settings != plan.team.Settings() {
+		if err := plan.team.SetSettings(*input.settings); err != nil {
+			return nil, err
+		}
+		plan.settingsUpdated = !plan.created
+	}
+
+	ids := make([]domain.ID, len(input.users))
+	for i, user := range input.users {
+		ids[i] = user.ID()
+	}
+	stored, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	storedByID := make(map[domain.ID]*domain.User, len(stored))
+	for _, user := range stored {
+		storedByID[user.ID()] = user
+	}
+
+	members := plan.team.UserIDs()
+	for _, user := range input.users {
+		change := memberChange{user: user, previous: storedByID[user.ID()]}
+		changed := change.previous == nil ||
+			change.previous.Name() != user.Name() ||
+			change.previous.Active() != user.Active()
+		if changed {
+			plan.users = append(plan.users, user)
+		}
+
+		if slices.Contains(members, user.ID()) {
+			if changed {
+				plan.updated = append(plan.updated, change)
+			}
+			continue
+		}
+
+		if err := plan.team.AddUser(user.ID()); err != nil {
+			return nil, err
+		}
+		plan.added = append(plan.added, change)
+	}
+
+	removedIDs := make([]domain.ID, 0)
+	for _, userID := range members {
+		if !slices.Contains(ids, userID) {
+			removedIDs = append(removedIDs, userID)
+			if err := plan.team.RemoveUser(userID); err != nil {
+				return nil, err
+			}
+		}
+	}
+	plan.removed, err = s.userRepo.FindByIDs(ctx, removedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+func (s *DefaultTeamService) applyReconciliation(ctx context.Context, plan *reconciliationPlan) error {
+	team := plan.team
+	events := make([]*domain.AssignmentEvent, 0)
+	if plan.created {
+		if err := s.teamRepo.CreateTeamAndModifyUsers(ctx, team, plan.users); err != nil {
+			return err
+		}
+		events = append(events, domain.TeamCreatedEvent(ctx, team))
+	} else {
+		if err := s.teamRepo.UpdateTeamAndModifyUsers(ctx, team, plan.users); err != nil {
+			return err
+		}
+	}
 
-	for _, user := range users {
-		team.AddUser(user.ID())
+	teamID := team.ID()
+	if !plan.created {
+		for _, c := range plan.added {
+			events = append(events, domain.TeamMemberAddedEvent(ctx, teamID, c.user.ID()))
+		}
+	}
+	for _, u := range plan.removed {
+		events = append(events, domain.TeamMemberRemovedEvent(ctx, teamID, u.ID()))
+	}
+	for _, c := range slices.Concat(plan.added, plan.updated) {
+		if c.previous != nil && c.previous.Active() != c.user.Active() {
+			events = append(events, domain.UserActivityChangedEvent(ctx, c.user, &teamID))
+		}
 	}
 
-	s.teamRepo.CreateTeamAndModifyUsers(team, users)
-	return nil
+	return s.eventRepo.Append(ctx, events...)
 }
 
-func (s *DefaultTeamService) FindTeamByName(name string) (*TeamWithUsersDTO, error) {
-	team, err := s.teamRepo.FindByName(name)
+// toDTO() maps plan to dto, members are the posted users
+func (p *reconciliationPlan) toDTO(members []*domain.User, dryRun bool) (*TeamReconciliationDTO, error) {
+	users, err := UsersToDTOs(members)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := UsersToDTOs(p.removed)
+	if err != nil {
+		return nil, err
+	}
+	added, err := memberChangesToDTOs(p.added)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := memberChangesToDTOs(p.updated)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := TeamSettingsToDTO(p.team.Settings())
+	return &TeamReconciliationDTO{
+		Team: &TeamWithUsersDTO{
+			TeamName:  p.team.Name().Value(),
+			TeamKey:   p.team.Key().Value(),
+			TeamUsers: users,
+			Settings:  &settings,
+			Version:   p.team.Version(),
+		},
+		DryRun:          dryRun,
+		Created:         p.created,
+		SettingsUpdated: p.settingsUpdated,
+		Added:           added,
+		Updated:         updated,
+		Removed:         removed,
+	}, nil
+}
+
+func memberChangesToDTOs(changes []memberChange) ([]*MemberChangeDTO, error) {
+	dtos := make([]*MemberChangeDTO, len(changes))
+	for i, c := range changes {
+		user, err := UserToDTO(c.user)
+		if err != nil {
+			return nil, err
+		}
+
+		dto := &MemberChangeDTO{User: user}
+		if c.previous != nil && c.previous.Name() != c.user.Name() {
+			name := c.previous.Name().Value()
+			dto.PreviousName = &name
+		}
+		if c.previous != nil && c.previous.Active() != c.user.Active() {
+			active := c.previous.Active()
+			dto.PreviousActive = &active
+		}
+		dtos[i] = dto
+	}
+
+	return dtos, nil
+}
+
+// resolveUser() maps dto to user with the same external key, or to a new user if there is no such one.
+// Invalid fields are collected into verr and nil user is returned.
+func (s *DefaultTeamService) resolveUser(ctx context.Context, field string, dto *UserDTO, verr *ValidationError) (*domain.User, error) {
+	if dto == nil {
+		return nil, ErrNilDTO
+	}
+
+	key, err := domain.NewExternalKey(dto.Key)
+	if err := verr.collect(field+".user_id", err); err != nil {
+		return nil, err
+	}
+	name, err := domain.NewUserName(dto.Name)
+	if err := verr.collect(field+".username", err); err != nil {
+		return nil, err
+	}
+	if key == "" || name == "" {
+		return nil, nil
+	}
+
+	existing, err := s.userRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return domain.ExistingUser(existing.ID(), key, name, dto.Active), nil
+	}
+
+	return domain.NewUser(key, name, dto.Active)
+}
+
+func (s *DefaultTeamService) FindTeamByName(ctx context.Context, name string) (*TeamWithUsersDTO, error) {
+	team, err := s.teamRepo.FindByName(ctx, name)
 	if err != nil {
 		return nil, err
 	}
@@ -88,9 +497,14 @@ func (s *DefaultTeamService) FindTeamByName(name string) (*TeamWithUsersDTO, err
 	if team == nil {
 		return nil, ErrNotFound
 	}
+
+	return s.teamToDTO(ctx, team)
+}
+
+func (s *DefaultTeamService) teamToDTO(ctx context.Context, team *domain.Team) (*TeamWithUsersDTO, error) {
 	users := make([]*UserDTO, len(team.UserIDs()))
 	for i, userID := range team.UserIDs() {
-		user, err := s.userRepo.FindByID(userID)
+		user, err := s.userRepo.FindByID(ctx, userID)
 		if err != nil {
 			return nil, err
 		}
@@ -101,40 +515,539 @@ func (s *DefaultTeamService) FindTeamByName(name string) (*TeamWithUsersDTO, err
 		users[i] = userDTO
 	}
 
+	settings := TeamSettingsToDTO(team.Settings())
+
+	parentTeamName, err := s.parentTeamName(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TeamWithUsersDTO{
-		TeamName:  team.Name().Value(),
-		TeamUsers: users,
+		TeamName:       team.Name().Value(),
+		TeamKey:        team.Key().Value(),
+		TeamUsers:      users,
+		Settings:       &settings,
+		ParentTeamName: parentTeamName,
+		Version:        team.Version(),
 	}, nil
 }
 
-func (s *DefaultTeamService) SetUserActiveByID(userID domain.ID, active bool) (*UserWithTeamNameDTO, error) {
-	user, err := s.userRepo.FindByID(userID)
+// parentTeamName() returns name of parent of the team, nil if the team has no parent
+func (s *DefaultTeamService) parentTeamName(ctx context.Context, team *domain.Team) (*string, error) {
+	parentID := team.ParentID()
+	if parentID == nil {
+		return nil, nil
+	}
+
+	parent, err := s.teamRepo.FindByID(ctx, *parentID)
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	name := parent.Name().Value()
+	return &name, nil
+}
+
+func (s *DefaultTeamService) SetUserActiveByKey(ctx context.Context, userKey string, active bool) (*UserWithTeamNameDTO, error) {
+	key, err := domain.NewExternalKey(userKey)
+	if err := invalidField("user_id", err); err != nil {
+		return nil, err
+	}
+
+	var (
+		user  *domain.User
+		teams []*domain.Team
+	)
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		user, err = s.userRepo.FindByKey(ctx, key)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("%w: no such user with key=%s", ErrNotFound, key)
+		}
+
+		changed := user.Active() != active
+		user.SetActive(active)
+
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		teams, err = s.teamRepo.FindTeamsByTeammateID(ctx, user.ID())
+		if err != nil || !changed {
+			return err
+		}
+
+		var teamID *domain.ID
+		if len(teams) > 0 {
+			id := teams[0].ID()
+			teamID = &id
+		}
+		return s.eventRepo.Append(ctx, domain.UserActivityChangedEvent(ctx, user, teamID))
+	})
 	if err != nil {
 		return nil, err
 	}
+
+	return userWithTeamsToDTO(user, teams)
+}
+
+func (s *DefaultTeamService) SetPrimaryTeam(ctx context.Context, userKey string, teamName string) (*UserWithTeamNameDTO, error) {
+	var (
+		user  *domain.User
+		teams []*domain.Team
+	)
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, member, err := s.findTeamAndUser(ctx, teamName, userKey)
+		if err != nil {
+			return err
+		}
+		user = member
+
+		if err := s.teamRepo.SetPrimaryTeam(ctx, user.ID(), team.ID()); err != nil {
+			return err
+		}
+
+		teams, err = s.teamRepo.FindTeamsByTeammateID(ctx, user.ID())
+		return err
+	})
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return userWithTeamsToDTO(user, teams)
+}
+
+// userWithTeamsToDTO() maps user with teams, primary team goes first
+func userWithTeamsToDTO(user *domain.User, teams []*domain.Team) (*UserWithTeamNameDTO, error) {
+	userDTO, err := UserToDTO(user)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := &UserWithTeamNameDTO{
+		User:      userDTO,
+		TeamNames: make([]string, len(teams)),
+	}
+	for i, team := range teams {
+		dto.TeamNames[i] = team.Name().Value()
+	}
+	if len(teams) > 0 {
+		dto.TeamName = dto.TeamNames[0]
+	}
+
+	return dto, nil
+}
+
+func (s *DefaultTeamService) DeactivateUsers(ctx context.Context, teamName string, userKeys []string) (*DeactivationResultDTO, error) {
+	verr := &ValidationError{}
+	if len(userKeys) == 0 {
+		verr.Violations = append(verr.Violations, FieldViolation{Field: "user_ids", Reason: "cannot be empty"})
+	}
+	keys := make([]domain.ExternalKey, len(userKeys))
+	for i, userKey := range userKeys {
+		key, err := domain.NewExternalKey(userKey)
+		if err := verr.collect(fmt.Sprintf("user_ids[%d]", i), err); err != nil {
+			return nil, err
+		}
+		keys[i] = key
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+	}
+
+	members := team.UserIDs()
+	userIDs := make([]domain.ID, 0, len(keys))
+	for i, key := range keys {
+		field := fmt.Sprintf("user_ids[%d]", i)
+		user, err := s.userRepo.FindByKey(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case user == nil || !slices.Contains(members, user.ID()):
+			verr.Violations = append(verr.Violations, FieldViolation{Field: field, Reason: domain.ErrNotTeamMember.Error()})
+		case slices.Contains(userIDs, user.ID()):
+			verr.Violations = append(verr.Violations, FieldViolation{Field: field, Reason: "duplicated user"})
+		default:
+			userIDs = append(userIDs, user.ID())
+		}
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	result, err := s.teamDomainServ.DeactivateUsers(ctx, team.ID(), userIDs)
+	if errors.Is(err, domain.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	} else if errors.Is(err, domain.ErrNotTeamMember) {
+		return nil, NewValidationError("user_ids", domain.ErrNotTeamMember.Error())
+	} else if errors.Is(err, domain.ErrRotationConflict) {
+		return nil, ErrRotationConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.deactivationResultToDTO(ctx, team, result)
+}
+
+func (s *DefaultTeamService) deactivationResultToDTO(ctx context.Context, team *domain.Team, result *domain.DeactivationResult) (*DeactivationResultDTO, error) {
+	deactivated, err := UsersToDTOs(result.DeactivatedUsers)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[domain.ID]string, len(result.DeactivatedUsers))
+	for _, u := range result.DeactivatedUsers {
+		keys[u.ID()] = u.Key().Value()
+	}
+	reassigned, notReassigned, err := s.reassignmentsToDTOs(ctx, keys, result.Reassigned, result.Failed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeactivationResultDTO{
+		TeamName:         team.Name().Value(),
+		DeactivatedUsers: deactivated,
+		Reassigned:       reassigned,
+		NotReassigned:    notReassigned,
+	}, nil
+}
+
+// reassignmentsToDTOs() maps reassignments to dtos. keys must contain external keys of old reviewers.
+func (s *DefaultTeamService) reassignmentsToDTOs(
+	ctx context.Context,
+	keys map[domain.ID]string,
+	result []domain.ReviewReassignment,
+	failed []domain.FailedReassignment,
+) ([]*ReassignmentDTO, []*FailedReassignmentDTO, error) {
+	newReviewerIDs := make([]domain.ID, len(result))
+	for i, r := range result {
+		newReviewerIDs[i] = r.NewReviewerID
+	}
+	newReviewers, err := s.userRepo.FindByIDs(ctx, newReviewerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, u := range newReviewers {
+		keys[u.ID()] = u.Key().Value()
+	}
+
+	reassigned := make([]*ReassignmentDTO, len(result))
+	for i, r := range result {
+		reassigned[i] = &ReassignmentDTO{
+			PullRequestKey: r.PullRequest.Key().Value(),
+			OldReviewerKey: keys[r.OldReviewerID],
+			NewReviewerKey: keys[r.NewReviewerID],
+		}
+	}
+
+	notReassigned := make([]*FailedReassignmentDTO, len(failed))
+	for i, f := range failed {
+		reason := f.Err
+		if errors.Is(f.Err, domain.ErrNoReviewCandidates) {
+			reason = ErrNoCandidate
+		}
+		notReassigned[i] = &FailedReassignmentDTO{
+			PullRequestKey: f.PullRequest.Key().Value(),
+			ReviewerKey:    keys[f.ReviewerID],
+			Reason:         reason,
+		}
+	}
+
+	return reassigned, notReassigned, nil
+}
+
+func (s *DefaultTeamService) AddMember(ctx context.Context, teamName string, userKey string, expectedVersions []int64) (*MembershipResultDTO, error) {
+	team, user, err := s.findTeamAndUser(ctx, teamName, userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = s.teamDomainServ.AddMember(ctx, team.ID(), user.ID(), expectedVersions)
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	teamDTO, err := s.teamToDTO(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MembershipResultDTO{
+		Team:          teamDTO,
+		Reassigned:    []*ReassignmentDTO{},
+		NotReassigned: []*FailedReassignmentDTO{},
+	}, nil
+}
+
+func (s *DefaultTeamService) RemoveMember(ctx context.Context, teamName string, userKey string, reassignReviews bool, expectedVersions []int64) (*MembershipResultDTO, error) {
+	team, user, err := s.findTeamAndUser(ctx, teamName, userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.teamDomainServ.RemoveMember(ctx, team.ID(), user.ID(), reassignReviews, expectedVersions)
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return s.membershipResultToDTO(ctx, user, result)
+}
+
+func (s *DefaultTeamService) MoveMember(ctx context.Context, fromTeamName string, toTeamName string, userKey string, reassignReviews bool, expectedVersions []int64) (*MembershipResultDTO, error) {
+	if fromTeamName == toTeamName {
+		return nil, NewValidationError("to_team_name", "must differ from from_team_name")
+	}
+	from, user, err := s.findTeamAndUser(ctx, fromTeamName, userKey)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.teamRepo.FindByName(ctx, toTeamName)
+	if err != nil {
+		return nil, err
+	}
+	if to == nil {
+		return nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, toTeamName)
+	}
+
+	result, err := s.teamDomainServ.MoveMember(ctx, from.ID(), to.ID(), user.ID(), reassignReviews, expectedVersions)
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return s.membershipResultToDTO(ctx, user, result)
+}
+
+func (s *DefaultTeamService) findTeamAndUser(ctx context.Context, teamName string, userKey string) (*domain.Team, *domain.User, error) {
+	key, err := domain.NewExternalKey(userKey)
+	if err := invalidField("user_id", err); err != nil {
+		return nil, nil, err
+	}
+
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if team == nil {
+		return nil, nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+	}
+
+	user, err := s.userRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
 	if user == nil {
-		return nil, fmt.Errorf("%w: no such user with id=%d", ErrNotFound, userID)
+		return nil, nil, fmt.Errorf("%w: no such user with key=%s", ErrNotFound, key)
 	}
 
-	user.SetActive(active)
+	return team, user, nil
+}
 
-	err = s.userRepo.Update(user)
+func (s *DefaultTeamService) membershipResultToDTO(ctx context.Context, user *domain.User, result *domain.MembershipResult) (*MembershipResultDTO, error) {
+	teamDTO, err := s.teamToDTO(ctx, result.Team)
 	if err != nil {
 		return nil, err
 	}
 
-	team, err := s.teamRepo.FindTeamByTeammateID(userID)
+	keys := map[domain.ID]string{user.ID(): user.Key().Value()}
+	reassigned, notReassigned, err := s.reassignmentsToDTOs(ctx, keys, result.Reassigned, result.Failed)
 	if err != nil {
 		return nil, err
 	}
 
-	userDTO, err := UserToDTO(user)
+	dto := &MembershipResultDTO{
+		Team:          teamDTO,
+		Reassigned:    reassigned,
+		NotReassigned: notReassigned,
+	}
+	if result.SourceTeam != nil {
+		version := result.SourceTeam.Version()
+		dto.SourceTeamVersion = &version
+	}
+
+	return dto, nil
+}
+
+func (s *DefaultTeamService) RenameTeam(ctx context.Context, teamName string, newTeamName string, expectedVersions []int64) (*TeamWithUsersDTO, error) {
+	name, err := domain.NewTeamName(newTeamName)
+	if err := invalidField("new_team_name", err); err != nil {
+		return nil, err
+	}
+
+	var team *domain.Team
+	err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		team, err = s.teamRepo.FindByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+		}
+		if err := domain.CheckVersion(team.Version(), expectedVersions); err != nil {
+			return err
+		}
+		if team.Name() == name {
+			return nil
+		}
+
+		existing, err := s.teamRepo.FindByName(ctx, name.Value())
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("%w: name=%s", ErrTeamExists, name)
+		}
+
+		team.Rename(name)
+		if err := s.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
+		return s.eventRepo.Append(ctx, domain.TeamChangedEvent(ctx, team, domain.EventTeamRenamed))
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.teamToDTO(ctx, team)
+}
+
+func (s *DefaultTeamService) SetParentTeam(ctx context.Context, teamName string, parentTeamName *string, expectedVersions []int64) (*TeamWithUsersDTO, error) {
+	var team *domain.Team
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		team, err = s.teamRepo.FindByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if team == nil {
+			return fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+		}
+		if err := domain.CheckVersion(team.Version(), expectedVersions); err != nil {
+			return err
+		}
+
+		var parent *domain.Team
+		var ancestors []*domain.Team
+		if parentTeamName != nil {
+			parent, err = s.teamRepo.FindByName(ctx, *parentTeamName)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return fmt.Errorf("%w: no such team with name=%s", ErrNotFound, *parentTeamName)
+			}
+			ancestors, err = s.teamRepo.FindAncestors(ctx, parent.ID())
+			if err != nil {
+				return err
+			}
+		}
+
+		currentID := team.ParentID()
+		if parent == nil && currentID == nil || parent != nil && currentID != nil && *currentID == parent.ID() {
+			return nil
+		}
+
+		err = team.SetParent(parent, ancestors)
+		if errors.Is(err, domain.ErrTeamHierarchyCycle) {
+			return NewValidationError("parent_team_name", domain.ErrTeamHierarchyCycle.Error())
+		} else if err != nil {
+			return err
+		}
+		if err := s.teamRepo.Update(ctx, team); err != nil {
+			return err
+		}
+		return s.eventRepo.Append(ctx, domain.TeamChangedEvent(ctx, team, domain.EventTeamParentChanged))
+	})
+	if errors.Is(err, domain.ErrVersionConflict) {
+		return nil, ErrVersionConflict
+	} else if err != nil {
+		return nil, err
+	}
+
+	return s.teamToDTO(ctx, team)
+}
+
+func (s *DefaultTeamService) DeleteTeam(ctx context.Context, teamName string, policy string, expectedVersions []int64) (*TeamDeletionResultDTO, error) {
+	deletionPolicy, err := domain.NewTeamDeletionPolicy(policy)
+	if err := invalidField("policy", err); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.FindByName(ctx, teamName)
 	if err != nil {
 		return nil, err
 	}
+	if team == nil {
+		return nil, fmt.Errorf("%w: no such team with name=%s", ErrNotFound, teamName)
+	}
+
+	result, err := s.teamDomainServ.DeleteTeam(ctx, team.ID(), deletionPolicy, expectedVersions)
+	switch {
+	case errors.Is(err, domain.ErrTeamNotFound):
+		return nil, ErrNotFound
+	case errors.Is(err, domain.ErrTeamHasOpenPullRequests):
+		return nil, ErrTeamHasOpenPRs
+	case errors.Is(err, domain.ErrVersionConflict):
+		return nil, ErrVersionConflict
+	case errors.Is(err, domain.ErrRotationConflict):
+		return nil, ErrRotationConflict
+	case err != nil:
+		return nil, err
+	}
 
-	return &UserWithTeamNameDTO{
-		User:     userDTO,
-		TeamName: team.Name().Value(),
+	members, err := UsersToDTOs(result.Members)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[domain.ID]string, len(result.Members))
+	for _, u := range result.Members {
+		keys[u.ID()] = u.Key().Value()
+	}
+	reassigned, notReassigned, err := s.reassignmentsToDTOs(ctx, keys, result.Reassigned, result.Failed)
+	if err != nil {
+		return nil, err
+	}
+
+	deactivated, err := UsersToDTOs(result.Deactivated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TeamDeletionResultDTO{
+		TeamName:      result.Team.Name().Value(),
+		Policy:        deletionPolicy.String(),
+		Members:       members,
+		Deactivated:   deactivated,
+		Reassigned:    reassigned,
+		NotReassigned: notReassigned,
 	}, nil
 }
+
+func membershipError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrTeamNotFound), errors.Is(err, domain.ErrUserNotFound):
+		return ErrNotFound
+	case errors.Is(err, domain.ErrNotTeamMember):
+		return NewValidationError("user_id", domain.ErrNotTeamMember.Error())
+	case errors.Is(err, domain.ErrAlreadyTeamMember):
+		// only target team of the move can already contain the user
+		return NewValidationError("to_team_name", domain.ErrAlreadyTeamMember.Error())
+	case errors.Is(err, domain.ErrVersionConflict):
+		return ErrVersionConflict
+	case errors.Is(err, domain.ErrRotationConflict):
+		return ErrRotationConflict
+	default:
+		return err
+	}
+}
@@ -0,0 +1,224 @@
+package app_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/alphameo/pr-reviewnager/internal/infra/db/memory"
+)
+
+func newTeamService(t *testing.T) *app.DefaultTeamService {
+	t.Helper()
+
+	store := memory.NewStore()
+	userRepo, err := memory.NewUserRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamRepo, err := memory.NewTeamRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prRepo, err := memory.NewPullRequestRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotationRepo, err := memory.NewReviewerRotationRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eventRepo, err := memory.NewAssignmentEventRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactor, err := memory.NewTransactor(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, err := domain.NewRoundRobinReviewerSelector(rotationRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selectors, err := domain.NewTeamReviewerSelectorProvider(selector, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	teamDomainServ, err := domain.NewDefaultTeamDomainService(userRepo, prRepo, teamRepo, selectors, eventRepo, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := app.NewDefaultTeamService(teamDomainServ, teamRepo, userRepo, eventRepo, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func member(key, name string, active bool) *app.UserDTO {
+	return &app.UserDTO{Key: key, Name: name, Active: active}
+}
+
+func memberKeys(users []*app.UserDTO) []string {
+	keys := make([]string, len(users))
+	for i, u := range users {
+		keys[i] = u.Key
+	}
+	return keys
+}
+
+func changeKeys(changes []*app.MemberChangeDTO) []string {
+	keys := make([]string, len(changes))
+	for i, c := range changes {
+		keys[i] = c.User.Key
+	}
+	return keys
+}
+
+func TestReconcileTeam(t *testing.T) {
+	const teamName = "backend"
+	stored := []*app.UserDTO{member("u1", "Alice", true), member("u2", "Bob", true)}
+
+	tests := []struct {
+		name string
+		// existing is stored before reconciliation
+		existing         bool
+		members          []*app.UserDTO
+		settings         *app.TeamSettingsDTO
+		dryRun           bool
+		expectedVersions func(version int64) []int64
+		wantErr          error
+		wantCreated      bool
+		wantSettings     bool
+		wantAdded        []string
+		wantUpdated      []string
+		wantRemoved      []string
+		// members of stored team after reconciliation, nil if team is not stored
+		wantStored []string
+	}{
+		{
+			name:        "create missing team",
+			members:     stored,
+			wantCreated: true,
+			wantAdded:   []string{"u1", "u2"},
+			wantStored:  []string{"u1", "u2"},
+		},
+		{
+			name:             "missing team with expected version",
+			members:          stored,
+			expectedVersions: func(int64) []int64 { return []int64{domain.InitialVersion} },
+			wantErr:          app.ErrVersionConflict,
+		},
+		{
+			name:        "dry run of creation",
+			members:     stored,
+			dryRun:      true,
+			wantCreated: true,
+			wantAdded:   []string{"u1", "u2"},
+		},
+		{
+			name:       "unchanged team",
+			existing:   true,
+			members:    stored,
+			wantStored: []string{"u1", "u2"},
+		},
+		{
+			name:     "add, update and remove members",
+			existing: true,
+			members:  []*app.UserDTO{member("u1", "Alice Smith", false), member("u3", "Carol", true)},
+			settings: &app.TeamSettingsDTO{MinReviewers: 1, MaxReviewers: 3, RequiredApprovals: 1},
+			expectedVersions: func(version int64) []int64 {
+				return []int64{version}
+			},
+			wantSettings: true,
+			wantAdded:    []string{"u3"},
+			wantUpdated:  []string{"u1"},
+			wantRemoved:  []string{"u2"},
+			wantStored:   []string{"u1", "u3"},
+		},
+		{
+			name:        "dry run of update",
+			existing:    true,
+			members:     []*app.UserDTO{member("u3", "Carol", true)},
+			dryRun:      true,
+			wantAdded:   []string{"u3"},
+			wantRemoved: []string{"u1", "u2"},
+			wantStored:  []string{"u1", "u2"},
+		},
+		{
+			name:             "stale version",
+			existing:         true,
+			members:          []*app.UserDTO{member("u3", "Carol", true)},
+			expectedVersions: func(version int64) []int64 { return []int64{version + 1} },
+			wantErr:          app.ErrVersionConflict,
+			wantStored:       []string{"u1", "u2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newTeamService(t)
+			version := domain.InitialVersion
+			if tt.existing {
+				err := s.CreateTeamWithUsers(ctx, &app.TeamWithUsersDTO{TeamName: teamName, TeamUsers: stored})
+				if err != nil {
+					t.Fatal(err)
+				}
+				team, err := s.FindTeamByName(ctx, teamName)
+				if err != nil {
+					t.Fatal(err)
+				}
+				version = team.Version
+			}
+			var expectedVersions []int64
+			if tt.expectedVersions != nil {
+				expectedVersions = tt.expectedVersions(version)
+			}
+
+			got, err := s.ReconcileTeam(ctx, &app.TeamWithUsersDTO{
+				TeamName:  teamName,
+				TeamUsers: tt.members,
+				Settings:  tt.settings,
+			}, tt.dryRun, expectedVersions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReconcileTeam() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil {
+				if got.Created != tt.wantCreated || got.SettingsUpdated != tt.wantSettings || got.DryRun != tt.dryRun {
+					t.Errorf("ReconcileTeam() created = %t, settings updated = %t, dry run = %t, want %t, %t, %t",
+						got.Created, got.SettingsUpdated, got.DryRun, tt.wantCreated, tt.wantSettings, tt.dryRun)
+				}
+				if keys := changeKeys(got.Added); !slices.Equal(keys, tt.wantAdded) {
+					t.Errorf("ReconcileTeam() added = %v, want %v", keys, tt.wantAdded)
+				}
+				if keys := changeKeys(got.Updated); !slices.Equal(keys, tt.wantUpdated) {
+					t.Errorf("ReconcileTeam() updated = %v, want %v", keys, tt.wantUpdated)
+				}
+				if keys := memberKeys(got.Removed); !slices.Equal(keys, tt.wantRemoved) {
+					t.Errorf("ReconcileTeam() removed = %v, want %v", keys, tt.wantRemoved)
+				}
+			}
+
+			team, err := s.FindTeamByName(ctx, teamName)
+			if tt.wantStored == nil {
+				if !errors.Is(err, app.ErrNotFound) {
+					t.Errorf("FindTeamByName() error = %v, want %v", err, app.ErrNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("FindTeamByName() error = %v", err)
+			}
+			keys := memberKeys(team.TeamUsers)
+			slices.Sort(keys)
+			if !slices.Equal(keys, tt.wantStored) {
+				t.Errorf("stored members = %v, want %v", keys, tt.wantStored)
+			}
+		})
+	}
+}
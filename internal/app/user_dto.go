@@ -4,16 +4,28 @@ import "github.com/alphameo/pr-reviewnager/internal/domain"
 
 type UserDTO struct {
 	ID     domain.ID
+	Key    string
 	Name   string
 	Active bool
 }
 
 type NewUserDTO struct {
+	Key    string
 	Name   string
 	Active bool
 }
 
 type UserWithTeamNameDTO struct {
-	User     *UserDTO
+	User *UserDTO
+	// TeamName is a primary team of the user, empty if user has no teams
 	TeamName string
+	// TeamNames are all teams of the user, primary team goes first
+	TeamNames []string
+}
+
+// UserIdentityDTO is an account of user on code hosting
+type UserIdentityDTO struct {
+	UserKey  string
+	Provider string
+	Login    string
 }
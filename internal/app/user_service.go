@@ -1,30 +1,45 @@
 package app
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 )
 
 type UserService interface {
-	RegisterUser(user *NewUserDTO) error
-	UnregisterUserByID(userID domain.ID) error
-	ListUsers() ([]*UserDTO, error)
+	RegisterUser(ctx context.Context, user *NewUserDTO) error
+	UnregisterUserByID(ctx context.Context, userID domain.ID) error
+	ListUsers(ctx context.Context) ([]*UserDTO, error)
+	// LinkIdentity() links user to account on code hosting, so pull requests opened there
+	// are attributed to the user
+	LinkIdentity(ctx context.Context, identity *UserIdentityDTO) (*UserIdentityDTO, error)
 }
 
 type DefaultUserService struct {
-	userRepo domain.UserRepository
+	userRepo     domain.UserRepository
+	identityRepo domain.UserIdentityRepository
 }
 
-func NewDefaultUserService(userRepository domain.UserRepository) (*DefaultUserService, error) {
+func NewDefaultUserService(
+	userRepository domain.UserRepository,
+	userIdentityRepository domain.UserIdentityRepository,
+) (*DefaultUserService, error) {
 	if userRepository == nil {
 		return nil, errors.New("userRepository cannot be nil")
 	}
+	if userIdentityRepository == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
 
-	return &DefaultUserService{userRepo: userRepository}, nil
+	return &DefaultUserService{
+		userRepo:     userRepository,
+		identityRepo: userIdentityRepository,
+	}, nil
 }
 
-func (s *DefaultUserService) RegisterUser(user *NewUserDTO) error {
+func (s *DefaultUserService) RegisterUser(ctx context.Context, user *NewUserDTO) error {
 	if user == nil {
 		return errors.New("user cannot be nil")
 	}
@@ -32,31 +47,72 @@ func (s *DefaultUserService) RegisterUser(user *NewUserDTO) error {
 	if err != nil {
 		return err
 	}
-	entity, err := domain.NewUser(name, user.Active)
+	key, err := domain.NewExternalKey(user.Key)
+	if err != nil {
+		return err
+	}
+	entity, err := domain.NewUser(key, name, user.Active)
 	if err != nil {
 		return err
 	}
 
-	err = s.userRepo.Create(entity)
+	err = s.userRepo.Create(ctx, entity)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *DefaultUserService) UnregisterUserByID(userID domain.ID) error {
-	err := s.userRepo.DeleteByID(userID)
+func (s *DefaultUserService) UnregisterUserByID(ctx context.Context, userID domain.ID) error {
+	err := s.userRepo.DeleteByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
-func (s *DefaultUserService) ListUsers() ([]*UserDTO, error) {
-	users, err := s.userRepo.FindAll()
+func (s *DefaultUserService) ListUsers(ctx context.Context) ([]*UserDTO, error) {
+	users, err := s.userRepo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	return UsersToDTOs(users)
 }
+
+func (s *DefaultUserService) LinkIdentity(ctx context.Context, identity *UserIdentityDTO) (*UserIdentityDTO, error) {
+	verr := &ValidationError{}
+	key, err := domain.NewExternalKey(identity.UserKey)
+	if err := verr.collect("user_id", err); err != nil {
+		return nil, err
+	}
+	provider, err := domain.NewIdentityProvider(identity.Provider)
+	if err := verr.collect("provider", err); err != nil {
+		return nil, err
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.FindByKey(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: no such user with key=%s", ErrNotFound, key)
+	}
+
+	entity, err := domain.NewUserIdentity(provider, identity.Login, user.ID())
+	if err := invalidField("login", err); err != nil {
+		return nil, err
+	}
+	if err := s.identityRepo.Save(ctx, entity); err != nil {
+		return nil, err
+	}
+
+	return &UserIdentityDTO{
+		UserKey:  user.Key().Value(),
+		Provider: entity.Provider().String(),
+		Login:    entity.Login(),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+var ErrValidation error = errors.New("invalid input")
+
+// FieldViolation describes why value of input field was rejected.
+// Field is named the way clients send it (e.g. "members[0].username")
+type FieldViolation struct {
+	Field  string
+	Reason string
+}
+
+// ValidationError aggregates violations of service input. It matches ErrValidation with errors.Is
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func NewValidationError(field, reason string) *ValidationError {
+	return &ValidationError{
+		Violations: []FieldViolation{{Field: field, Reason: reason}},
+	}
+}
+
+func (e *ValidationError) Error() string {
+	violations := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		violations[i] = v.Field + ": " + v.Reason
+	}
+
+	return ErrValidation.Error() + ": " + strings.Join(violations, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// collect() records domain validation error as violation of input field.
+// Errors of other kinds are returned as is.
+func (e *ValidationError) collect(field string, err error) error {
+	var domainErr *domain.ValidationError
+	if errors.As(err, &domainErr) {
+		e.Violations = append(e.Violations, FieldViolation{Field: field, Reason: domainErr.Reason})
+		return nil
+	}
+
+	return err
+}
+
+// invalidField() converts domain validation error into violation of input field.
+// Errors of other kinds (including nil) are returned as is.
+func invalidField(field string, err error) error {
+	verr := &ValidationError{}
+	if err := verr.collect(field, err); err != nil {
+		return err
+	}
+
+	return verr.errOrNil()
+}
+
+// errOrNil() returns validation error if at least one violation was collected
+func (e *ValidationError) errOrNil() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+
+	return e
+}
@@ -0,0 +1,280 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+// WebhookSender posts deliveries to subscribers
+type WebhookSender interface {
+	// Send() posts delivery payload signed with subscription secret
+	// and returns error if subscriber did not accept it
+	Send(ctx context.Context, subscription *domain.WebhookSubscription, delivery *domain.WebhookDelivery) error
+}
+
+type WebhookDispatcherConfig struct {
+	PollInterval time.Duration
+	// BatchSize limits number of events and deliveries processed per poll
+	BatchSize int
+	// Lease is time during which claimed delivery is not sent by other dispatchers
+	Lease time.Duration
+	Retry domain.RetryPolicy
+}
+
+// WebhookDispatcher publishes outbox events to webhook subscribers.
+// Several dispatchers may share the same storage.
+type WebhookDispatcher struct {
+	outboxRepo       domain.OutboxRepository
+	subscriptionRepo domain.WebhookSubscriptionRepository
+	deliveryRepo     domain.WebhookDeliveryRepository
+	userRepo         domain.UserRepository
+	prRepo           domain.PullRequestRepository
+	teamRepo         domain.TeamRepository
+	transactor       domain.Transactor
+	sender           WebhookSender
+	config           WebhookDispatcherConfig
+}
+
+func NewWebhookDispatcher(
+	outboxRepository domain.OutboxRepository,
+	webhookSubscriptionRepository domain.WebhookSubscriptionRepository,
+	webhookDeliveryRepository domain.WebhookDeliveryRepository,
+	userRepository domain.UserRepository,
+	pullRequestRepository domain.PullRequestRepository,
+	teamRepository domain.TeamRepository,
+	transactor domain.Transactor,
+	sender WebhookSender,
+	config WebhookDispatcherConfig,
+) (*WebhookDispatcher, error) {
+	if outboxRepository == nil {
+		return nil, errors.New("outboxRepository cannot be nil")
+	}
+	if webhookSubscriptionRepository == nil {
+		return nil, errors.New("webhookSubscriptionRepository cannot be nil")
+	}
+	if webhookDeliveryRepository == nil {
+		return nil, errors.New("webhookDeliveryRepository cannot be nil")
+	}
+	if userRepository == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if pullRequestRepository == nil {
+		return nil, errors.New("pullRequestRepository cannot be nil")
+	}
+	if teamRepository == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+	if sender == nil {
+		return nil, errors.New("sender cannot be nil")
+	}
+	if config.PollInterval <= 0 || config.BatchSize <= 0 || config.Lease <= 0 || config.Retry.MaxAttempts <= 0 {
+		return nil, errors.New("poll interval, batch size, lease and max attempts must be positive")
+	}
+
+	return &WebhookDispatcher{
+		outboxRepo:       outboxRepository,
+		subscriptionRepo: webhookSubscriptionRepository,
+		deliveryRepo:     webhookDeliveryRepository,
+		userRepo:         userRepository,
+		prRepo:           pullRequestRepository,
+		teamRepo:         teamRepository,
+		transactor:       transactor,
+		sender:           sender,
+		config:           config,
+	}, nil
+}
+
+// Run() dispatches events every poll interval until ctx is cancelled
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Webhook dispatch failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce() turns pending outbox events into deliveries
+// and sends deliveries, which are due
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) error {
+	if err := d.publishOutbox(ctx); err != nil {
+		return fmt.Errorf("failed to publish outbox events: %w", err)
+	}
+	if err := d.sendDue(ctx); err != nil {
+		return fmt.Errorf("failed to send webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// publishOutbox() creates delivery of every pending event for every subscriber accepting it
+func (d *WebhookDispatcher) publishOutbox(ctx context.Context) error {
+	return d.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		events, err := d.outboxRepo.FindPendingForUpdate(ctx, d.config.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		subscriptions, err := d.subscriptionRepo.FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		eventIDs := make([]domain.ID, len(events))
+		deliveries := make([]*domain.WebhookDelivery, 0, len(events))
+		for i, event := range events {
+			eventIDs[i] = event.ID
+
+			var payload []byte
+			for _, subscription := range subscriptions {
+				if !subscription.Accepts(event.Type) {
+					continue
+				}
+				if payload == nil {
+					if payload, err = d.buildPayload(ctx, event); err != nil {
+						return err
+					}
+				}
+				deliveries = append(deliveries, domain.NewWebhookDelivery(subscription.ID(), event, payload, now))
+			}
+		}
+
+		if len(deliveries) != 0 {
+			if err := d.deliveryRepo.Create(ctx, deliveries...); err != nil {
+				return err
+			}
+		}
+
+		return d.outboxRepo.MarkPublished(ctx, eventIDs...)
+	})
+}
+
+// buildPayload() resolves external keys of entities referred by event and encodes it to JSON
+func (d *WebhookDispatcher) buildPayload(ctx context.Context, event *domain.AssignmentEvent) ([]byte, error) {
+	payload := WebhookEventPayload{
+		EventID:          event.ID.String(),
+		EventType:        strings.ToUpper(event.Type.String()),
+		Actor:            event.Actor,
+		OccurredAt:       event.OccurredAt,
+		MissingApprovals: event.MissingApprovals,
+	}
+
+	if event.PullRequestID != nil {
+		pr, err := d.prRepo.FindByID(ctx, *event.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if pr != nil {
+			payload.PullRequestID = pr.Key().Value()
+			payload.PullRequestName = pr.Title().Value()
+		}
+	}
+
+	if event.TeamID != nil {
+		team, err := d.teamRepo.FindByID(ctx, *event.TeamID)
+		if err != nil {
+			return nil, err
+		}
+		if team != nil {
+			payload.TeamName = team.Name().Value()
+		}
+	}
+
+	ids := make([]domain.ID, 0, 3)
+	for _, id := range []*domain.ID{event.UserID, event.OldReviewerID, event.NewReviewerID} {
+		if id != nil {
+			ids = append(ids, *id)
+		}
+	}
+	users, err := d.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[domain.ID]string, len(users))
+	for _, user := range users {
+		keys[user.ID()] = user.Key().Value()
+	}
+	keyOf := func(id *domain.ID) string {
+		if id == nil {
+			return ""
+		}
+		return keys[*id]
+	}
+	payload.UserID = keyOf(event.UserID)
+	payload.OldUserID = keyOf(event.OldReviewerID)
+	payload.NewUserID = keyOf(event.NewReviewerID)
+
+	return json.Marshal(payload)
+}
+
+// sendDue() sends claimed deliveries concurrently and records results of attempts
+func (d *WebhookDispatcher) sendDue(ctx context.Context) error {
+	deliveries, err := d.deliveryRepo.ClaimDue(ctx, time.Now(), d.config.Lease, d.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	for _, delivery := range deliveries {
+		wg.Go(func() {
+			if err := d.send(ctx, delivery); err != nil {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	subscription, err := d.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID())
+	if err != nil {
+		return err
+	}
+	if subscription == nil {
+		// webhook was deleted after delivery had been claimed
+		return nil
+	}
+
+	if err := d.sender.Send(ctx, subscription, delivery); err != nil {
+		if ctx.Err() != nil {
+			// delivery is retried after lease expires
+			return nil
+		}
+		delivery.RecordFailure(err.Error(), time.Now(), d.config.Retry)
+	} else {
+		delivery.RecordSuccess(time.Now())
+	}
+
+	return d.deliveryRepo.Update(ctx, delivery)
+}
@@ -0,0 +1,57 @@
+package app
+
+import (
+	"time"
+)
+
+type NewWebhookDTO struct {
+	URL string
+	// generated if empty
+	Secret string
+	// empty to receive events of all types
+	EventTypes []string
+}
+
+type WebhookDTO struct {
+	ID         string
+	URL        string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+// RegisteredWebhookDTO is returned once on registration,
+// since secret is not shown afterwards
+type RegisteredWebhookDTO struct {
+	Webhook *WebhookDTO
+	Secret  string
+}
+
+type WebhookDeliveryDTO struct {
+	ID            string
+	WebhookID     string
+	EventID       string
+	EventType     string
+	Status        string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// WebhookEventPayload is JSON body posted to webhook subscribers.
+// Entities are referred to by their external keys, empty ones are omitted.
+type WebhookEventPayload struct {
+	EventID         string    `json:"event_id"`
+	EventType       string    `json:"event_type"`
+	Actor           string    `json:"actor"`
+	OccurredAt      time.Time `json:"occurred_at"`
+	PullRequestID   string    `json:"pull_request_id,omitempty"`
+	PullRequestName string    `json:"pull_request_name,omitempty"`
+	TeamName        string    `json:"team_name,omitempty"`
+	UserID          string    `json:"user_id,omitempty"`
+	OldUserID       string    `json:"old_user_id,omitempty"`
+	NewUserID       string    `json:"new_user_id,omitempty"`
+	// MissingApprovals is set for pull request merged on code hosting without required approvals
+	MissingApprovals *int `json:"missing_approvals,omitempty"`
+}
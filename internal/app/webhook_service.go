@@ -0,0 +1,164 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type WebhookService interface {
+	// RegisterWebhook() subscribes URL to events. Secret used to sign payloads
+	// is generated if not provided and returned only here.
+	RegisterWebhook(ctx context.Context, webhook *NewWebhookDTO) (*RegisteredWebhookDTO, error)
+	FindWebhooks(ctx context.Context) ([]*WebhookDTO, error)
+	DeleteWebhook(ctx context.Context, webhookID string) error
+	// FindDeliveries() returns deliveries filtered by webhook and status, empty filters are ignored
+	FindDeliveries(ctx context.Context, webhookID string, status string) ([]*WebhookDeliveryDTO, error)
+}
+
+// webhookSecretBytes is a size of generated secrets before hex encoding
+const webhookSecretBytes = 32
+
+type DefaultWebhookService struct {
+	subscriptionRepo domain.WebhookSubscriptionRepository
+	deliveryRepo     domain.WebhookDeliveryRepository
+}
+
+func NewDefaultWebhookService(
+	webhookSubscriptionRepository domain.WebhookSubscriptionRepository,
+	webhookDeliveryRepository domain.WebhookDeliveryRepository,
+) (*DefaultWebhookService, error) {
+	if webhookSubscriptionRepository == nil {
+		return nil, errors.New("webhookSubscriptionRepository cannot be nil")
+	}
+	if webhookDeliveryRepository == nil {
+		return nil, errors.New("webhookDeliveryRepository cannot be nil")
+	}
+
+	return &DefaultWebhookService{
+		subscriptionRepo: webhookSubscriptionRepository,
+		deliveryRepo:     webhookDeliveryRepository,
+	}, nil
+}
+
+func (s *DefaultWebhookService) RegisterWebhook(ctx context.Context, webhook *NewWebhookDTO) (*RegisteredWebhookDTO, error) {
+	verr := &ValidationError{}
+	eventTypes := make([]domain.AssignmentEventType, 0, len(webhook.EventTypes))
+	for i, value := range webhook.EventTypes {
+		eventType, err := domain.NewAssignmentEventType(value)
+		if err := verr.collect(fmt.Sprintf("event_types[%d]", i), err); err != nil {
+			return nil, err
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+	if err := verr.errOrNil(); err != nil {
+		return nil, err
+	}
+
+	secret := webhook.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription, err := domain.NewWebhookSubscription(webhook.URL, secret, eventTypes)
+	if err := invalidField("url", err); err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &RegisteredWebhookDTO{
+		Webhook: WebhookToDTO(subscription),
+		Secret:  secret,
+	}, nil
+}
+
+func (s *DefaultWebhookService) FindWebhooks(ctx context.Context) ([]*WebhookDTO, error) {
+	subscriptions, err := s.subscriptionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*WebhookDTO, len(subscriptions))
+	for i, subscription := range subscriptions {
+		dtos[i] = WebhookToDTO(subscription)
+	}
+
+	return dtos, nil
+}
+
+func (s *DefaultWebhookService) DeleteWebhook(ctx context.Context, webhookID string) error {
+	id, err := s.findWebhookID(ctx, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionRepo.DeleteByID(ctx, id)
+}
+
+func (s *DefaultWebhookService) FindDeliveries(ctx context.Context, webhookID string, status string) ([]*WebhookDeliveryDTO, error) {
+	filter := domain.WebhookDeliveryFilter{}
+	if webhookID != "" {
+		id, err := s.findWebhookID(ctx, webhookID)
+		if err != nil {
+			return nil, err
+		}
+		filter.SubscriptionID = &id
+	}
+	if status != "" {
+		deliveryStatus, err := domain.NewDeliveryStatus(status)
+		if err := invalidField("status", err); err != nil {
+			return nil, err
+		}
+		filter.Status = &deliveryStatus
+	}
+
+	deliveries, err := s.deliveryRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*WebhookDeliveryDTO, len(deliveries))
+	for i, delivery := range deliveries {
+		dtos[i] = WebhookDeliveryToDTO(delivery)
+	}
+
+	return dtos, nil
+}
+
+// findWebhookID() parses webhook id and checks that webhook exists
+func (s *DefaultWebhookService) findWebhookID(ctx context.Context, webhookID string) (domain.ID, error) {
+	id, err := domain.ParseID(webhookID)
+	if err != nil {
+		return id, NewValidationError("webhook_id", "must be UUID")
+	}
+
+	subscription, err := s.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return id, err
+	}
+	if subscription == nil {
+		return id, fmt.Errorf("%w: no such webhook with id=%s", ErrNotFound, webhookID)
+	}
+
+	return id, nil
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,109 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DBPoolConfig describes sizing of database connection pool.
+// Zero values keep pgxpool defaults.
+type DBPoolConfig struct {
+	MaxConns          int32
+	MinConns          int32
+	MaxConnLifetime   time.Duration
+	MaxConnIdleTime   time.Duration
+	HealthCheckPeriod time.Duration
+}
+
+// DBPoolConfigFromEnv reads pool sizing from DB_POOL_MAX_CONNS, DB_POOL_MIN_CONNS (integers)
+// and DB_POOL_MAX_CONN_LIFETIME, DB_POOL_MAX_CONN_IDLE_TIME, DB_POOL_HEALTH_CHECK_PERIOD (durations, e.g. 30m)
+func DBPoolConfigFromEnv() (*DBPoolConfig, error) {
+	config := &DBPoolConfig{}
+
+	var err error
+	if config.MaxConns, err = int32FromEnv("DB_POOL_MAX_CONNS"); err != nil {
+		return nil, err
+	}
+	if config.MinConns, err = int32FromEnv("DB_POOL_MIN_CONNS"); err != nil {
+		return nil, err
+	}
+	if config.MaxConnLifetime, err = durationFromEnv("DB_POOL_MAX_CONN_LIFETIME"); err != nil {
+		return nil, err
+	}
+	if config.MaxConnIdleTime, err = durationFromEnv("DB_POOL_MAX_CONN_IDLE_TIME"); err != nil {
+		return nil, err
+	}
+	if config.HealthCheckPeriod, err = durationFromEnv("DB_POOL_HEALTH_CHECK_PERIOD"); err != nil {
+		return nil, err
+	}
+
+	if config.MaxConns > 0 && config.MinConns > config.MaxConns {
+		return nil, fmt.Errorf(
+			"DB_POOL_MIN_CONNS (%d) cannot be greater than DB_POOL_MAX_CONNS (%d)",
+			config.MinConns, config.MaxConns,
+		)
+	}
+
+	return config, nil
+}
+
+func newPgxPoolConfig(dsn string, config *DBPoolConfig) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return poolConfig, nil
+	}
+
+	if config.MaxConns > 0 {
+		poolConfig.MaxConns = config.MaxConns
+	}
+	if config.MinConns > 0 {
+		poolConfig.MinConns = config.MinConns
+	}
+	if config.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = config.MaxConnLifetime
+	}
+	if config.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = config.MaxConnIdleTime
+	}
+	if config.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = config.HealthCheckPeriod
+	}
+
+	return poolConfig, nil
+}
+
+func int32FromEnv(name string) (int32, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected non-negative integer", name, value)
+	}
+
+	return int32(parsed), nil
+}
+
+func durationFromEnv(name string) (time.Duration, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected non-negative duration", name, value)
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,79 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/adapters/github"
+	"github.com/alphameo/pr-reviewnager/internal/adapters/gitlab"
+	"github.com/alphameo/pr-reviewnager/internal/app"
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+var defaultForgeRetry = RetryConfig{
+	PollInterval: time.Second,
+	BatchSize:    50,
+	Timeout:      5 * time.Second,
+	Retry: domain.RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   5 * time.Second,
+		MaxDelay:    30 * time.Minute,
+	},
+}
+
+// ForgeConfig describes synchronization of reviewers with code hosting.
+// Provider without token is not synchronized.
+type ForgeConfig struct {
+	// GitHubAPIURL and GitLabAPIURL default to public instances
+	GitHubAPIURL string
+	GitHubToken  string
+	GitLabAPIURL string
+	GitLabToken  string
+	RetryConfig
+}
+
+// ForgeConfigFromEnv reads GITHUB_API_URL, GITHUB_TOKEN, GITLAB_API_URL, GITLAB_TOKEN
+// and call settings from variables with FORGE prefix (see retryPolicyFromEnv)
+func ForgeConfigFromEnv() (*ForgeConfig, error) {
+	retry, err := retryPolicyFromEnv("FORGE", defaultForgeRetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForgeConfig{
+		GitHubAPIURL: os.Getenv("GITHUB_API_URL"),
+		GitHubToken:  os.Getenv("GITHUB_TOKEN"),
+		GitLabAPIURL: os.Getenv("GITLAB_API_URL"),
+		GitLabToken:  os.Getenv("GITLAB_TOKEN"),
+		RetryConfig:  retry,
+	}, nil
+}
+
+// clients() creates clients of providers with configured token
+func (c *ForgeConfig) clients() (map[domain.IdentityProvider]app.ForgeClient, error) {
+	clients := make(map[domain.IdentityProvider]app.ForgeClient)
+
+	if c.GitHubToken != "" {
+		client, err := github.NewClient(c.GitHubAPIURL, c.GitHubToken, c.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+		clients[domain.ProviderGitHub] = client
+	}
+	if c.GitLabToken != "" {
+		client, err := gitlab.NewClient(c.GitLabAPIURL, c.GitLabToken, c.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitLab client: %w", err)
+		}
+		clients[domain.ProviderGitLab] = client
+	}
+
+	return clients, nil
+}
+
+// lease() returns time during which claimed call is not retried again.
+// It exceeds time of the longest call, which takes up to three requests to GitLab.
+func (c *ForgeConfig) lease() time.Duration {
+	return 4*c.Timeout + c.PollInterval
+}
@@ -0,0 +1,20 @@
+package cfg
+
+import (
+	"os"
+)
+
+// IntegrationConfig holds secrets authenticating webhooks of code hosting
+type IntegrationConfig struct {
+	GitHubWebhookSecret string
+	GitLabWebhookToken  string
+}
+
+// IntegrationConfigFromEnv reads GITHUB_WEBHOOK_SECRET and GITLAB_WEBHOOK_TOKEN.
+// Webhooks of provider without secret are rejected.
+func IntegrationConfigFromEnv() *IntegrationConfig {
+	return &IntegrationConfig{
+		GitHubWebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitLabWebhookToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+	}
+}
@@ -0,0 +1,166 @@
+package cfg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/alphameo/pr-reviewnager/internal/infra/db/memory"
+)
+
+type MemoryRepositoryContainer struct {
+	userRepo *memory.UserRepository
+	idRepo   *memory.UserIdentityRepository
+	inRepo   *memory.InboundDeliveryRepository
+	teamRepo *memory.TeamRepository
+	prRepo   *memory.PullRequestRepository
+	rotRepo  *memory.ReviewerRotationRepository
+	statRepo *memory.StatsRepository
+	evtRepo  *memory.AssignmentEventRepository
+	outbox   *memory.OutboxRepository
+	hookRepo *memory.WebhookSubscriptionRepository
+	dlvRepo  *memory.WebhookDeliveryRepository
+	fcRepo   *memory.ForgeCallRepository
+	txor     *memory.Transactor
+}
+
+func NewMemoryRepositoryContainer() (*MemoryRepositoryContainer, error) {
+	store := memory.NewStore()
+
+	teamRepo, err := memory.NewTeamRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create team repository: %w", err)
+	}
+
+	userRepo, err := memory.NewUserRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user repository: %w", err)
+	}
+
+	idRepo, err := memory.NewUserIdentityRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user identity repository: %w", err)
+	}
+
+	inRepo, err := memory.NewInboundDeliveryRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create inbound delivery repository: %w", err)
+	}
+
+	prRepo, err := memory.NewPullRequestRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request repository: %w", err)
+	}
+
+	rotRepo, err := memory.NewReviewerRotationRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reviewer rotation repository: %w", err)
+	}
+
+	statRepo, err := memory.NewStatsRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stats repository: %w", err)
+	}
+
+	evtRepo, err := memory.NewAssignmentEventRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create assignment event repository: %w", err)
+	}
+
+	outbox, err := memory.NewOutboxRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outbox repository: %w", err)
+	}
+
+	hookRepo, err := memory.NewWebhookSubscriptionRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription repository: %w", err)
+	}
+
+	dlvRepo, err := memory.NewWebhookDeliveryRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook delivery repository: %w", err)
+	}
+
+	fcRepo, err := memory.NewForgeCallRepository(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge call repository: %w", err)
+	}
+
+	txor, err := memory.NewTransactor(store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+
+	return &MemoryRepositoryContainer{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		idRepo:   idRepo,
+		inRepo:   inRepo,
+		prRepo:   prRepo,
+		rotRepo:  rotRepo,
+		statRepo: statRepo,
+		evtRepo:  evtRepo,
+		outbox:   outbox,
+		hookRepo: hookRepo,
+		dlvRepo:  dlvRepo,
+		fcRepo:   fcRepo,
+		txor:     txor,
+	}, nil
+}
+
+func (s *MemoryRepositoryContainer) UserRepository() domain.UserRepository {
+	return s.userRepo
+}
+
+func (s *MemoryRepositoryContainer) UserIdentityRepository() domain.UserIdentityRepository {
+	return s.idRepo
+}
+
+func (s *MemoryRepositoryContainer) InboundDeliveryRepository() domain.InboundDeliveryRepository {
+	return s.inRepo
+}
+
+func (s *MemoryRepositoryContainer) TeamRepository() domain.TeamRepository {
+	return s.teamRepo
+}
+
+func (s *MemoryRepositoryContainer) PullRequestRepository() domain.PullRequestRepository {
+	return s.prRepo
+}
+
+func (s *MemoryRepositoryContainer) ReviewerRotationRepository() domain.ReviewerRotationRepository {
+	return s.rotRepo
+}
+
+func (s *MemoryRepositoryContainer) StatsRepository() domain.StatsRepository {
+	return s.statRepo
+}
+
+func (s *MemoryRepositoryContainer) AssignmentEventRepository() domain.AssignmentEventRepository {
+	return s.evtRepo
+}
+
+func (s *MemoryRepositoryContainer) OutboxRepository() domain.OutboxRepository {
+	return s.outbox
+}
+
+func (s *MemoryRepositoryContainer) WebhookSubscriptionRepository() domain.WebhookSubscriptionRepository {
+	return s.hookRepo
+}
+
+func (s *MemoryRepositoryContainer) WebhookDeliveryRepository() domain.WebhookDeliveryRepository {
+	return s.dlvRepo
+}
+
+func (s *MemoryRepositoryContainer) ForgeCallRepository() domain.ForgeCallRepository {
+	return s.fcRepo
+}
+
+func (s *MemoryRepositoryContainer) Transactor() domain.Transactor {
+	return s.txor
+}
+
+func (s *MemoryRepositoryContainer) Close(_ context.Context) error {
+	return nil
+}
@@ -6,51 +6,140 @@ import (
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 	"github.com/alphameo/pr-reviewnager/internal/infra/db/postgres"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PSQLRepositoryContainer struct {
 	userRepo *postgres.UserRepository
+	idRepo   *postgres.UserIdentityRepository
+	inRepo   *postgres.InboundDeliveryRepository
 	teamRepo *postgres.TeamRepository
 	prRepo   *postgres.PullRequestRepository
-	conn     *pgx.Conn
+	rotRepo  *postgres.ReviewerRotationRepository
+	statRepo *postgres.StatsRepository
+	evtRepo  *postgres.AssignmentEventRepository
+	outbox   *postgres.OutboxRepository
+	hookRepo *postgres.WebhookSubscriptionRepository
+	dlvRepo  *postgres.WebhookDeliveryRepository
+	fcRepo   *postgres.ForgeCallRepository
+	txor     *postgres.Transactor
+	pool     *pgxpool.Pool
 }
 
-func NewPSQLRepositoryContainer(ctx context.Context, dsn string) (*PSQLRepositoryContainer, error) {
+func NewPSQLRepositoryContainer(
+	ctx context.Context,
+	dsn string,
+	poolConfig *DBPoolConfig,
+) (*PSQLRepositoryContainer, error) {
 	if ctx == nil {
 		ctx = context.Background()
 	}
 
-	conn, err := postgres.NewConnection(ctx, dsn)
+	pgxPoolConfig, err := newPgxPoolConfig(dsn, poolConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	pool, err := postgres.NewPool(ctx, pgxPoolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	queries := postgres.NewQueries(conn)
+	queries := postgres.NewQueries(pool)
 
-	teamRepo, err := postgres.NewTeamRepository(queries, conn)
+	teamRepo, err := postgres.NewTeamRepository(queries, pool)
 	if err != nil {
-		conn.Close(context.Background())
+		pool.Close()
 		return nil, fmt.Errorf("failed to create team repository: %w", err)
 	}
 
 	userRepo, err := postgres.NewUserRepository(queries)
 	if err != nil {
-		conn.Close(context.Background())
+		pool.Close()
 		return nil, fmt.Errorf("failed to create user repository: %w", err)
 	}
 
-	prRepo, err := postgres.NewPullRequestRepository(queries, conn)
+	idRepo, err := postgres.NewUserIdentityRepository(queries, pool)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create user identity repository: %w", err)
+	}
+
+	inRepo, err := postgres.NewInboundDeliveryRepository(queries)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create inbound delivery repository: %w", err)
+	}
+
+	prRepo, err := postgres.NewPullRequestRepository(queries, pool)
 	if err != nil {
-		conn.Close(context.Background())
+		pool.Close()
 		return nil, fmt.Errorf("failed to create pull request repository: %w", err)
 	}
 
+	rotRepo, err := postgres.NewReviewerRotationRepository(queries)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create reviewer rotation repository: %w", err)
+	}
+
+	statRepo, err := postgres.NewStatsRepository(queries)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create stats repository: %w", err)
+	}
+
+	evtRepo, err := postgres.NewAssignmentEventRepository(queries, pool)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create assignment event repository: %w", err)
+	}
+
+	outbox, err := postgres.NewOutboxRepository(queries, pool)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create outbox repository: %w", err)
+	}
+
+	hookRepo, err := postgres.NewWebhookSubscriptionRepository(queries)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create webhook subscription repository: %w", err)
+	}
+
+	dlvRepo, err := postgres.NewWebhookDeliveryRepository(queries, pool)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create webhook delivery repository: %w", err)
+	}
+
+	fcRepo, err := postgres.NewForgeCallRepository(queries)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create forge call repository: %w", err)
+	}
+
+	txor, err := postgres.NewTransactor(pool)
+	if err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to create transactor: %w", err)
+	}
+
 	return &PSQLRepositoryContainer{
 		teamRepo: teamRepo,
 		userRepo: userRepo,
+		idRepo:   idRepo,
+		inRepo:   inRepo,
 		prRepo:   prRepo,
-		conn:     conn,
+		rotRepo:  rotRepo,
+		statRepo: statRepo,
+		evtRepo:  evtRepo,
+		outbox:   outbox,
+		hookRepo: hookRepo,
+		dlvRepo:  dlvRepo,
+		fcRepo:   fcRepo,
+		txor:     txor,
+		pool:     pool,
 	}, nil
 }
 
@@ -58,6 +147,14 @@ func (s *PSQLRepositoryContainer) UserRepository() domain.UserRepository {
 	return s.userRepo
 }
 
+func (s *PSQLRepositoryContainer) UserIdentityRepository() domain.UserIdentityRepository {
+	return s.idRepo
+}
+
+func (s *PSQLRepositoryContainer) InboundDeliveryRepository() domain.InboundDeliveryRepository {
+	return s.inRepo
+}
+
 func (s *PSQLRepositoryContainer) TeamRepository() domain.TeamRepository {
 	return s.teamRepo
 }
@@ -66,12 +163,43 @@ func (s *PSQLRepositoryContainer) PullRequestRepository() domain.PullRequestRepo
 	return s.prRepo
 }
 
-func (s *PSQLRepositoryContainer) Close(ctx context.Context) error {
-	if s.conn == nil {
+func (s *PSQLRepositoryContainer) ReviewerRotationRepository() domain.ReviewerRotationRepository {
+	return s.rotRepo
+}
+
+func (s *PSQLRepositoryContainer) StatsRepository() domain.StatsRepository {
+	return s.statRepo
+}
+
+func (s *PSQLRepositoryContainer) AssignmentEventRepository() domain.AssignmentEventRepository {
+	return s.evtRepo
+}
+
+func (s *PSQLRepositoryContainer) OutboxRepository() domain.OutboxRepository {
+	return s.outbox
+}
+
+func (s *PSQLRepositoryContainer) WebhookSubscriptionRepository() domain.WebhookSubscriptionRepository {
+	return s.hookRepo
+}
+
+func (s *PSQLRepositoryContainer) WebhookDeliveryRepository() domain.WebhookDeliveryRepository {
+	return s.dlvRepo
+}
+
+func (s *PSQLRepositoryContainer) ForgeCallRepository() domain.ForgeCallRepository {
+	return s.fcRepo
+}
+
+func (s *PSQLRepositoryContainer) Transactor() domain.Transactor {
+	return s.txor
+}
+
+// Close() waits for acquired connections to be released and closes the pool
+func (s *PSQLRepositoryContainer) Close(_ context.Context) error {
+	if s.pool == nil {
 		return nil
 	}
-	if ctx == nil {
-		ctx = context.Background()
-	}
-	return s.conn.Close(ctx)
+	s.pool.Close()
+	return nil
 }
@@ -0,0 +1,92 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+// RetryConfig describes background processing of queued requests to remote service with retries
+type RetryConfig struct {
+	PollInterval time.Duration
+	BatchSize    int32
+	// Timeout limits single request to remote service
+	Timeout time.Duration
+	Retry   domain.RetryPolicy
+}
+
+// retryPolicyFromEnv reads <prefix>_POLL_INTERVAL, <prefix>_TIMEOUT, <prefix>_BACKOFF_BASE,
+// <prefix>_BACKOFF_MAX (durations) and <prefix>_BATCH_SIZE, <prefix>_MAX_ATTEMPTS (integers).
+// Unset values are replaced with defaults. Set values must be positive
+// and maximum backoff cannot be less than the base one.
+func retryPolicyFromEnv(prefix string, defaults RetryConfig) (RetryConfig, error) {
+	config := defaults
+
+	var err error
+	if config.PollInterval, err = positiveDurationFromEnv(prefix+"_POLL_INTERVAL", defaults.PollInterval); err != nil {
+		return RetryConfig{}, err
+	}
+	if config.BatchSize, err = positiveInt32FromEnv(prefix+"_BATCH_SIZE", defaults.BatchSize); err != nil {
+		return RetryConfig{}, err
+	}
+	if config.Timeout, err = positiveDurationFromEnv(prefix+"_TIMEOUT", defaults.Timeout); err != nil {
+		return RetryConfig{}, err
+	}
+	maxAttempts, err := positiveInt32FromEnv(prefix+"_MAX_ATTEMPTS", int32(defaults.Retry.MaxAttempts))
+	if err != nil {
+		return RetryConfig{}, err
+	}
+	config.Retry.MaxAttempts = int(maxAttempts)
+	if config.Retry.BaseDelay, err = positiveDurationFromEnv(prefix+"_BACKOFF_BASE", defaults.Retry.BaseDelay); err != nil {
+		return RetryConfig{}, err
+	}
+	if config.Retry.MaxDelay, err = positiveDurationFromEnv(prefix+"_BACKOFF_MAX", defaults.Retry.MaxDelay); err != nil {
+		return RetryConfig{}, err
+	}
+
+	if config.Retry.BaseDelay > config.Retry.MaxDelay {
+		return RetryConfig{}, fmt.Errorf(
+			"%s_BACKOFF_BASE (%s) cannot be greater than %s_BACKOFF_MAX (%s)",
+			prefix, config.Retry.BaseDelay, prefix, config.Retry.MaxDelay,
+		)
+	}
+
+	return config, nil
+}
+
+// positiveDurationFromEnv() returns defaultValue if variable is not set
+func positiveDurationFromEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	if strings.TrimSpace(os.Getenv(name)) == "" {
+		return defaultValue, nil
+	}
+
+	value, err := durationFromEnv(name)
+	if err != nil {
+		return 0, err
+	}
+	if value == 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected positive duration", name, os.Getenv(name))
+	}
+
+	return value, nil
+}
+
+// positiveInt32FromEnv() returns defaultValue if variable is not set
+func positiveInt32FromEnv(name string, defaultValue int32) (int32, error) {
+	if strings.TrimSpace(os.Getenv(name)) == "" {
+		return defaultValue, nil
+	}
+
+	value, err := int32FromEnv(name)
+	if err != nil {
+		return 0, err
+	}
+	if value == 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected positive integer", name, os.Getenv(name))
+	}
+
+	return value, nil
+}
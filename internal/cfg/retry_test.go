@@ -0,0 +1,77 @@
+package cfg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+func TestRetryPolicyFromEnv(t *testing.T) {
+	defaults := RetryConfig{
+		PollInterval: time.Second,
+		BatchSize:    10,
+		Timeout:      5 * time.Second,
+		Retry:        domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Second, MaxDelay: time.Minute},
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    RetryConfig
+		wantErr bool
+	}{
+		{name: "defaults", want: defaults},
+		{
+			name: "all set",
+			env: map[string]string{
+				"TEST_POLL_INTERVAL": "2s",
+				"TEST_BATCH_SIZE":    "20",
+				"TEST_TIMEOUT":       "3s",
+				"TEST_MAX_ATTEMPTS":  "4",
+				"TEST_BACKOFF_BASE":  "10s",
+				"TEST_BACKOFF_MAX":   "1h",
+			},
+			want: RetryConfig{
+				PollInterval: 2 * time.Second,
+				BatchSize:    20,
+				Timeout:      3 * time.Second,
+				Retry:        domain.RetryPolicy{MaxAttempts: 4, BaseDelay: 10 * time.Second, MaxDelay: time.Hour},
+			},
+		},
+		{
+			name: "equal backoffs",
+			env:  map[string]string{"TEST_BACKOFF_BASE": "1m"},
+			want: RetryConfig{
+				PollInterval: defaults.PollInterval,
+				BatchSize:    defaults.BatchSize,
+				Timeout:      defaults.Timeout,
+				Retry:        domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Minute},
+			},
+		},
+		{name: "zero interval", env: map[string]string{"TEST_POLL_INTERVAL": "0s"}, wantErr: true},
+		{name: "negative interval", env: map[string]string{"TEST_POLL_INTERVAL": "-1s"}, wantErr: true},
+		{name: "zero timeout", env: map[string]string{"TEST_TIMEOUT": "0"}, wantErr: true},
+		{name: "zero batch size", env: map[string]string{"TEST_BATCH_SIZE": "0"}, wantErr: true},
+		{name: "negative max attempts", env: map[string]string{"TEST_MAX_ATTEMPTS": "-1"}, wantErr: true},
+		{name: "zero base backoff", env: map[string]string{"TEST_BACKOFF_BASE": "0s"}, wantErr: true},
+		{name: "max backoff less than base", env: map[string]string{"TEST_BACKOFF_BASE": "1h"}, wantErr: true},
+		{name: "malformed duration", env: map[string]string{"TEST_BACKOFF_MAX": "soon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			got, err := retryPolicyFromEnv("TEST", defaults)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("retryPolicyFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("retryPolicyFromEnv() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package cfg
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+const (
+	RandomStrategy      = "random"
+	LeastLoadedStrategy = "least_loaded"
+	RoundRobinStrategy  = "round_robin"
+
+	defaultReviewerStrategy = RandomStrategy
+)
+
+// ReviewerSelectionConfig describes which reviewer selection strategy is used by each team
+type ReviewerSelectionConfig struct {
+	DefaultStrategy string
+	// team external key (name of the team on creation) -> strategy name
+	TeamStrategies map[string]string
+}
+
+// ReviewerSelectionConfigFromEnv reads strategies from REVIEWER_STRATEGY (default one)
+// and REVIEWER_TEAM_STRATEGIES (comma separated list of team=strategy pairs, where team is a key of team)
+func ReviewerSelectionConfigFromEnv() (*ReviewerSelectionConfig, error) {
+	config := &ReviewerSelectionConfig{
+		DefaultStrategy: strings.TrimSpace(os.Getenv("REVIEWER_STRATEGY")),
+		TeamStrategies:  make(map[string]string),
+	}
+	if config.DefaultStrategy == "" {
+		config.DefaultStrategy = defaultReviewerStrategy
+	}
+
+	teamStrategies := strings.TrimSpace(os.Getenv("REVIEWER_TEAM_STRATEGIES"))
+	if teamStrategies == "" {
+		return config, nil
+	}
+
+	for pair := range strings.SplitSeq(teamStrategies, ",") {
+		teamKey, strategy, ok := strings.Cut(pair, "=")
+		teamKey = strings.TrimSpace(teamKey)
+		strategy = strings.TrimSpace(strategy)
+		if !ok || teamKey == "" || strategy == "" {
+			return nil, fmt.Errorf("invalid team strategy %q: expected team=strategy", pair)
+		}
+		config.TeamStrategies[teamKey] = strategy
+	}
+
+	return config, nil
+}
+
+func newReviewerSelectorProvider(
+	config *ReviewerSelectionConfig,
+	repositoryContainer RepositoryContainer,
+) (*domain.TeamReviewerSelectorProvider, error) {
+	if config == nil {
+		config = &ReviewerSelectionConfig{DefaultStrategy: defaultReviewerStrategy}
+	}
+
+	defaultSelector, err := newReviewerSelector(config.DefaultStrategy, repositoryContainer)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSelectors := make(map[domain.ExternalKey]domain.ReviewerSelector, len(config.TeamStrategies))
+	for teamKey, strategy := range config.TeamStrategies {
+		selector, err := newReviewerSelector(strategy, repositoryContainer)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamKey, err)
+		}
+		teamSelectors[domain.ExistingExternalKey(teamKey)] = selector
+	}
+
+	return domain.NewTeamReviewerSelectorProvider(defaultSelector, teamSelectors)
+}
+
+func newReviewerSelector(strategy string, repositoryContainer RepositoryContainer) (domain.ReviewerSelector, error) {
+	switch strings.ToLower(strategy) {
+	case RandomStrategy:
+		return domain.NewRandomReviewerSelector(), nil
+	case LeastLoadedStrategy:
+		return domain.NewLeastLoadedReviewerSelector(repositoryContainer.PullRequestRepository())
+	case RoundRobinStrategy:
+		return domain.NewRoundRobinReviewerSelector(repositoryContainer.ReviewerRotationRepository())
+	default:
+		return nil, fmt.Errorf("unknown reviewer selection strategy: %s", strategy)
+	}
+}
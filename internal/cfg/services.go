@@ -6,14 +6,25 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/alphameo/pr-reviewnager/internal/adapters/webhook"
 	"github.com/alphameo/pr-reviewnager/internal/app"
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 )
 
 type RepositoryContainer interface {
 	UserRepository() domain.UserRepository
+	UserIdentityRepository() domain.UserIdentityRepository
+	InboundDeliveryRepository() domain.InboundDeliveryRepository
 	TeamRepository() domain.TeamRepository
 	PullRequestRepository() domain.PullRequestRepository
+	ReviewerRotationRepository() domain.ReviewerRotationRepository
+	StatsRepository() domain.StatsRepository
+	AssignmentEventRepository() domain.AssignmentEventRepository
+	OutboxRepository() domain.OutboxRepository
+	WebhookSubscriptionRepository() domain.WebhookSubscriptionRepository
+	WebhookDeliveryRepository() domain.WebhookDeliveryRepository
+	ForgeCallRepository() domain.ForgeCallRepository
+	Transactor() domain.Transactor
 	Close(ctx context.Context) error
 }
 
@@ -21,45 +32,183 @@ type ServiceContainer struct {
 	UserService        app.UserService
 	TeamService        app.TeamService
 	PullRequestService app.PullRequestService
+	StatsService       app.StatsService
+	WebhookService     app.WebhookService
+	ForgeEventService  app.ForgeEventService
+	// WebhookDispatcher must be run in background to deliver events to webhooks
+	WebhookDispatcher *app.WebhookDispatcher
+	// ForgeReviewerSync must be run in background to push queued calls to code hosting
+	ForgeReviewerSync *app.ForgeReviewerSync
 }
 
-func NewServiceContainer(repositoryContainer RepositoryContainer) (*ServiceContainer, error) {
+func NewServiceContainer(
+	repositoryContainer RepositoryContainer,
+	reviewerSelectionConfig *ReviewerSelectionConfig,
+	webhookConfig *WebhookConfig,
+	forgeConfig *ForgeConfig,
+) (*ServiceContainer, error) {
 	if repositoryContainer == nil {
 		return nil, errors.New("storage cannot be nil")
 	}
+	if webhookConfig == nil {
+		return nil, errors.New("webhookConfig cannot be nil")
+	}
+	if forgeConfig == nil {
+		return nil, errors.New("forgeConfig cannot be nil")
+	}
+
+	selectorProvider, err := newReviewerSelectorProvider(reviewerSelectionConfig, repositoryContainer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure reviewer selection: %w", err)
+	}
+
+	// every recorded event is also enqueued for delivery to webhooks
+	eventRepo, err := domain.NewOutboxEventRepository(
+		repositoryContainer.AssignmentEventRepository(),
+		repositoryContainer.OutboxRepository(),
+		repositoryContainer.Transactor(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create outbox event repository: %w", err)
+	}
+
 	prDomainServ, err := domain.NewDefaultPullRequestDomainService(
 		repositoryContainer.UserRepository(),
 		repositoryContainer.PullRequestRepository(),
 		repositoryContainer.TeamRepository(),
+		selectorProvider,
+		eventRepo,
+		repositoryContainer.Transactor(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create domain pull request service: %w", err)
 	}
 
+	teamDomainServ, err := domain.NewDefaultTeamDomainService(
+		repositoryContainer.UserRepository(),
+		repositoryContainer.PullRequestRepository(),
+		repositoryContainer.TeamRepository(),
+		selectorProvider,
+		eventRepo,
+		repositoryContainer.Transactor(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create domain team service: %w", err)
+	}
+
 	teamServ, err := app.NewDefaultTeamService(
+		teamDomainServ,
 		repositoryContainer.TeamRepository(),
 		repositoryContainer.UserRepository(),
+		eventRepo,
+		repositoryContainer.Transactor(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create team service: %w", err)
 	}
 
-	userServ, err := app.NewDefaultUserService(repositoryContainer.UserRepository())
+	userServ, err := app.NewDefaultUserService(
+		repositoryContainer.UserRepository(),
+		repositoryContainer.UserIdentityRepository(),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create user service: %w", err)
 	}
 
+	forgeClients, err := forgeConfig.clients()
+	if err != nil {
+		return nil, err
+	}
+
+	forgeSync, err := app.NewForgeReviewerSync(
+		forgeClients,
+		repositoryContainer.ForgeCallRepository(),
+		repositoryContainer.PullRequestRepository(),
+		repositoryContainer.UserIdentityRepository(),
+		app.ForgeReviewerSyncConfig{
+			PollInterval: forgeConfig.PollInterval,
+			BatchSize:    int(forgeConfig.BatchSize),
+			Lease:        forgeConfig.lease(),
+			Retry:        forgeConfig.Retry,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge reviewer sync: %w", err)
+	}
+
 	prServ, err := app.NewDefaultPullRequestService(
 		prDomainServ,
 		repositoryContainer.PullRequestRepository(),
+		repositoryContainer.UserRepository(),
+		repositoryContainer.TeamRepository(),
+		eventRepo,
+		forgeSync,
+		repositoryContainer.Transactor(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pull request service: %w", err)
 	}
 
+	statsServ, err := app.NewDefaultStatsService(
+		repositoryContainer.StatsRepository(),
+		repositoryContainer.TeamRepository(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stats service: %w", err)
+	}
+
+	forgeServ, err := app.NewDefaultForgeEventService(
+		prServ,
+		repositoryContainer.UserRepository(),
+		repositoryContainer.UserIdentityRepository(),
+		repositoryContainer.InboundDeliveryRepository(),
+		repositoryContainer.Transactor(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create forge event service: %w", err)
+	}
+
+	webhookServ, err := app.NewDefaultWebhookService(
+		repositoryContainer.WebhookSubscriptionRepository(),
+		repositoryContainer.WebhookDeliveryRepository(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook service: %w", err)
+	}
+
+	sender, err := webhook.NewHTTPSender(webhookConfig.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook sender: %w", err)
+	}
+
+	dispatcher, err := app.NewWebhookDispatcher(
+		repositoryContainer.OutboxRepository(),
+		repositoryContainer.WebhookSubscriptionRepository(),
+		repositoryContainer.WebhookDeliveryRepository(),
+		repositoryContainer.UserRepository(),
+		repositoryContainer.PullRequestRepository(),
+		repositoryContainer.TeamRepository(),
+		repositoryContainer.Transactor(),
+		sender,
+		app.WebhookDispatcherConfig{
+			PollInterval: webhookConfig.PollInterval,
+			BatchSize:    int(webhookConfig.BatchSize),
+			Lease:        webhookConfig.lease(),
+			Retry:        webhookConfig.Retry,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook dispatcher: %w", err)
+	}
+
 	return &ServiceContainer{
 		TeamService:        teamServ,
 		UserService:        userServ,
 		PullRequestService: prServ,
+		StatsService:       statsServ,
+		WebhookService:     webhookServ,
+		ForgeEventService:  forgeServ,
+		WebhookDispatcher:  dispatcher,
+		ForgeReviewerSync:  forgeSync,
 	}, nil
 }
@@ -0,0 +1,80 @@
+package cfg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	PostgresStorage = "postgres"
+	MemoryStorage   = "memory"
+
+	defaultStorage = PostgresStorage
+)
+
+// StorageConfig describes where repositories keep data
+type StorageConfig struct {
+	Storage string
+	// used by postgres storage only
+	DatabaseURL string
+	Pool        *DBPoolConfig
+}
+
+// StorageConfigFromEnv reads storage kind from STORAGE (postgres or memory, postgres by default).
+// Postgres storage also requires DATABASE_URL and reads pool sizing (see DBPoolConfigFromEnv).
+func StorageConfigFromEnv() (*StorageConfig, error) {
+	config := &StorageConfig{
+		Storage: strings.ToLower(strings.TrimSpace(os.Getenv("STORAGE"))),
+	}
+	if config.Storage == "" {
+		config.Storage = defaultStorage
+	}
+
+	switch config.Storage {
+	case MemoryStorage:
+		return config, nil
+	case PostgresStorage:
+	default:
+		return nil, fmt.Errorf("unknown storage: %s", config.Storage)
+	}
+
+	config.DatabaseURL = os.Getenv("DATABASE_URL")
+	if config.DatabaseURL == "" {
+		return nil, errors.New("DATABASE_URL environment variable is not set")
+	}
+
+	poolConfig, err := DBPoolConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	config.Pool = poolConfig
+
+	return config, nil
+}
+
+// NewRepositoryContainer() creates repositories of configured storage
+func NewRepositoryContainer(ctx context.Context, config *StorageConfig) (RepositoryContainer, error) {
+	if config == nil {
+		return nil, errors.New("storage config cannot be nil")
+	}
+
+	switch config.Storage {
+	case MemoryStorage:
+		container, err := NewMemoryRepositoryContainer()
+		if err != nil {
+			return nil, err
+		}
+		return container, nil
+	case PostgresStorage:
+		container, err := NewPSQLRepositoryContainer(ctx, config.DatabaseURL, config.Pool)
+		if err != nil {
+			return nil, err
+		}
+		return container, nil
+	default:
+		return nil, fmt.Errorf("unknown storage: %s", config.Storage)
+	}
+}
@@ -0,0 +1,39 @@
+package cfg
+
+import (
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+var defaultWebhookRetry = RetryConfig{
+	PollInterval: time.Second,
+	BatchSize:    100,
+	Timeout:      5 * time.Second,
+	Retry: domain.RetryPolicy{
+		MaxAttempts: 8,
+		BaseDelay:   time.Second,
+		MaxDelay:    10 * time.Minute,
+	},
+}
+
+// WebhookConfig describes delivery of events to webhook subscribers
+type WebhookConfig struct {
+	RetryConfig
+}
+
+// WebhookConfigFromEnv reads delivery settings from variables with WEBHOOK prefix (see retryPolicyFromEnv)
+func WebhookConfigFromEnv() (*WebhookConfig, error) {
+	retry, err := retryPolicyFromEnv("WEBHOOK", defaultWebhookRetry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebhookConfig{RetryConfig: retry}, nil
+}
+
+// lease() returns time during which claimed delivery is not sent again.
+// It exceeds request timeout, so delivery in flight is not duplicated.
+func (c *WebhookConfig) lease() time.Duration {
+	return 2*c.Timeout + c.PollInterval
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// SystemActor is recorded as actor of changes made without identified initiator
+const SystemActor = "system"
+
+type actorKey struct{}
+
+// WithActor() returns context, changes made within which are attributed to actor.
+// Blank actor leaves ctx unchanged.
+func WithActor(ctx context.Context, actor string) context.Context {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return ctx
+	}
+
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext() returns actor set by WithActor() or SystemActor if there is none
+func ActorFromContext(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok {
+		return actor
+	}
+
+	return SystemActor
+}
@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type AssignmentEventType string
+
+const (
+	EventPRCreated          AssignmentEventType = "pull_request_created"
+	EventReviewerAssigned   AssignmentEventType = "reviewer_assigned"
+	EventReviewerUnassigned AssignmentEventType = "reviewer_unassigned"
+	EventReviewerReassigned AssignmentEventType = "reviewer_reassigned"
+	EventPRMerged           AssignmentEventType = "pull_request_merged"
+	EventPRReady            AssignmentEventType = "pull_request_ready"
+	EventPRClosed           AssignmentEventType = "pull_request_closed"
+	EventPRReopened         AssignmentEventType = "pull_request_reopened"
+	EventUserActivated      AssignmentEventType = "user_activated"
+	EventUserDeactivated    AssignmentEventType = "user_deactivated"
+	EventTeamCreated        AssignmentEventType = "team_created"
+	EventTeamMemberAdded    AssignmentEventType = "team_member_added"
+	EventTeamMemberRemoved  AssignmentEventType = "team_member_removed"
+	EventTeamRenamed        AssignmentEventType = "team_renamed"
+	EventTeamParentChanged  AssignmentEventType = "team_parent_changed"
+	EventTeamDeleted        AssignmentEventType = "team_deleted"
+)
+
+// AssignmentEventTypes lists all known event types
+var AssignmentEventTypes = []AssignmentEventType{
+	EventPRCreated,
+	EventReviewerAssigned,
+	EventReviewerUnassigned,
+	EventReviewerReassigned,
+	EventPRMerged,
+	EventPRReady,
+	EventPRClosed,
+	EventPRReopened,
+	EventUserActivated,
+	EventUserDeactivated,
+	EventTeamCreated,
+	EventTeamMemberAdded,
+	EventTeamMemberRemoved,
+	EventTeamRenamed,
+	EventTeamParentChanged,
+	EventTeamDeleted,
+}
+
+func NewAssignmentEventType(value string) (AssignmentEventType, error) {
+	eventType := AssignmentEventType(strings.ToLower(strings.TrimSpace(value)))
+	if !slices.Contains(AssignmentEventTypes, eventType) {
+		return "", NewValidationError("event_type", fmt.Sprintf("unknown value %q", value))
+	}
+
+	return eventType, nil
+}
+
+func (t AssignmentEventType) String() string {
+	return string(t)
+}
+
+// AssignmentEvent is an entry of append-only audit log of review assignments
+// and changes affecting them. Fields not related to event type are nil.
+type AssignmentEvent struct {
+	ID         ID
+	Type       AssignmentEventType
+	Actor      string
+	OccurredAt time.Time
+
+	PullRequestID *ID
+	TeamID        *ID
+	// author of created pull request, assigned or unassigned reviewer,
+	// activated or deactivated user, added or removed team member
+	UserID        *ID
+	OldReviewerID *ID
+	NewReviewerID *ID
+	// MissingApprovals is set for pull request merged on code hosting without approvals required by team
+	MissingApprovals *int
+}
+
+func newAssignmentEvent(ctx context.Context, eventType AssignmentEventType) *AssignmentEvent {
+	return &AssignmentEvent{
+		ID:         NewID(),
+		Type:       eventType,
+		Actor:      ActorFromContext(ctx),
+		OccurredAt: time.Now(),
+	}
+}
+
+// PullRequestCreatedEvents() returns events of pull request creation
+// followed by assignment of its reviewers
+func PullRequestCreatedEvents(ctx context.Context, pullRequest *PullRequest, teamID ID) []*AssignmentEvent {
+	prID := pullRequest.ID()
+	authorID := pullRequest.AuthorID()
+
+	created := newAssignmentEvent(ctx, EventPRCreated)
+	created.PullRequestID = &prID
+	created.TeamID = &teamID
+	created.UserID = &authorID
+
+	events := []*AssignmentEvent{created}
+	for _, reviewerID := range pullRequest.ReviewerIDs() {
+		events = append(events, ReviewerAssignedEvent(ctx, prID, reviewerID))
+	}
+
+	return events
+}
+
+func ReviewerAssignedEvent(ctx context.Context, pullRequestID ID, reviewerID ID) *AssignmentEvent {
+	event := newAssignmentEvent(ctx, EventReviewerAssigned)
+	event.PullRequestID = &pullRequestID
+	event.UserID = &reviewerID
+	return event
+}
+
+func ReviewerUnassignedEvent(ctx context.Context, pullRequestID ID, reviewerID ID) *AssignmentEvent {
+	event := newAssignmentEvent(ctx, EventReviewerUnassigned)
+	event.PullRequestID = &pullRequestID
+	event.UserID = &reviewerID
+	return event
+}
+
+func ReviewerReassignedEvent(ctx context.Context, pullRequestID ID, oldReviewerID ID, newReviewerID ID) *AssignmentEvent {
+	event := newAssignmentEvent(ctx, EventReviewerReassigned)
+	event.PullRequestID = &pullRequestID
+	event.OldReviewerID = &oldReviewerID
+	event.NewReviewerID = &newReviewerID
+	return event
+}
+
+func PullRequestMergedEvent(ctx context.Context, pullRequest *PullRequest) *AssignmentEvent {
+	prID := pullRequest.ID()
+
+	event := newAssignmentEvent(ctx, EventPRMerged)
+	event.PullRequestID = &prID
+	if mergedAt := pullRequest.MergedAt(); mergedAt != nil {
+		event.OccurredAt = *mergedAt
+	}
+	return event
+}
+
+// PullRequestStatusChangedEvent() returns event of pull request moved
+// to ready, closed or reopened status
+func PullRequestStatusChangedEvent(ctx context.Context, pullRequest *PullRequest, eventType AssignmentEventType) *AssignmentEvent {
+	prID := pullRequest.ID()
+
+	event := newAssignmentEvent(ctx, eventType)
+	event.PullRequestID = &prID
+	return event
+}
+
+// UserActivityChangedEvent() returns event of user activation or deactivation
+// according to the current state of user. teamID is nil for users without team.
+func UserActivityChangedEvent(ctx context.Context, user *User, teamID *ID) *AssignmentEvent {
+	eventType := EventUserDeactivated
+	if user.Active() {
+		eventType = EventUserActivated
+	}
+	userID := user.ID()
+
+	event := newAssignmentEvent(ctx, eventType)
+	event.UserID = &userID
+	event.TeamID = teamID
+	return event
+}
+
+func TeamCreatedEvent(ctx context.Context, team *Team) *AssignmentEvent {
+	teamID := team.ID()
+
+	event := newAssignmentEvent(ctx, EventTeamCreated)
+	event.TeamID = &teamID
+	return event
+}
+
+// TeamChangedEvent() returns event of team renaming or deletion
+func TeamChangedEvent(ctx context.Context, team *Team, eventType AssignmentEventType) *AssignmentEvent {
+	teamID := team.ID()
+
+	event := newAssignmentEvent(ctx, eventType)
+	event.TeamID = &teamID
+	return event
+}
+
+func TeamMemberAddedEvent(ctx context.Context, teamID ID, userID ID) *AssignmentEvent {
+	event := newAssignmentEvent(ctx, EventTeamMemberAdded)
+	event.TeamID = &teamID
+	event.UserID = &userID
+	return event
+}
+
+func TeamMemberRemovedEvent(ctx context.Context, teamID ID, userID ID) *AssignmentEvent {
+	event := newAssignmentEvent(ctx, EventTeamMemberRemoved)
+	event.TeamID = &teamID
+	event.UserID = &userID
+	return event
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+type AssignmentEventRepository interface {
+	// Append() stores events. Called within transaction, events are stored only
+	// if the transaction is committed.
+	Append(ctx context.Context, events ...*AssignmentEvent) error
+	// FindByPullRequestID() returns events of pull request in order of occurrence
+	FindByPullRequestID(ctx context.Context, pullRequestID ID) ([]*AssignmentEvent, error)
+}
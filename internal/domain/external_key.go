@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxExternalKeyLength = 255
+
+// ExternalKey is an opaque identifier of entity in external systems (API clients, code hosting).
+// Unlike ID it is not generated by the service.
+type ExternalKey string
+
+func NewExternalKey(key string) (ExternalKey, error) {
+	processed := strings.TrimSpace(key)
+
+	eKey := ExistingExternalKey(processed)
+	if err := eKey.Validate(); err != nil {
+		return "", err
+	}
+
+	return eKey, nil
+}
+
+func ExistingExternalKey(key string) ExternalKey {
+	return ExternalKey(key)
+}
+
+func (k ExternalKey) Value() string {
+	return string(k)
+}
+
+func (k ExternalKey) String() string {
+	return string(k)
+}
+
+func (k ExternalKey) Validate() error {
+	if len(k.String()) == 0 {
+		return NewValidationError("external_key", "cannot be empty")
+	}
+	if len(k.String()) > maxExternalKeyLength {
+		return NewValidationError("external_key", fmt.Sprintf("cannot be longer than %d characters", maxExternalKeyLength))
+	}
+
+	return nil
+}
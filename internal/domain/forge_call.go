@@ -0,0 +1,134 @@
+package domain
+
+import "time"
+
+type ForgeCallAction string
+
+const (
+	ForgeRequestReviewers   ForgeCallAction = "request_reviewers"
+	ForgeUnrequestReviewers ForgeCallAction = "unrequest_reviewers"
+)
+
+func (a ForgeCallAction) String() string {
+	return string(a)
+}
+
+// ForgeCall is a change of reviewers at code hosting, which is queued with the change
+// in the service and pushed in background, failed calls are retried.
+// Status DeliveryDelivered means that code hosting accepted the change.
+type ForgeCall struct {
+	id            ID
+	pullRequestID ID
+	action        ForgeCallAction
+	reviewerIDs   []ID
+	status        DeliveryStatus
+	attempts      int
+	nextAttemptAt time.Time
+	lastError     string
+	createdAt     time.Time
+	completedAt   *time.Time
+}
+
+func NewForgeCall(pullRequestID ID, action ForgeCallAction, reviewerIDs []ID, now time.Time) *ForgeCall {
+	return &ForgeCall{
+		id:            NewID(),
+		pullRequestID: pullRequestID,
+		action:        action,
+		reviewerIDs:   reviewerIDs,
+		status:        DeliveryPending,
+		nextAttemptAt: now,
+		createdAt:     now,
+	}
+}
+
+func ExistingForgeCall(
+	id ID,
+	pullRequestID ID,
+	action ForgeCallAction,
+	reviewerIDs []ID,
+	status DeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	createdAt time.Time,
+	completedAt *time.Time,
+) *ForgeCall {
+	return &ForgeCall{
+		id:            id,
+		pullRequestID: pullRequestID,
+		action:        action,
+		reviewerIDs:   reviewerIDs,
+		status:        status,
+		attempts:      attempts,
+		nextAttemptAt: nextAttemptAt,
+		lastError:     lastError,
+		createdAt:     createdAt,
+		completedAt:   completedAt,
+	}
+}
+
+func (c *ForgeCall) ID() ID {
+	return c.id
+}
+
+func (c *ForgeCall) PullRequestID() ID {
+	return c.pullRequestID
+}
+
+func (c *ForgeCall) Action() ForgeCallAction {
+	return c.action
+}
+
+// ReviewerIDs() returns users, which are requested or unrequested as reviewers
+func (c *ForgeCall) ReviewerIDs() []ID {
+	return c.reviewerIDs
+}
+
+func (c *ForgeCall) Status() DeliveryStatus {
+	return c.status
+}
+
+func (c *ForgeCall) Attempts() int {
+	return c.attempts
+}
+
+func (c *ForgeCall) NextAttemptAt() time.Time {
+	return c.nextAttemptAt
+}
+
+// LastError() returns reason of the last failed attempt
+func (c *ForgeCall) LastError() string {
+	return c.lastError
+}
+
+func (c *ForgeCall) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c *ForgeCall) CompletedAt() *time.Time {
+	if c.completedAt == nil {
+		return nil
+	}
+	copy := *c.completedAt
+	return &copy
+}
+
+func (c *ForgeCall) RecordSuccess(now time.Time) {
+	c.attempts++
+	c.status = DeliveryDelivered
+	c.lastError = ""
+	c.completedAt = &now
+}
+
+// RecordFailure() schedules next attempt according to policy or marks call dead
+// if there are no attempts left
+func (c *ForgeCall) RecordFailure(reason string, now time.Time, policy RetryPolicy) {
+	c.attempts++
+	c.lastError = reason
+	if c.attempts >= policy.MaxAttempts {
+		c.status = DeliveryDead
+		return
+	}
+
+	c.nextAttemptAt = now.Add(policy.Delay(c.attempts))
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type ForgeCallRepository interface {
+	Create(ctx context.Context, call *ForgeCall) error
+	// ClaimDue() returns pending calls with next attempt not later than now
+	// and postpones their next attempt by lease, so concurrent workers skip them
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*ForgeCall, error)
+	Update(ctx context.Context, call *ForgeCall) error
+}
@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// InboundDeliveryRepository remembers webhook deliveries received from code hosting,
+// so deliveries retried by provider are processed once
+type InboundDeliveryRepository interface {
+	// Claim() records delivery and reports whether it was recorded by this call.
+	// Claiming already recorded delivery is not an error and returns false.
+	Claim(ctx context.Context, provider IdentityProvider, deliveryID string, receivedAt time.Time) (bool, error)
+}
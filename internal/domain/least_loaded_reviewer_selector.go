@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"slices"
+)
+
+// LeastLoadedReviewerSelector chooses reviewers with the lowest number of open reviews,
+// ties are broken randomly
+type LeastLoadedReviewerSelector struct {
+	prRepo PullRequestRepository
+}
+
+func NewLeastLoadedReviewerSelector(pullRequestRepository PullRequestRepository) (*LeastLoadedReviewerSelector, error) {
+	if pullRequestRepository == nil {
+		return nil, errors.New("pullRequestRepository cannot be nil")
+	}
+
+	return &LeastLoadedReviewerSelector{prRepo: pullRequestRepository}, nil
+}
+
+func (s *LeastLoadedReviewerSelector) SelectReviewers(ctx context.Context, team *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error) {
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	available := excludeUsers(candidates, except...)
+	if count <= 0 || len(available) == 0 {
+		return &ReviewerSelection{Reviewers: []*User{}}, nil
+	}
+
+	loads, err := s.prRepo.CountOpenReviewsByTeamID(ctx, team.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers := make([]*User, 0, min(count, len(available)))
+	for len(reviewers) < count && len(available) > 0 {
+		idx := leastLoadedUserIndex(available, loads)
+		reviewers = append(reviewers, available[idx])
+		available = slices.Delete(available, idx, idx+1)
+	}
+
+	return &ReviewerSelection{Reviewers: reviewers}, nil
+}
+
+// leastLoadedUserIndex returns index of random user among those with minimal load
+func leastLoadedUserIndex(users []*User, loads map[ID]int) int {
+	minLoad := loads[users[0].ID()]
+	for _, u := range users[1:] {
+		minLoad = min(minLoad, loads[u.ID()])
+	}
+
+	leastLoaded := make([]int, 0, len(users))
+	for i, u := range users {
+		if loads[u.ID()] == minLoad {
+			leastLoaded = append(leastLoaded, i)
+		}
+	}
+
+	return leastLoaded[rand.Intn(len(leastLoaded))]
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+// OutboxRepository keeps events until they are published to webhook subscribers
+type OutboxRepository interface {
+	// Enqueue() stores events to be published. Called within transaction, events become
+	// visible to publisher only if the transaction is committed.
+	Enqueue(ctx context.Context, events ...*AssignmentEvent) error
+	// FindPendingForUpdate() returns at most limit unpublished events in order of enqueueing
+	// and locks them until the end of transaction, skipping events locked by others.
+	// Must be called within Transactor.WithinTransaction(), returns ErrNoTransaction otherwise.
+	FindPendingForUpdate(ctx context.Context, limit int) ([]*AssignmentEvent, error)
+	MarkPublished(ctx context.Context, eventIDs ...ID) error
+}
+
+// OutboxEventRepository appends events to audit log and enqueues them for publishing
+// in a single transaction
+type OutboxEventRepository struct {
+	events     AssignmentEventRepository
+	outbox     OutboxRepository
+	transactor Transactor
+}
+
+func NewOutboxEventRepository(
+	assignmentEventRepository AssignmentEventRepository,
+	outboxRepository OutboxRepository,
+	transactor Transactor,
+) (*OutboxEventRepository, error) {
+	if assignmentEventRepository == nil {
+		return nil, errors.New("assignmentEventRepository cannot be nil")
+	}
+	if outboxRepository == nil {
+		return nil, errors.New("outboxRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+
+	return &OutboxEventRepository{
+		events:     assignmentEventRepository,
+		outbox:     outboxRepository,
+		transactor: transactor,
+	}, nil
+}
+
+func (r *OutboxEventRepository) Append(ctx context.Context, events ...*AssignmentEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := r.events.Append(ctx, events...); err != nil {
+			return err
+		}
+		return r.outbox.Enqueue(ctx, events...)
+	})
+}
+
+func (r *OutboxEventRepository) FindByPullRequestID(ctx context.Context, pullRequestID ID) ([]*AssignmentEvent, error) {
+	return r.events.FindByPullRequestID(ctx, pullRequestID)
+}
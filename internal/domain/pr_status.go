@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -10,8 +11,20 @@ type PRStatus string
 const (
 	PROpen   PRStatus = "open"
 	PRMerged PRStatus = "merged"
+	// PRDraft is pull request, which is not ready for review and has no reviewers
+	PRDraft PRStatus = "draft"
+	// PRClosed is pull request, which was closed without merge and can be reopened
+	PRClosed PRStatus = "closed"
 )
 
+// prTransitions lists statuses, to which pull request can move from the given one
+var prTransitions = map[PRStatus][]PRStatus{
+	PRDraft:  {PROpen, PRClosed},
+	PROpen:   {PRMerged, PRClosed},
+	PRClosed: {PROpen},
+	PRMerged: {},
+}
+
 func NewPRStatus(value string) (PRStatus, error) {
 	processed := strings.ToLower(strings.TrimSpace(value))
 	switch processed {
@@ -19,8 +32,12 @@ func NewPRStatus(value string) (PRStatus, error) {
 		return PROpen, nil
 	case "merged":
 		return PRMerged, nil
+	case "draft":
+		return PRDraft, nil
+	case "closed":
+		return PRClosed, nil
 	default:
-		return PRStatus(""), fmt.Errorf("no PR status: %s", processed)
+		return PRStatus(""), NewValidationError("status", fmt.Sprintf("unknown value %q", processed))
 	}
 }
 
@@ -28,6 +45,11 @@ func ExistingPRStatus(value string) PRStatus {
 	return PRStatus(value)
 }
 
+// CanTransitionTo() reports whether pull request with this status can move to next one
+func (s PRStatus) CanTransitionTo(next PRStatus) bool {
+	return slices.Contains(prTransitions[s], next)
+}
+
 func (s PRStatus) String() string {
 	return string(s)
 }
@@ -7,61 +7,112 @@ import (
 	"time"
 )
 
-const MaxReviewersCount int = 2
-
 var (
 	ErrPRAlreadyMerged           = errors.New("PR is already merged")
-	ErrMaxReviewersCount         = fmt.Errorf("maximum number of reviewers is %d", MaxReviewersCount)
+	ErrMaxReviewersCount         = errors.New("maximum number of reviewers exceeded")
 	ErrAlreadyAssignedAsReviewer = errors.New("user already assiggned as reviewer")
+	ErrNotEnoughApprovals        = errors.New("not enough approvals")
+	ErrPRNotOpen                 = errors.New("PR is not open")
+	ErrInvalidStatusTransition   = errors.New("invalid PR status transition")
 )
 
 type PullRequest struct {
 	id        ID
+	key       ExternalKey
 	title     PRTitle
 	authorID  ID
+	teamID    *ID
 	createdAt time.Time
 	status    PRStatus
 	mergedAt  *time.Time
 	// slice (not map) because reviewers count is often not large
-	reviewerIDs []ID
+	reviewers    []Reviewer
+	maxReviewers int
+	// incremented on every save, used for optimistic concurrency control
+	version int64
+	// reassignments made since pull request was loaded, saved by repository
+	reassignments []ReviewerReassignment
+}
+
+// Reviewer is a user assigned to pull request and the state of the review
+type Reviewer struct {
+	UserID     ID
+	State      ReviewState
+	AssignedAt time.Time
+	// ReviewedAt is time of the latest submitted review, nil while review is pending
+	ReviewedAt *time.Time
+	// FallbackTeamID is an ancestor of the team of pull request, which reviewer was drawn from,
+	// nil if reviewer is drawn from the team of pull request
+	FallbackTeamID *ID
 }
 
-func NewPullRequest(title PRTitle, authorID ID) (*PullRequest, error) {
-	return NewPullRequestWithID(NewID(), title, authorID)
+// ReviewerReassignment records replacement of one reviewer by another
+type ReviewerReassignment struct {
+	OldReviewerID ID
+	NewReviewerID ID
+	ReassignedAt  time.Time
 }
 
-func NewPullRequestWithID(id ID, title PRTitle, authorID ID) (*PullRequest, error) {
+func NewPullRequest(key ExternalKey, title PRTitle, authorID ID) (*PullRequest, error) {
+	return NewPullRequestWithID(NewID(), key, title, authorID)
+}
+
+func NewPullRequestWithID(id ID, key ExternalKey, title PRTitle, authorID ID) (*PullRequest, error) {
 	return &PullRequest{
 		id,
+		key,
 		title,
 		authorID,
+		nil,
 		time.Now(),
 		PROpen,
 		nil,
-		make([]ID, 0, MaxReviewersCount),
+		make([]Reviewer, 0, DefaultMaxReviewersCount),
+		DefaultMaxReviewersCount,
+		InitialVersion,
+		nil,
 	}, nil
 }
 
+// NewDraftPullRequest() creates pull request, which gets reviewers only after MarkAsReady()
+func NewDraftPullRequest(key ExternalKey, title PRTitle, authorID ID) (*PullRequest, error) {
+	pr, err := NewPullRequest(key, title, authorID)
+	if err != nil {
+		return nil, err
+	}
+	pr.status = PRDraft
+	return pr, nil
+}
+
 func ExistingPullRequest(
 	id ID,
+	key ExternalKey,
 	title PRTitle,
 	authorID ID,
+	teamID *ID,
 	createdAt time.Time,
 	status PRStatus,
 	mergedAt *time.Time,
-	reviewerIDs []ID,
+	reviewers []Reviewer,
+	maxReviewers int,
+	version int64,
 ) *PullRequest {
-	rIDs := make([]ID, 0, MaxReviewersCount)
-	rIDs = append(rIDs, reviewerIDs...)
+	rs := make([]Reviewer, 0, max(len(reviewers), maxReviewers))
+	rs = append(rs, reviewers...)
 
 	return &PullRequest{
 		id,
+		key,
 		title,
 		authorID,
+		teamID,
 		createdAt,
 		status,
 		mergedAt,
-		rIDs,
+		rs,
+		maxReviewers,
+		version,
+		nil,
 	}
 }
 
@@ -69,6 +120,10 @@ func (p *PullRequest) ID() ID {
 	return p.id
 }
 
+func (p *PullRequest) Key() ExternalKey {
+	return p.key
+}
+
 func (p *PullRequest) Title() PRTitle {
 	return p.title
 }
@@ -77,6 +132,21 @@ func (p *PullRequest) AuthorID() ID {
 	return p.authorID
 }
 
+// TeamID() returns team, which pool reviewers are drawn from.
+// Nil means primary team of the author.
+func (p *PullRequest) TeamID() *ID {
+	if p.teamID == nil {
+		return nil
+	}
+	id := *p.teamID
+	return &id
+}
+
+// SetTeamID() chooses team, which pool reviewers of pull request are drawn from
+func (p *PullRequest) SetTeamID(teamID ID) {
+	p.teamID = &teamID
+}
+
 func (p *PullRequest) Status() PRStatus {
 	return p.status
 }
@@ -93,48 +163,208 @@ func (p *PullRequest) CreatedAt() time.Time {
 	return p.createdAt
 }
 
+func (p *PullRequest) Version() int64 {
+	return p.version
+}
+
+// SetVersion() is used by repositories to reflect version of saved pull request
+func (p *PullRequest) SetVersion(version int64) {
+	p.version = version
+}
+
 func (p *PullRequest) ReviewerIDs() []ID {
-	return slices.Clone(p.reviewerIDs)
+	ids := make([]ID, len(p.reviewers))
+	for i, r := range p.reviewers {
+		ids[i] = r.UserID
+	}
+	return ids
+}
+
+// Reviewers() returns assigned reviewers with states of their reviews
+func (p *PullRequest) Reviewers() []Reviewer {
+	return slices.Clone(p.reviewers)
+}
+
+// Approvals() returns number of reviewers, who approved pull request
+func (p *PullRequest) Approvals() int {
+	count := 0
+	for _, r := range p.reviewers {
+		if r.State == ReviewApproved {
+			count++
+		}
+	}
+	return count
+}
+
+func (p *PullRequest) MaxReviewers() int {
+	return p.maxReviewers
 }
 
+// SetMaxReviewers() limits number of reviewers, which can be assigned to pull request
+func (p *PullRequest) SetMaxReviewers(count int) error {
+	if count < 0 {
+		return fmt.Errorf("%w: limit cannot be negative", ErrInvalidReviewersRange)
+	}
+	if len(p.reviewers) > count {
+		return fmt.Errorf("%w: %d reviewers already assigned", ErrMaxReviewersCount, len(p.reviewers))
+	}
+
+	p.maxReviewers = count
+	return nil
+}
+
+// AssignReviewer() adds reviewer with pending review
 func (p *PullRequest) AssignReviewer(reviewerID ID) error {
-	if len(p.reviewerIDs) == MaxReviewersCount {
-		return ErrMaxReviewersCount
+	if len(p.reviewers) >= p.maxReviewers {
+		return fmt.Errorf("%w: limit is %d", ErrMaxReviewersCount, p.maxReviewers)
 	}
 
-	if p.status == PRMerged {
-		return ErrPRAlreadyMerged
+	if err := p.checkOpen(); err != nil {
+		return err
 	}
 
-	if slices.Contains(p.reviewerIDs, reviewerID) {
+	if p.reviewerIndex(reviewerID) != -1 {
 		return fmt.Errorf("%w: id=%v", ErrAlreadyAssignedAsReviewer, reviewerID)
 	}
 
-	p.reviewerIDs = append(p.reviewerIDs, reviewerID)
+	p.reviewers = append(p.reviewers, Reviewer{
+		UserID:     reviewerID,
+		State:      ReviewPending,
+		AssignedAt: time.Now(),
+	})
 	return nil
 }
 
 func (p *PullRequest) UnassignReviewer(reviewerID ID) error {
-	if p.status == PRMerged {
-		return ErrPRAlreadyMerged
+	if err := p.checkOpen(); err != nil {
+		return err
 	}
 
-	idx := slices.Index(p.reviewerIDs, reviewerID)
+	idx := p.reviewerIndex(reviewerID)
 	if idx == -1 {
 		return fmt.Errorf("no user with id=%d inside reviewers list", reviewerID)
 	}
 
-	p.reviewerIDs = slices.Delete(p.reviewerIDs, idx, idx+1)
+	p.reviewers = slices.Delete(p.reviewers, idx, idx+1)
 	return nil
 }
 
-func (p *PullRequest) MarkAsMerged() {
+// SubmitReview() sets state of review of assigned reviewer. Pending state
+// requests review again.
+func (p *PullRequest) SubmitReview(reviewerID ID, state ReviewState) error {
+	if err := p.checkOpen(); err != nil {
+		return err
+	}
+
+	idx := p.reviewerIndex(reviewerID)
+	if idx == -1 {
+		return fmt.Errorf("cannot submit review of user with id=%s: %w", reviewerID, ErrUserNotReviewer)
+	}
+
+	reviewer := &p.reviewers[idx]
+	reviewer.State = state
+	if state == ReviewPending {
+		reviewer.ReviewedAt = nil
+	} else {
+		now := time.Now()
+		reviewer.ReviewedAt = &now
+	}
+	return nil
+}
+
+// checkOpen() returns error if reviewers of pull request cannot be changed
+func (p *PullRequest) checkOpen() error {
+	switch p.status {
+	case PROpen:
+		return nil
+	case PRMerged:
+		return ErrPRAlreadyMerged
+	default:
+		return fmt.Errorf("%w: status is %s", ErrPRNotOpen, p.status)
+	}
+}
+
+func (p *PullRequest) reviewerIndex(reviewerID ID) int {
+	return slices.IndexFunc(p.reviewers, func(r Reviewer) bool {
+		return r.UserID == reviewerID
+	})
+}
+
+// setFallbackTeam() records, that assigned reviewer was drawn from ancestor team
+func (p *PullRequest) setFallbackTeam(reviewerID ID, teamID ID) {
+	if idx := p.reviewerIndex(reviewerID); idx != -1 {
+		p.reviewers[idx].FallbackTeamID = &teamID
+	}
+}
+
+// ReassignReviewer() replaces assigned reviewer with another user and records the reassignment
+func (p *PullRequest) ReassignReviewer(oldReviewerID ID, newReviewerID ID) error {
+	reviewers := slices.Clone(p.reviewers)
+	if err := p.UnassignReviewer(oldReviewerID); err != nil {
+		return err
+	}
+	if err := p.AssignReviewer(newReviewerID); err != nil {
+		p.reviewers = reviewers
+		return err
+	}
+
+	p.reassignments = append(p.reassignments, ReviewerReassignment{
+		OldReviewerID: oldReviewerID,
+		NewReviewerID: newReviewerID,
+		ReassignedAt:  time.Now(),
+	})
+	return nil
+}
+
+// PendingReassignments() returns reassignments made since pull request was loaded
+func (p *PullRequest) PendingReassignments() []ReviewerReassignment {
+	return slices.Clone(p.reassignments)
+}
+
+// MarkAsMerged() merges open pull request. Merging of merged one does nothing.
+func (p *PullRequest) MarkAsMerged() error {
 	if p.status == PRMerged {
-		return
+		return nil
+	}
+	if err := p.transition(PRMerged); err != nil {
+		return err
 	}
 	time := time.Now()
-	p.status = PRMerged
 	p.mergedAt = &time
+	return nil
+}
+
+// MarkAsReady() moves draft to open status, so reviewers can be assigned
+func (p *PullRequest) MarkAsReady() error {
+	if p.status != PRDraft {
+		return fmt.Errorf("%w: %s is not a draft", ErrInvalidStatusTransition, p.status)
+	}
+	return p.transition(PROpen)
+}
+
+// Close() closes pull request without merge. Assigned reviewers are kept.
+func (p *PullRequest) Close() error {
+	return p.transition(PRClosed)
+}
+
+// Reopen() moves closed pull request to open status
+func (p *PullRequest) Reopen() error {
+	if p.status != PRClosed {
+		return fmt.Errorf("%w: %s is not closed", ErrInvalidStatusTransition, p.status)
+	}
+	return p.transition(PROpen)
+}
+
+func (p *PullRequest) transition(next PRStatus) error {
+	if p.status == PRMerged {
+		return ErrPRAlreadyMerged
+	}
+	if !p.status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: from %s to %s", ErrInvalidStatusTransition, p.status, next)
+	}
+
+	p.status = next
+	return nil
 }
 
 func validateIDsUniqueness(ids []ID) error {
@@ -151,11 +381,17 @@ func validateIDsUniqueness(ids []ID) error {
 }
 
 func (p *PullRequest) Validate() error {
-	if len(p.reviewerIDs) > MaxReviewersCount {
-		return ErrMaxReviewersCount
+	if err := p.key.Validate(); err != nil {
+		return err
+	}
+	if p.maxReviewers < 0 {
+		return fmt.Errorf("%w: limit cannot be negative", ErrInvalidReviewersRange)
+	}
+	if len(p.reviewers) > p.maxReviewers {
+		return fmt.Errorf("%w: limit is %d", ErrMaxReviewersCount, p.maxReviewers)
 	}
 
-	err := validateIDsUniqueness(p.reviewerIDs)
+	err := validateIDsUniqueness(p.ReviewerIDs())
 	if err != nil {
 		return fmt.Errorf("pull requests: %w", err)
 	}
@@ -163,8 +399,14 @@ func (p *PullRequest) Validate() error {
 	if p.status == PRMerged && p.mergedAt == nil {
 		return errors.New("PR marked as merged, but time is not specified")
 	}
-	if p.status == PROpen && p.mergedAt != nil {
-		return errors.New("PR marked as opened, but merege time not specified")
+	if p.status != PRMerged && p.mergedAt != nil {
+		return fmt.Errorf("PR marked as %s, but merge time is specified", p.status)
+	}
+	if p.status == PRDraft && len(p.reviewers) > 0 {
+		return errors.New("PR marked as draft, but reviewers are assigned")
+	}
+	if _, ok := prTransitions[p.status]; !ok {
+		return fmt.Errorf("unknown PR status %q", p.status)
 	}
 
 	return nil
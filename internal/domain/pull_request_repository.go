@@ -1,7 +1,23 @@
 package domain
 
+import "context"
 
 type PullRequestRepository interface {
 	Repository[PullRequest, ID]
-	FindPullRequestsByReviewer(userID ID) ([]*PullRequest, error)
+	FindByKey(ctx context.Context, key ExternalKey) (*PullRequest, error)
+	// FindByIDForUpdate() returns pull request and locks it until the end of transaction.
+	// Must be called within Transactor.WithinTransaction(), returns ErrNoTransaction otherwise.
+	FindByIDForUpdate(ctx context.Context, id ID) (*PullRequest, error)
+	FindPullRequestsByReviewer(ctx context.Context, userID ID) ([]*PullRequest, error)
+	// CountOpenReviewsByTeamID() returns number of open pull requests assigned
+	// to every member of the team
+	CountOpenReviewsByTeamID(ctx context.Context, teamID ID) (map[ID]int, error)
+	// CountOpenPullRequestsByTeamID() returns number of open pull requests authored by members of the team
+	CountOpenPullRequestsByTeamID(ctx context.Context, teamID ID) (int, error)
+	// CreateAndAdvanceRotation() creates pull request and moves team rotation cursor
+	// in a single transaction. Returns ErrRotationConflict if cursor was moved concurrently.
+	CreateAndAdvanceRotation(ctx context.Context, pullRequest *PullRequest, advance *RotationAdvance) error
+	// UpdateAndAdvanceRotation() updates pull request and moves team rotation cursor
+	// in a single transaction. Returns ErrRotationConflict if cursor was moved concurrently.
+	UpdateAndAdvanceRotation(ctx context.Context, pullRequest *PullRequest, advance *RotationAdvance) error
 }
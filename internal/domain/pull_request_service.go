@@ -1,29 +1,61 @@
 package domain
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math/rand"
 	"slices"
 )
 
 type PullRequestDomainService interface {
 	// CreateAndAssignReviewers() creates a new pull request and automatically assigns
-	// 2 reviewers by randomly selecting from the author's team.
-	CreateAndAssignReviewers(pullRequest *PullRequest) (*PullRequest, error)
-
-	// ReassignReviewer() unassign user-reviewer with given id and assigns another from his team, excluding
-	// him and pr author. After, method returns id of new user-reviewer and pull request
-	ReassignReviewer(userID ID, pullRequestID ID) (*ReassignReviewerResponse, error)
-
-	// MarkAsMerged() idempotently marks pull request as merged and sets time of marking
-	MarkAsMerged(pullRequestID ID) (*PullRequest, error)
+	// reviewers chosen by the reviewer selector of the team of pull request, which is
+	// primary team of the author if it is not set. Author must be a member of the team.
+	// Number of reviewers is limited by team settings. If the team lacks candidates, the rest of reviewers
+	// is drawn from its ancestors, nearest first. Drafts are created without reviewers.
+	CreateAndAssignReviewers(ctx context.Context, pullRequest *PullRequest) (*PullRequest, error)
+
+	// ReassignReviewer() unassign user-reviewer with given id and assigns another from team of pull request,
+	// excluding him and pr author. If the team has no candidates, new reviewer is drawn from its ancestors.
+	// After, method returns id of new user-reviewer and pull request.
+	// If expectedVersions are set, pull request must have one of them.
+	ReassignReviewer(ctx context.Context, userID ID, pullRequestID ID, expectedVersions []int64) (*ReassignReviewerResponse, error)
+
+	// MarkAsMerged() idempotently marks pull request as merged and sets time of marking.
+	// Fails with ErrNotEnoughApprovals if team of pull request requires more approvals.
+	// If expectedVersions are set, pull request must have one of them.
+	MarkAsMerged(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error)
+
+	// MarkAsMergedUpstream() idempotently marks pull request merged on code hosting. Approvals are not
+	// required, because merge has already happened there; missing ones are recorded in merge event.
+	MarkAsMergedUpstream(ctx context.Context, pullRequestID ID) (*PullRequest, error)
+
+	// SubmitReview() sets state of review of assigned reviewer.
+	// If expectedVersions are set, pull request must have one of them.
+	SubmitReview(ctx context.Context, reviewerID ID, pullRequestID ID, state ReviewState, expectedVersions []int64) (*PullRequest, error)
+
+	// MarkAsReady() opens draft and assigns reviewers to it as on creation.
+	// Marking of open pull request does nothing.
+	// If expectedVersions are set, pull request must have one of them.
+	MarkAsReady(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error)
+
+	// Close() idempotently closes pull request without merge.
+	// If expectedVersions are set, pull request must have one of them.
+	Close(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error)
+
+	// Reopen() opens closed pull request. Reviewers are assigned as on creation
+	// only if it has none, e.g. it was closed as draft. Reopening of open pull request does nothing.
+	// If expectedVersions are set, pull request must have one of them.
+	Reopen(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error)
 }
 
 type DefaultPullRequestDomainService struct {
-	userRepo UserRepository
-	teamRepo TeamRepository
-	prRepo   PullRequestRepository
+	userRepo   UserRepository
+	teamRepo   TeamRepository
+	prRepo     PullRequestRepository
+	selectors  ReviewerSelectorProvider
+	eventRepo  AssignmentEventRepository
+	transactor Transactor
 }
 
 var (
@@ -40,6 +72,9 @@ func NewDefaultPullRequestDomainService(
 	userRepository UserRepository,
 	pullRequestRepository PullRequestRepository,
 	teamRepository TeamRepository,
+	reviewerSelectorProvider ReviewerSelectorProvider,
+	assignmentEventRepository AssignmentEventRepository,
+	transactor Transactor,
 ) (*DefaultPullRequestDomainService, error) {
 	if userRepository == nil {
 		return nil, errors.New("userRepository cannot be nil")
@@ -50,16 +85,28 @@ func NewDefaultPullRequestDomainService(
 	if teamRepository == nil {
 		return nil, errors.New("teamRepository cannot be nil")
 	}
+	if reviewerSelectorProvider == nil {
+		return nil, errors.New("reviewerSelectorProvider cannot be nil")
+	}
+	if assignmentEventRepository == nil {
+		return nil, errors.New("assignmentEventRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
 
 	return &DefaultPullRequestDomainService{
-		userRepo: userRepository,
-		prRepo:   pullRequestRepository,
-		teamRepo: teamRepository,
+		userRepo:   userRepository,
+		prRepo:     pullRequestRepository,
+		teamRepo:   teamRepository,
+		selectors:  reviewerSelectorProvider,
+		eventRepo:  assignmentEventRepository,
+		transactor: transactor,
 	}, nil
 }
 
-func (s *DefaultPullRequestDomainService) CreateAndAssignReviewers(pullRequest *PullRequest) (*PullRequest, error) {
-	prDTO, err := s.prRepo.FindByID(pullRequest.ID())
+func (s *DefaultPullRequestDomainService) CreateAndAssignReviewers(ctx context.Context, pullRequest *PullRequest) (*PullRequest, error) {
+	prDTO, err := s.prRepo.FindByKey(ctx, pullRequest.Key())
 	if err != nil {
 		return nil, err
 	}
@@ -69,7 +116,7 @@ func (s *DefaultPullRequestDomainService) CreateAndAssignReviewers(pullRequest *
 
 	authorID := pullRequest.AuthorID()
 
-	author, err := s.userRepo.FindByID(authorID)
+	author, err := s.userRepo.FindByID(ctx, authorID)
 	if err != nil {
 		return nil, err
 	}
@@ -77,30 +124,203 @@ func (s *DefaultPullRequestDomainService) CreateAndAssignReviewers(pullRequest *
 		return nil, ErrAuthorNotFound
 	}
 
-	team, err := s.teamRepo.FindTeamByTeammateID(authorID)
+	team, err := s.creationTeam(ctx, pullRequest)
+	if err != nil {
+		return nil, err
+	}
+	pullRequest.SetTeamID(team.ID())
+
+	if pullRequest.Status() == PRDraft {
+		if err := pullRequest.SetMaxReviewers(team.Settings().MaxReviewers()); err != nil {
+			return nil, err
+		}
+		err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.prRepo.Create(ctx, pullRequest); err != nil {
+				return err
+			}
+			return s.eventRepo.Append(ctx, PullRequestCreatedEvents(ctx, pullRequest, team.ID())...)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return pullRequest, nil
+	}
+
+	err = withRotationRetry(ctx, func(ctx context.Context) error {
+		selection, err := s.assignReviewers(ctx, pullRequest, team)
+		if err != nil {
+			return err
+		}
+
+		err = s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			if err := s.createPullRequest(ctx, pullRequest, selection); err != nil {
+				return err
+			}
+			return s.eventRepo.Append(ctx, PullRequestCreatedEvents(ctx, pullRequest, team.ID())...)
+		})
+		if errors.Is(err, ErrRotationConflict) {
+			// selection is repeated from scratch on retry
+			for _, u := range selection.Reviewers {
+				if err := pullRequest.UnassignReviewer(u.ID()); err != nil {
+					return err
+				}
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pullRequest, nil
+}
+
+// creationTeam() returns team chosen for new pull request or primary team of the author
+func (s *DefaultPullRequestDomainService) creationTeam(ctx context.Context, pullRequest *PullRequest) (*Team, error) {
+	teamID := pullRequest.TeamID()
+	if teamID == nil {
+		team, err := s.teamRepo.FindTeamByTeammateID(ctx, pullRequest.AuthorID())
+		if err != nil {
+			return nil, err
+		}
+		if team == nil {
+			return nil, ErrTeamNotFound
+		}
+		return team, nil
+	}
+
+	team, err := s.teamRepo.FindByID(ctx, *teamID)
 	if err != nil {
 		return nil, err
 	}
 	if team == nil {
 		return nil, ErrTeamNotFound
 	}
+	if !slices.Contains(team.UserIDs(), pullRequest.AuthorID()) {
+		return nil, fmt.Errorf("%w: author is not in team=%s", ErrNotTeamMember, team.Name())
+	}
+
+	return team, nil
+}
+
+// findPullRequestTeam() returns team of pull request or primary team of the author,
+// if team is not set or was deleted. Returns nil if there is no such team.
+func findPullRequestTeam(ctx context.Context, teamRepo TeamRepository, pullRequest *PullRequest) (*Team, error) {
+	if teamID := pullRequest.TeamID(); teamID != nil {
+		team, err := teamRepo.FindByID(ctx, *teamID)
+		if err != nil || team != nil {
+			return team, err
+		}
+	}
+
+	return teamRepo.FindTeamByTeammateID(ctx, pullRequest.AuthorID())
+}
 
-	availableUsers, err := s.teamRepo.FindActiveUsersByTeamID(team.ID())
+// assignReviewers() assigns reviewers chosen by the reviewer selector of the team
+// to pull request without reviewers, filling missing ones from ancestors of the team.
+// Number of reviewers is limited by team settings.
+func (s *DefaultPullRequestDomainService) assignReviewers(ctx context.Context, pullRequest *PullRequest, team *Team) (*ReviewerSelection, error) {
+	availableUsers, err := s.teamRepo.FindActiveUsersByTeamID(ctx, team.ID())
 	if err != nil {
 		return nil, err
 	}
 
-	reviewers := chooseRandomUsers(availableUsers, MaxReviewersCount, authorID)
-	for _, u := range reviewers {
-		pullRequest.AssignReviewer(u.ID())
+	settings := team.Settings()
+	if err := pullRequest.SetMaxReviewers(settings.MaxReviewers()); err != nil {
+		return nil, err
 	}
 
-	err = s.prRepo.Create(pullRequest)
+	selector := s.selectors.SelectorForTeam(team)
+	selection, err := selector.SelectReviewers(ctx, team, availableUsers, settings.MaxReviewers(), pullRequest.AuthorID())
 	if err != nil {
 		return nil, err
 	}
 
-	return pullRequest, nil
+	except := []ID{pullRequest.AuthorID()}
+	for _, u := range selection.Reviewers {
+		except = append(except, u.ID())
+	}
+	fallback, err := selectFallbackReviewers(ctx, s.teamRepo, team, settings.MaxReviewers()-len(selection.Reviewers), except...)
+	if err != nil {
+		return nil, err
+	}
+
+	if found := len(selection.Reviewers) + len(fallback); found < settings.MinReviewers() {
+		return nil, fmt.Errorf(
+			"%w: team requires at least %d reviewers, found %d",
+			ErrNoReviewCandidates, settings.MinReviewers(), found,
+		)
+	}
+	for _, u := range selection.Reviewers {
+		if err := pullRequest.AssignReviewer(u.ID()); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range fallback {
+		if err := pullRequest.AssignReviewer(r.user.ID()); err != nil {
+			return nil, err
+		}
+		pullRequest.setFallbackTeam(r.user.ID(), r.teamID)
+		selection.Reviewers = append(selection.Reviewers, r.user)
+	}
+
+	return selection, nil
+}
+
+// fallbackReviewer is a reviewer drawn from ancestor of the team of pull request
+type fallbackReviewer struct {
+	user   *User
+	teamID ID
+}
+
+// selectFallbackReviewers() draws at most count reviewers from ancestors of the team, nearest first,
+// excluding users with ids listed in except. Reviewers are chosen at random, so rotation
+// of ancestors is not moved.
+func selectFallbackReviewers(ctx context.Context, teamRepo TeamRepository, team *Team, count int, except ...ID) ([]fallbackReviewer, error) {
+	if count <= 0 || team.ParentID() == nil {
+		return nil, nil
+	}
+
+	ancestors, err := teamRepo.FindAncestors(ctx, team.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	except = slices.Clone(except)
+	reviewers := make([]fallbackReviewer, 0, count)
+	for _, ancestor := range ancestors {
+		if len(reviewers) == count {
+			break
+		}
+
+		candidates, err := teamRepo.FindActiveUsersByTeamID(ctx, ancestor.ID())
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range chooseRandomUsers(excludeUsers(candidates, except...), count-len(reviewers)) {
+			reviewers = append(reviewers, fallbackReviewer{user: u, teamID: ancestor.ID()})
+			except = append(except, u.ID())
+		}
+	}
+
+	return reviewers, nil
+}
+
+func (s *DefaultPullRequestDomainService) createPullRequest(ctx context.Context, pullRequest *PullRequest, selection *ReviewerSelection) error {
+	if selection.Rotation == nil {
+		return s.prRepo.Create(ctx, pullRequest)
+	}
+
+	return s.prRepo.CreateAndAdvanceRotation(ctx, pullRequest, selection.Rotation)
+}
+
+func (s *DefaultPullRequestDomainService) updatePullRequest(ctx context.Context, pullRequest *PullRequest, selection *ReviewerSelection) error {
+	if selection.Rotation == nil {
+		return s.prRepo.Update(ctx, pullRequest)
+	}
+
+	return s.prRepo.UpdateAndAdvanceRotation(ctx, pullRequest, selection.Rotation)
 }
 
 type ReassignReviewerResponse struct {
@@ -108,17 +328,37 @@ type ReassignReviewerResponse struct {
 	PullRequest   PullRequest
 }
 
-func (s *DefaultPullRequestDomainService) ReassignReviewer(userID ID, pullRequestID ID) (*ReassignReviewerResponse, error) {
-	pr, err := s.prRepo.FindByID(pullRequestID)
+func (s *DefaultPullRequestDomainService) ReassignReviewer(ctx context.Context, userID ID, pullRequestID ID, expectedVersions []int64) (*ReassignReviewerResponse, error) {
+	var response *ReassignReviewerResponse
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			response, err = s.reassignReviewer(ctx, userID, pullRequestID, expectedVersions)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// reassignReviewer() must be called within transaction, pull request stays locked until its end
+func (s *DefaultPullRequestDomainService) reassignReviewer(ctx context.Context, userID ID, pullRequestID ID, expectedVersions []int64) (*ReassignReviewerResponse, error) {
+	pr, err := s.prRepo.FindByIDForUpdate(ctx, pullRequestID)
 	if err != nil {
 		return nil, err
 	}
 	if pr == nil {
 		return nil, ErrPRNotFound
 	}
+	if err := CheckVersion(pr.Version(), expectedVersions); err != nil {
+		return nil, err
+	}
 
-	if pr.Status() == PRMerged {
-		return nil, ErrPRAlreadyMerged
+	if err := pr.checkOpen(); err != nil {
+		return nil, err
 	}
 
 	reviewerIDs := pr.ReviewerIDs()
@@ -128,92 +368,255 @@ func (s *DefaultPullRequestDomainService) ReassignReviewer(userID ID, pullReques
 	}
 
 	authorID := pr.AuthorID()
-	team, err := s.teamRepo.FindTeamByTeammateID(authorID)
+	team, err := findPullRequestTeam(ctx, s.teamRepo, pr)
 	if err != nil {
 		return nil, err
 	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
 
-	availableUsers, err := s.teamRepo.FindActiveUsersByTeamID(team.ID())
+	availableUsers, err := s.teamRepo.FindActiveUsersByTeamID(ctx, team.ID())
 	if err != nil {
 		return nil, err
 	}
-	if len(availableUsers) == 0 {
-		return nil, ErrNoReviewCandidates
-	}
 
 	exceptionalReviewerIDs := pr.ReviewerIDs()
 	exceptionalReviewerIDs = append(exceptionalReviewerIDs, authorID)
-	newReviewer := chooseRandomUser(availableUsers, exceptionalReviewerIDs...)
-
-	pr.UnassignReviewer(userID)
-	pr.AssignReviewer(newReviewer.ID())
-	err = s.prRepo.Update(pr)
+	selector := s.selectors.SelectorForTeam(team)
+	selection, err := selector.SelectReviewers(ctx, team, availableUsers, 1, exceptionalReviewerIDs...)
 	if err != nil {
 		return nil, err
 	}
 
+	var newReviewer *User
+	var fallbackTeamID *ID
+	if len(selection.Reviewers) > 0 {
+		newReviewer = selection.Reviewers[0]
+	} else {
+		fallback, err := selectFallbackReviewers(ctx, s.teamRepo, team, 1, exceptionalReviewerIDs...)
+		if err != nil {
+			return nil, err
+		}
+		if len(fallback) == 0 {
+			return nil, ErrNoReviewCandidates
+		}
+		newReviewer = fallback[0].user
+		fallbackTeamID = &fallback[0].teamID
+	}
+
+	if err := pr.ReassignReviewer(userID, newReviewer.ID()); err != nil {
+		return nil, err
+	}
+	if fallbackTeamID != nil {
+		pr.setFallbackTeam(newReviewer.ID(), *fallbackTeamID)
+	}
+
+	if err := s.updatePullRequest(ctx, pr, selection); err != nil {
+		return nil, err
+	}
+	if err := s.eventRepo.Append(ctx, ReviewerReassignedEvent(ctx, pr.ID(), userID, newReviewer.ID())); err != nil {
+		return nil, err
+	}
+
 	return &ReassignReviewerResponse{
 		NewReviewerID: newReviewer.ID(),
 		PullRequest:   *pr,
 	}, nil
 }
 
-func chooseRandomUser(availableUsers []*User, except ...ID) *User {
-	for i, u := range availableUsers {
-		for _, exceptional := range except {
-			if u.ID() == exceptional {
-				availableUsers = slices.Delete(availableUsers, i, i+1)
-				continue
+func (s *DefaultPullRequestDomainService) MarkAsMerged(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error) {
+	return s.merge(ctx, pullRequestID, expectedVersions, false)
+}
+
+func (s *DefaultPullRequestDomainService) MarkAsMergedUpstream(ctx context.Context, pullRequestID ID) (*PullRequest, error) {
+	return s.merge(ctx, pullRequestID, nil, true)
+}
+
+// merge() marks pull request as merged. Upstream merge skips approvals check
+// and records number of missing approvals in merge event.
+func (s *DefaultPullRequestDomainService) merge(ctx context.Context, pullRequestID ID, expectedVersions []int64, upstream bool) (*PullRequest, error) {
+	var pr *PullRequest
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.FindByIDForUpdate(ctx, pullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			return ErrPRNotFound
+		}
+		if err := CheckVersion(pr.Version(), expectedVersions); err != nil {
+			return err
+		}
+
+		if pr.Status() == PRMerged {
+			return nil
+		}
+
+		team, err := findPullRequestTeam(ctx, s.teamRepo, pr)
+		if err != nil {
+			return err
+		}
+		missingApprovals := 0
+		if team != nil {
+			missingApprovals = team.Settings().MissingApprovals(pr)
+			if !upstream {
+				if err := team.Settings().CheckApprovals(pr); err != nil {
+					return err
+				}
 			}
 		}
+
+		if err := pr.MarkAsMerged(); err != nil {
+			return err
+		}
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+
+		event := PullRequestMergedEvent(ctx, pr)
+		if missingApprovals > 0 {
+			event.MissingApprovals = &missingApprovals
+		}
+		return s.eventRepo.Append(ctx, event)
+	})
+	if err != nil {
+		return nil, err
 	}
-	idx := rand.Intn(len(availableUsers))
 
-	return availableUsers[idx]
+	return pr, nil
 }
 
-func chooseRandomUsers(availableUsers []*User, maxCount int, except ...ID) []*User {
-	for i, u := range availableUsers {
-		for _, exceptional := range except {
-			if u.ID() == exceptional {
-				availableUsers = slices.Delete(availableUsers, i, i+1)
-				continue
-			}
+func (s *DefaultPullRequestDomainService) SubmitReview(ctx context.Context, reviewerID ID, pullRequestID ID, state ReviewState, expectedVersions []int64) (*PullRequest, error) {
+	var pr *PullRequest
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.FindByIDForUpdate(ctx, pullRequestID)
+		if err != nil {
+			return err
+		}
+		if pr == nil {
+			return ErrPRNotFound
+		}
+		if err := CheckVersion(pr.Version(), expectedVersions); err != nil {
+			return err
+		}
+
+		if err := pr.SubmitReview(reviewerID, state); err != nil {
+			return err
 		}
+		return s.prRepo.Update(ctx, pr)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *DefaultPullRequestDomainService) MarkAsReady(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error) {
+	return s.open(ctx, pullRequestID, expectedVersions, (*PullRequest).MarkAsReady, EventPRReady)
+}
+
+func (s *DefaultPullRequestDomainService) Reopen(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error) {
+	return s.open(ctx, pullRequestID, expectedVersions, (*PullRequest).Reopen, EventPRReopened)
+}
+
+// open() moves pull request to open status with given transition and assigns
+// reviewers, if it has none
+func (s *DefaultPullRequestDomainService) open(
+	ctx context.Context,
+	pullRequestID ID,
+	expectedVersions []int64,
+	transition func(*PullRequest) error,
+	eventType AssignmentEventType,
+) (*PullRequest, error) {
+	var pr *PullRequest
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var err error
+			pr, err = s.lockPullRequest(ctx, pullRequestID, expectedVersions)
+			if err != nil {
+				return err
+			}
+			if pr.Status() == PROpen {
+				return nil
+			}
+			if err := transition(pr); err != nil {
+				return err
+			}
+
+			events := []*AssignmentEvent{PullRequestStatusChangedEvent(ctx, pr, eventType)}
+			selection := &ReviewerSelection{}
+			if len(pr.ReviewerIDs()) == 0 {
+				team, err := findPullRequestTeam(ctx, s.teamRepo, pr)
+				if err != nil {
+					return err
+				}
+				if team == nil {
+					return ErrTeamNotFound
+				}
+				pr.SetTeamID(team.ID())
+				selection, err = s.assignReviewers(ctx, pr, team)
+				if err != nil {
+					return err
+				}
+				for _, reviewerID := range pr.ReviewerIDs() {
+					events = append(events, ReviewerAssignedEvent(ctx, pr.ID(), reviewerID))
+				}
+			}
+
+			if err := s.updatePullRequest(ctx, pr, selection); err != nil {
+				return err
+			}
+			return s.eventRepo.Append(ctx, events...)
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := make([]*User, 0, maxCount)
-	if len(availableUsers) <= maxCount {
-		for i := range min(maxCount, len(availableUsers)) {
-			reviewers = append(reviewers, availableUsers[i])
+	return pr, nil
+}
+
+func (s *DefaultPullRequestDomainService) Close(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error) {
+	var pr *PullRequest
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.lockPullRequest(ctx, pullRequestID, expectedVersions)
+		if err != nil {
+			return err
 		}
-	} else {
-		for range maxCount {
-			idx := rand.Intn(len(availableUsers))
-			reviewers = append(reviewers, availableUsers[idx])
-			availableUsers = slices.Delete(availableUsers, idx, idx+1)
+		if pr.Status() == PRClosed {
+			return nil
 		}
+
+		if err := pr.Close(); err != nil {
+			return err
+		}
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return s.eventRepo.Append(ctx, PullRequestStatusChangedEvent(ctx, pr, EventPRClosed))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return reviewers
+	return pr, nil
 }
 
-func (s *DefaultPullRequestDomainService) MarkAsMerged(pullRequestID ID) (*PullRequest, error) {
-	pr, err := s.prRepo.FindByID(pullRequestID)
+// lockPullRequest() must be called within transaction, pull request stays locked until its end
+func (s *DefaultPullRequestDomainService) lockPullRequest(ctx context.Context, pullRequestID ID, expectedVersions []int64) (*PullRequest, error) {
+	pr, err := s.prRepo.FindByIDForUpdate(ctx, pullRequestID)
 	if err != nil {
 		return nil, err
 	}
 	if pr == nil {
 		return nil, ErrPRNotFound
 	}
-
-	if pr.Status() == PRMerged {
-		return pr, nil
-	}
-
-	pr.MarkAsMerged()
-	err = s.prRepo.Update(pr)
-	if err != nil {
+	if err := CheckVersion(pr.Version(), expectedVersions); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,454 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/alphameo/pr-reviewnager/internal/infra/db/memory"
+	"github.com/google/uuid"
+)
+
+// fixture is a pair of domain services working with the same memory store
+type fixture struct {
+	users       *memory.UserRepository
+	teams       *memory.TeamRepository
+	prs         *memory.PullRequestRepository
+	events      *memory.AssignmentEventRepository
+	prService   *domain.DefaultPullRequestDomainService
+	teamService *domain.DefaultTeamDomainService
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+
+	store := memory.NewStore()
+	f := &fixture{}
+	var err error
+	if f.users, err = memory.NewUserRepository(store); err != nil {
+		t.Fatal(err)
+	}
+	if f.teams, err = memory.NewTeamRepository(store); err != nil {
+		t.Fatal(err)
+	}
+	if f.prs, err = memory.NewPullRequestRepository(store); err != nil {
+		t.Fatal(err)
+	}
+	if f.events, err = memory.NewAssignmentEventRepository(store); err != nil {
+		t.Fatal(err)
+	}
+	rotationRepo, err := memory.NewReviewerRotationRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	transactor, err := memory.NewTransactor(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, err := domain.NewRoundRobinReviewerSelector(rotationRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selectors, err := domain.NewTeamReviewerSelectorProvider(selector, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.prService, err = domain.NewDefaultPullRequestDomainService(f.users, f.prs, f.teams, selectors, f.events, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.teamService, err = domain.NewDefaultTeamDomainService(f.users, f.prs, f.teams, selectors, f.events, transactor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func testID(n int) domain.ID {
+	return domain.ExistingID(uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-%012d", n)))
+}
+
+func testUser(n int, active bool) *domain.User {
+	return domain.ExistingUser(
+		testID(n),
+		domain.ExistingExternalKey(fmt.Sprintf("u%d", n)),
+		domain.ExistingUserName(fmt.Sprintf("user %d", n)),
+		active,
+	)
+}
+
+// team() stores team with given settings and users, created users are saved too
+func (f *fixture) team(t *testing.T, settings domain.TeamSettings, users ...*domain.User) *domain.Team {
+	t.Helper()
+
+	id := domain.NewID()
+	userIDs := make([]domain.ID, len(users))
+	for i, u := range users {
+		userIDs[i] = u.ID()
+	}
+	team := domain.ExistingTeam(
+		id,
+		domain.ExistingExternalKey(id.String()),
+		domain.ExistingTeamName(id.String()),
+		nil,
+		userIDs,
+		settings,
+		domain.InitialVersion,
+	)
+	if err := f.teams.CreateTeamAndModifyUsers(context.Background(), team, users); err != nil {
+		t.Fatalf("CreateTeamAndModifyUsers() error = %v", err)
+	}
+	return team
+}
+
+// pullRequest() creates pull request of the author with reviewers assigned by the service
+func (f *fixture) pullRequest(t *testing.T, n int, authorID domain.ID, draft bool) *domain.PullRequest {
+	t.Helper()
+
+	newPullRequest := domain.NewPullRequest
+	if draft {
+		newPullRequest = domain.NewDraftPullRequest
+	}
+	pr, err := newPullRequest(
+		domain.ExistingExternalKey(fmt.Sprintf("pr-%d", n)),
+		domain.ExistingPRTitle("title"),
+		authorID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err = f.prService.CreateAndAssignReviewers(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("CreateAndAssignReviewers() error = %v", err)
+	}
+	return pr
+}
+
+// storedPullRequest() stores pull request of the team with given reviewers bypassing the selection
+func (f *fixture) storedPullRequest(t *testing.T, n int, teamID domain.ID, authorID domain.ID, reviewerIDs ...domain.ID) *domain.PullRequest {
+	t.Helper()
+
+	pr, err := domain.NewPullRequest(
+		domain.ExistingExternalKey(fmt.Sprintf("pr-%d", n)),
+		domain.ExistingPRTitle("title"),
+		authorID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr.SetTeamID(teamID)
+	for _, id := range reviewerIDs {
+		if err := pr.AssignReviewer(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := f.prs.Create(context.Background(), pr); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return pr
+}
+
+func (f *fixture) findPullRequest(t *testing.T, id domain.ID) *domain.PullRequest {
+	t.Helper()
+
+	pr, err := f.prs.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if pr == nil {
+		t.Fatalf("FindByID() = nil, want pull request with id=%s", id)
+	}
+	return pr
+}
+
+func TestReassignReviewer(t *testing.T) {
+	tests := []struct {
+		name string
+		// members of the team besides active author with id 1
+		members []*domain.User
+		// prepare() returns reviewer to reassign
+		prepare          func(t *testing.T, f *fixture, pr *domain.PullRequest) domain.ID
+		expectedVersions func(pr *domain.PullRequest) []int64
+		wantErr          error
+	}{
+		{
+			name:    "free teammate replaces reviewer",
+			members: []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+		},
+		{
+			name:             "expected version matches",
+			members:          []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+			expectedVersions: func(pr *domain.PullRequest) []int64 { return []int64{pr.Version()} },
+		},
+		{
+			name:             "stale version",
+			members:          []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+			expectedVersions: func(pr *domain.PullRequest) []int64 { return []int64{pr.Version() + 1} },
+			wantErr:          domain.ErrVersionConflict,
+		},
+		{
+			name:    "inactive teammate is not a candidate",
+			members: []*domain.User{testUser(2, true), testUser(3, true), testUser(4, false)},
+			wantErr: domain.ErrNoReviewCandidates,
+		},
+		{
+			name:    "user is not a reviewer",
+			members: []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+			prepare: func(t *testing.T, f *fixture, pr *domain.PullRequest) domain.ID {
+				return testID(1)
+			},
+			wantErr: domain.ErrUserNotReviewer,
+		},
+		{
+			name:    "merged pull request",
+			members: []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+			prepare: func(t *testing.T, f *fixture, pr *domain.PullRequest) domain.ID {
+				if _, err := f.prService.MarkAsMerged(context.Background(), pr.ID(), nil); err != nil {
+					t.Fatal(err)
+				}
+				return pr.ReviewerIDs()[0]
+			},
+			wantErr: domain.ErrPRAlreadyMerged,
+		},
+		{
+			name:    "closed pull request",
+			members: []*domain.User{testUser(2, true), testUser(3, true), testUser(4, true)},
+			prepare: func(t *testing.T, f *fixture, pr *domain.PullRequest) domain.ID {
+				if _, err := f.prService.Close(context.Background(), pr.ID(), nil); err != nil {
+					t.Fatal(err)
+				}
+				return pr.ReviewerIDs()[0]
+			},
+			wantErr: domain.ErrPRNotOpen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			f.team(t, domain.DefaultTeamSettings(), append([]*domain.User{testUser(1, true)}, tt.members...)...)
+			pr := f.pullRequest(t, 1, testID(1), false)
+			oldReviewerIDs := pr.ReviewerIDs()
+			if len(oldReviewerIDs) != domain.DefaultMaxReviewersCount {
+				t.Fatalf("ReviewerIDs() = %v, want %d reviewers", oldReviewerIDs, domain.DefaultMaxReviewersCount)
+			}
+
+			reviewerID := oldReviewerIDs[0]
+			if tt.prepare != nil {
+				reviewerID = tt.prepare(t, f, pr)
+			}
+			var expectedVersions []int64
+			if tt.expectedVersions != nil {
+				expectedVersions = tt.expectedVersions(f.findPullRequest(t, pr.ID()))
+			}
+
+			got, err := f.prService.ReassignReviewer(ctx, reviewerID, pr.ID(), expectedVersions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ReassignReviewer() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if stored := f.findPullRequest(t, pr.ID()).ReviewerIDs(); !slices.Equal(stored, oldReviewerIDs) {
+					t.Errorf("stored reviewers = %v, want unchanged %v", stored, oldReviewerIDs)
+				}
+				return
+			}
+
+			// the only member, who is neither author nor reviewer
+			want := testID(4)
+			for _, u := range tt.members {
+				if !slices.Contains(oldReviewerIDs, u.ID()) {
+					want = u.ID()
+				}
+			}
+			if got.NewReviewerID != want {
+				t.Errorf("ReassignReviewer() new reviewer = %s, want %s", got.NewReviewerID, want)
+			}
+			stored := f.findPullRequest(t, pr.ID()).ReviewerIDs()
+			if slices.Contains(stored, reviewerID) || !slices.Contains(stored, want) {
+				t.Errorf("stored reviewers = %v, want %s replaced by %s", stored, reviewerID, want)
+			}
+		})
+	}
+}
+
+func TestMergeChecksRequiredApprovals(t *testing.T) {
+	tests := []struct {
+		name        string
+		approvals   int
+		upstream    bool
+		wantErr     error
+		wantMissing *int
+		wantStatus  domain.PRStatus
+	}{
+		{name: "not enough approvals", approvals: 1, wantErr: domain.ErrNotEnoughApprovals, wantStatus: domain.PROpen},
+		{name: "required approvals", approvals: 2, wantStatus: domain.PRMerged},
+		{name: "upstream merge without approvals", upstream: true, wantMissing: ptr(2), wantStatus: domain.PRMerged},
+		{name: "upstream merge with approvals", approvals: 2, upstream: true, wantStatus: domain.PRMerged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			settings, err := domain.NewTeamSettings(2, 2, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			f.team(t, settings, testUser(1, true), testUser(2, true), testUser(3, true))
+			pr := f.pullRequest(t, 1, testID(1), false)
+			for _, reviewerID := range pr.ReviewerIDs()[:tt.approvals] {
+				if _, err := f.prService.SubmitReview(ctx, reviewerID, pr.ID(), domain.ReviewApproved, nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.upstream {
+				_, err = f.prService.MarkAsMergedUpstream(ctx, pr.ID())
+			} else {
+				_, err = f.prService.MarkAsMerged(ctx, pr.ID(), nil)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("merge error = %v, want %v", err, tt.wantErr)
+			}
+			if got := f.findPullRequest(t, pr.ID()).Status(); got != tt.wantStatus {
+				t.Errorf("stored status = %s, want %s", got, tt.wantStatus)
+			}
+
+			events, err := f.events.FindByPullRequestID(ctx, pr.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			merges := slices.DeleteFunc(events, func(e *domain.AssignmentEvent) bool {
+				return e.Type != domain.EventPRMerged
+			})
+			if tt.wantErr != nil {
+				if len(merges) != 0 {
+					t.Errorf("merge events = %d, want none", len(merges))
+				}
+				return
+			}
+			if len(merges) != 1 {
+				t.Fatalf("merge events = %d, want 1", len(merges))
+			}
+			if got := merges[0].MissingApprovals; !equalPtr(got, tt.wantMissing) {
+				t.Errorf("MissingApprovals = %v, want %v", deref(got), deref(tt.wantMissing))
+			}
+		})
+	}
+}
+
+func TestPullRequestStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name  string
+		draft bool
+		// actions are applied in order, all but the last one must succeed
+		actions       []string
+		wantErr       error
+		wantStatus    domain.PRStatus
+		wantReviewers int
+	}{
+		{name: "draft has no reviewers", draft: true, wantStatus: domain.PRDraft},
+		{name: "ready draft gets reviewers", draft: true, actions: []string{"ready"}, wantStatus: domain.PROpen, wantReviewers: 2},
+		{name: "ready open does nothing", actions: []string{"ready"}, wantStatus: domain.PROpen, wantReviewers: 2},
+		{name: "closed keeps reviewers", actions: []string{"close"}, wantStatus: domain.PRClosed, wantReviewers: 2},
+		{name: "reopen keeps reviewers", actions: []string{"close", "reopen"}, wantStatus: domain.PROpen, wantReviewers: 2},
+		{
+			name:          "reopen of draft closed gets reviewers",
+			draft:         true,
+			actions:       []string{"close", "reopen"},
+			wantStatus:    domain.PROpen,
+			wantReviewers: 2,
+		},
+		{name: "reopen open does nothing", actions: []string{"reopen"}, wantStatus: domain.PROpen, wantReviewers: 2},
+		{
+			name:          "closed cannot become ready",
+			actions:       []string{"close", "ready"},
+			wantErr:       domain.ErrInvalidStatusTransition,
+			wantStatus:    domain.PRClosed,
+			wantReviewers: 2,
+		},
+		{
+			name:       "draft cannot be merged",
+			draft:      true,
+			actions:    []string{"merge"},
+			wantErr:    domain.ErrInvalidStatusTransition,
+			wantStatus: domain.PRDraft,
+		},
+		{
+			name:          "merged cannot be reopened",
+			actions:       []string{"merge", "reopen"},
+			wantErr:       domain.ErrInvalidStatusTransition,
+			wantStatus:    domain.PRMerged,
+			wantReviewers: 2,
+		},
+		{
+			name:          "merged cannot be closed",
+			actions:       []string{"merge", "close"},
+			wantErr:       domain.ErrPRAlreadyMerged,
+			wantStatus:    domain.PRMerged,
+			wantReviewers: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			f.team(t, domain.DefaultTeamSettings(), testUser(1, true), testUser(2, true), testUser(3, true))
+			pr := f.pullRequest(t, 1, testID(1), tt.draft)
+
+			var err error
+			for i, action := range tt.actions {
+				switch action {
+				case "ready":
+					_, err = f.prService.MarkAsReady(ctx, pr.ID(), nil)
+				case "close":
+					_, err = f.prService.Close(ctx, pr.ID(), nil)
+				case "reopen":
+					_, err = f.prService.Reopen(ctx, pr.ID(), nil)
+				case "merge":
+					_, err = f.prService.MarkAsMerged(ctx, pr.ID(), nil)
+				default:
+					t.Fatalf("unknown action %q", action)
+				}
+				if err != nil && i < len(tt.actions)-1 {
+					t.Fatalf("%s error = %v", action, err)
+				}
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored := f.findPullRequest(t, pr.ID())
+			if stored.Status() != tt.wantStatus {
+				t.Errorf("stored status = %s, want %s", stored.Status(), tt.wantStatus)
+			}
+			if got := len(stored.ReviewerIDs()); got != tt.wantReviewers {
+				t.Errorf("stored reviewers = %d, want %d", got, tt.wantReviewers)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func deref[T any](v *T) any {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"strings"
 )
 
@@ -32,7 +31,7 @@ func (n PRTitle) String() string {
 
 func (n PRTitle) Validate() error {
 	if len(n.String()) == 0 {
-		return errors.New("pull request title cannot be empty")
+		return NewValidationError("title", "cannot be empty")
 	}
 
 	return nil
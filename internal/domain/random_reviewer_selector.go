@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+)
+
+// RandomReviewerSelector chooses reviewers uniformly at random
+type RandomReviewerSelector struct{}
+
+func NewRandomReviewerSelector() *RandomReviewerSelector {
+	return &RandomReviewerSelector{}
+}
+
+func (s *RandomReviewerSelector) SelectReviewers(ctx context.Context, _ *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error) {
+	return &ReviewerSelection{
+		Reviewers: chooseRandomUsers(excludeUsers(candidates, except...), count),
+	}, nil
+}
+
+func chooseRandomUsers(availableUsers []*User, maxCount int) []*User {
+	if maxCount <= 0 {
+		return []*User{}
+	}
+	if len(availableUsers) <= maxCount {
+		return slices.Clone(availableUsers)
+	}
+
+	availableUsers = slices.Clone(availableUsers)
+	reviewers := make([]*User, 0, maxCount)
+	for range maxCount {
+		idx := rand.Intn(len(availableUsers))
+		reviewers = append(reviewers, availableUsers[idx])
+		availableUsers = slices.Delete(availableUsers, idx, idx+1)
+	}
+
+	return reviewers
+}
@@ -1,9 +1,32 @@
 package domain
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// InitialVersion is a version of entity, which has not been modified since creation
+const InitialVersion int64 = 1
+
+// ErrVersionConflict is returned when entity was modified after it had been read
+var ErrVersionConflict = errors.New("entity version conflict")
+
+// CheckVersion() returns ErrVersionConflict if expected versions are set and actual one is none of them.
+// Nil expected does not restrict version.
+func CheckVersion(actual int64, expected []int64) error {
+	if expected != nil && !slices.Contains(expected, actual) {
+		return fmt.Errorf("%w: expected versions %v, actual %d", ErrVersionConflict, expected, actual)
+	}
+
+	return nil
+}
+
 type Repository[T any, ID any] interface {
-	Create(entity *T) error
-	FindByID(id ID) (*T, error)
-	FindAll() ([]*T, error)
-	Update(entity *T) error
-	DeleteByID(id ID) error
+	Create(ctx context.Context, entity *T) error
+	FindByID(ctx context.Context, id ID) (*T, error)
+	FindAll(ctx context.Context) ([]*T, error)
+	Update(ctx context.Context, entity *T) error
+	DeleteByID(ctx context.Context, id ID) error
 }
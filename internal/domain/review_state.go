@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ReviewState is result of review of assigned reviewer. It is informational
+// and does not block anything except merging with required approvals.
+type ReviewState string
+
+const (
+	ReviewPending          ReviewState = "pending"
+	ReviewApproved         ReviewState = "approved"
+	ReviewChangesRequested ReviewState = "changes_requested"
+	ReviewCommented        ReviewState = "commented"
+)
+
+func NewReviewState(value string) (ReviewState, error) {
+	processed := strings.ToLower(strings.TrimSpace(value))
+	switch processed {
+	case "pending":
+		return ReviewPending, nil
+	case "approved":
+		return ReviewApproved, nil
+	case "changes_requested":
+		return ReviewChangesRequested, nil
+	case "commented":
+		return ReviewCommented, nil
+	default:
+		return ReviewState(""), NewValidationError("state", fmt.Sprintf("unknown value %q", processed))
+	}
+}
+
+func (s ReviewState) String() string {
+	return string(s)
+}
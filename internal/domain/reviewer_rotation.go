@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrRotationConflict = errors.New("reviewer rotation was advanced concurrently")
+
+// RotationAdvance describes a move of team's round-robin cursor
+type RotationAdvance struct {
+	TeamID ID
+	// last reviewer before the move, nil if team rotation has not started yet
+	From *ID
+	To   ID
+}
+
+type ReviewerRotationRepository interface {
+	// FindRotationCursor() returns id of the last reviewer chosen in team rotation
+	// or nil if rotation has not started yet
+	FindRotationCursor(ctx context.Context, teamID ID) (*ID, error)
+}
+
+// maxRotationAttempts limits reselection of reviewers when team rotation
+// is concurrently advanced by another request
+const maxRotationAttempts = 3
+
+// withRotationRetry() runs fn again while it fails with ErrRotationConflict, at most maxRotationAttempts
+// times in total. Rotation was moved by concurrent request, so fn must select reviewers from scratch.
+func withRotationRetry(ctx context.Context, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if errors.Is(err, ErrRotationConflict) && attempt < maxRotationAttempts && ctx.Err() == nil {
+			continue
+		}
+		return err
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithRotationRetry(t *testing.T) {
+	errOther := errors.New("other")
+
+	tests := []struct {
+		name         string
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		{name: "success", errs: []error{nil}, wantAttempts: 1},
+		{name: "success after conflict", errs: []error{ErrRotationConflict, nil}, wantAttempts: 2},
+		{
+			name:         "wrapped conflict is retried",
+			errs:         []error{fmt.Errorf("save: %w", ErrRotationConflict), nil},
+			wantAttempts: 2,
+		},
+		{
+			name:         "conflicts exhaust attempts",
+			errs:         []error{ErrRotationConflict, ErrRotationConflict, ErrRotationConflict, nil},
+			wantErr:      ErrRotationConflict,
+			wantAttempts: maxRotationAttempts,
+		},
+		{name: "other error is not retried", errs: []error{errOther, nil}, wantErr: errOther, wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := withRotationRetry(context.Background(), func(ctx context.Context) error {
+				err := tt.errs[attempts]
+				attempts++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("withRotationRetry() error = %v, want %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("withRotationRetry() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWithRotationRetryStopsOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		attempts++
+		cancel()
+		return ErrRotationConflict
+	})
+	if !errors.Is(err, ErrRotationConflict) || attempts != 1 {
+		t.Errorf("withRotationRetry() = %v after %d attempts, want %v after 1", err, attempts, ErrRotationConflict)
+	}
+}
@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// ReviewerSelector encapsulates strategy of choosing reviewers among team members
+type ReviewerSelector interface {
+	// SelectReviewers() returns at most count users from candidates of the given team,
+	// excluding users with ids listed in except
+	SelectReviewers(ctx context.Context, team *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error)
+}
+
+// RotatingReviewerSelector is implemented by strategies, which depend on persisted rotation cursor.
+// It allows to chain several selections before rotation is saved.
+type RotatingReviewerSelector interface {
+	ReviewerSelector
+	// SelectReviewersFrom() works like SelectReviewers(), but starts rotation from given cursor
+	// instead of the persisted one
+	SelectReviewersFrom(ctx context.Context, cursor *ID, team *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error)
+}
+
+type ReviewerSelection struct {
+	Reviewers []*User
+	// Rotation is set by strategies with persisted state and must be saved
+	// together with the pull request reviewers were chosen for
+	Rotation *RotationAdvance
+}
+
+// ReviewerSelectorProvider resolves reviewer selection strategy used by a team
+type ReviewerSelectorProvider interface {
+	SelectorForTeam(team *Team) ReviewerSelector
+}
+
+// TeamReviewerSelectorProvider resolves strategies by external keys of teams
+type TeamReviewerSelectorProvider struct {
+	defaultSelector ReviewerSelector
+	teamSelectors   map[ExternalKey]ReviewerSelector
+}
+
+func NewTeamReviewerSelectorProvider(
+	defaultSelector ReviewerSelector,
+	teamSelectors map[ExternalKey]ReviewerSelector,
+) (*TeamReviewerSelectorProvider, error) {
+	if defaultSelector == nil {
+		return nil, errors.New("defaultSelector cannot be nil")
+	}
+
+	selectors := make(map[ExternalKey]ReviewerSelector, len(teamSelectors))
+	for key, selector := range teamSelectors {
+		if selector == nil {
+			return nil, errors.New("team selector cannot be nil")
+		}
+		selectors[key] = selector
+	}
+
+	return &TeamReviewerSelectorProvider{
+		defaultSelector: defaultSelector,
+		teamSelectors:   selectors,
+	}, nil
+}
+
+func (p *TeamReviewerSelectorProvider) SelectorForTeam(team *Team) ReviewerSelector {
+	if team == nil {
+		return p.defaultSelector
+	}
+	if selector, ok := p.teamSelectors[team.Key()]; ok {
+		return selector
+	}
+
+	return p.defaultSelector
+}
+
+func excludeUsers(users []*User, except ...ID) []*User {
+	filtered := make([]*User, 0, len(users))
+	for _, u := range users {
+		if !slices.Contains(except, u.ID()) {
+			filtered = append(filtered, u)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// RoundRobinReviewerSelector rotates reviewers in a fixed order of team members.
+// Position in rotation is persisted per team, so returned selection always contains
+// rotation advance.
+type RoundRobinReviewerSelector struct {
+	rotationRepo ReviewerRotationRepository
+}
+
+func NewRoundRobinReviewerSelector(rotationRepository ReviewerRotationRepository) (*RoundRobinReviewerSelector, error) {
+	if rotationRepository == nil {
+		return nil, errors.New("rotationRepository cannot be nil")
+	}
+
+	return &RoundRobinReviewerSelector{rotationRepo: rotationRepository}, nil
+}
+
+func (s *RoundRobinReviewerSelector) SelectReviewers(ctx context.Context, team *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error) {
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	cursor, err := s.rotationRepo.FindRotationCursor(ctx, team.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SelectReviewersFrom(ctx, cursor, team, candidates, count, except...)
+}
+
+func (s *RoundRobinReviewerSelector) SelectReviewersFrom(ctx context.Context, cursor *ID, team *Team, candidates []*User, count int, except ...ID) (*ReviewerSelection, error) {
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+
+	available := make(map[ID]*User, len(candidates))
+	for _, u := range excludeUsers(candidates, except...) {
+		available[u.ID()] = u
+	}
+	if count <= 0 || len(available) == 0 {
+		return &ReviewerSelection{Reviewers: []*User{}}, nil
+	}
+
+	order := team.UserIDs()
+	start := 0
+	if cursor != nil {
+		// if last reviewer has left the team, rotation starts over
+		start = slices.Index(order, *cursor) + 1
+	}
+
+	reviewers := make([]*User, 0, min(count, len(available)))
+	for i := range order {
+		if len(reviewers) == count {
+			break
+		}
+		if u, ok := available[order[(start+i)%len(order)]]; ok {
+			reviewers = append(reviewers, u)
+		}
+	}
+	if len(reviewers) == 0 {
+		return &ReviewerSelection{Reviewers: reviewers}, nil
+	}
+
+	return &ReviewerSelection{
+		Reviewers: reviewers,
+		Rotation: &RotationAdvance{
+			TeamID: team.ID(),
+			From:   cursor,
+			To:     reviewers[len(reviewers)-1].ID(),
+		},
+	}, nil
+}
@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// UserReviewStats aggregates review assignments of a single user
+type UserReviewStats struct {
+	UserID   ID
+	UserKey  ExternalKey
+	UserName UserName
+	// all assignments including ones reassigned away later
+	TotalAssignments int
+	OpenAssignments  int
+	MergedReviews    int
+	ReassignedAway   int
+}
+
+// PullRequestReviewStats aggregates review assignments of a single pull request
+type PullRequestReviewStats struct {
+	PullRequestID     ID
+	PullRequestKey    ExternalKey
+	Title             PRTitle
+	Status            PRStatus
+	CreatedAt         time.Time
+	MergedAt          *time.Time
+	ReviewersAssigned int
+	Reassignments     int
+}
+
+// TimeToMerge() returns time passed from creation to merge or nil if pull request is not merged
+func (s *PullRequestReviewStats) TimeToMerge() *time.Duration {
+	if s.MergedAt == nil {
+		return nil
+	}
+
+	d := s.MergedAt.Sub(s.CreatedAt)
+	return &d
+}
+
+type StatsRepository interface {
+	// FindUserReviewStats() returns stats of members of team with given id,
+	// or of all users if teamID is nil
+	FindUserReviewStats(ctx context.Context, teamID *ID) ([]*UserReviewStats, error)
+	// FindPullRequestReviewStats() returns stats of pull requests authored by members of team
+	// with given id, or of all pull requests if teamID is nil
+	FindPullRequestReviewStats(ctx context.Context, teamID *ID) ([]*PullRequestReviewStats, error)
+}
@@ -8,35 +8,55 @@ import (
 
 const avgUserCountInTeam = 10
 
-var ErrAlreadyTeamMember = errors.New("user is already a team member")
+var (
+	ErrAlreadyTeamMember  = errors.New("user is already a team member")
+	ErrTeamHierarchyCycle = errors.New("team cannot be its own ancestor")
+)
 
 type Team struct {
 	id   ID
+	key  ExternalKey
 	name TeamName
+	// team, which reviewers are drawn from when the team lacks candidates
+	parentID *ID
 	// slice (not a map) becuse member count cannot be very large
-	userIDs []ID
+	userIDs  []ID
+	settings TeamSettings
+	// incremented on every save, used for optimistic concurrency control
+	version int64
 }
 
-func NewTeam(name TeamName) (*Team, error) {
+func NewTeam(key ExternalKey, name TeamName) (*Team, error) {
 	return &Team{
-		id:      NewID(),
-		name:    name,
-		userIDs: make([]ID, 0, avgUserCountInTeam),
+		id:       NewID(),
+		key:      key,
+		name:     name,
+		userIDs:  make([]ID, 0, avgUserCountInTeam),
+		settings: DefaultTeamSettings(),
+		version:  InitialVersion,
 	}, nil
 }
 
 func ExistingTeam(
 	id ID,
+	key ExternalKey,
 	name TeamName,
+	parentID *ID,
 	userIDs []ID,
+	settings TeamSettings,
+	version int64,
 ) *Team {
 	uIDs := make([]ID, 0, max(len(userIDs), avgUserCountInTeam))
 	uIDs = append(uIDs, userIDs...)
 
 	return &Team{
-		id:      id,
-		name:    name,
-		userIDs: uIDs,
+		id:       id,
+		key:      key,
+		name:     name,
+		parentID: parentID,
+		userIDs:  uIDs,
+		settings: settings,
+		version:  version,
 	}
 }
 
@@ -44,10 +64,74 @@ func (t *Team) ID() ID {
 	return t.id
 }
 
+func (t *Team) Key() ExternalKey {
+	return t.key
+}
+
 func (t *Team) Name() TeamName {
 	return t.name
 }
 
+// Rename() changes name of the team. Key of the team stays the same,
+// so configuration bound to it is not affected.
+func (t *Team) Rename(name TeamName) {
+	t.name = name
+}
+
+// ParentID() returns parent team, nil if the team is a root of hierarchy
+func (t *Team) ParentID() *ID {
+	if t.parentID == nil {
+		return nil
+	}
+	id := *t.parentID
+	return &id
+}
+
+// SetParent() attaches the team to parent team with given ancestors (nearest first)
+// or detaches it from hierarchy if parent is nil.
+// Returns ErrTeamHierarchyCycle if the team is the parent or one of its ancestors.
+func (t *Team) SetParent(parent *Team, ancestors []*Team) error {
+	if parent == nil {
+		t.parentID = nil
+		return nil
+	}
+
+	if parent.ID() == t.id {
+		return fmt.Errorf("%w: team=%s", ErrTeamHierarchyCycle, t.name)
+	}
+	for _, ancestor := range ancestors {
+		if ancestor.ID() == t.id {
+			return fmt.Errorf("%w: team=%s is an ancestor of team=%s", ErrTeamHierarchyCycle, t.name, parent.Name())
+		}
+	}
+
+	parentID := parent.ID()
+	t.parentID = &parentID
+	return nil
+}
+
+func (t *Team) Settings() TeamSettings {
+	return t.settings
+}
+
+func (t *Team) SetSettings(settings TeamSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	t.settings = settings
+	return nil
+}
+
+func (t *Team) Version() int64 {
+	return t.version
+}
+
+// SetVersion() is used by repositories to reflect version of saved team
+func (t *Team) SetVersion(version int64) {
+	t.version = version
+}
+
 func (t *Team) UserIDs() []ID {
 	return slices.Clone(t.userIDs)
 }
@@ -77,9 +161,15 @@ func (t *Team) Validate() error {
 	if err := validateIDsUniqueness(t.UserIDs()); err != nil {
 		return fmt.Errorf("error in team members: %w", err)
 	}
+	if err := t.key.Validate(); err != nil {
+		return err
+	}
 	if err := t.name.Validate(); err != nil {
 		return err
 	}
+	if err := t.settings.Validate(); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"strings"
 )
 
@@ -32,7 +31,7 @@ func (n TeamName) String() string {
 
 func (n TeamName) Validate() error {
 	if len(n.String()) == 0 {
-		return errors.New("team name cannot be empty")
+		return NewValidationError("team_name", "cannot be empty")
 	}
 
 	return nil
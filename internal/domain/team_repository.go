@@ -1,9 +1,35 @@
 package domain
 
+import "context"
+
 type TeamRepository interface {
 	Repository[Team, ID]
-	FindByName(teamName string) (*Team, error)
-	CreateTeamAndModifyUsers(team *Team, users []*User) error
-	FindTeamByTeammateID(userID ID) (*Team, error)
-	FindActiveUsersByTeamID(teamID ID) ([]*User, error)
+	FindByName(ctx context.Context, teamName string) (*Team, error)
+	FindByKey(ctx context.Context, key ExternalKey) (*Team, error)
+	CreateTeamAndModifyUsers(ctx context.Context, team *Team, users []*User) error
+	// UpdateTeamAndModifyUsers() creates or updates users and updates team with its members
+	// in a single transaction. Returns ErrVersionConflict if team was modified concurrently.
+	UpdateTeamAndModifyUsers(ctx context.Context, team *Team, users []*User) error
+	// FindTeamByTeammateID() returns primary team of the user, nil if user has no teams
+	FindTeamByTeammateID(ctx context.Context, userID ID) (*Team, error)
+	// FindTeamsByTeammateID() returns all teams of the user, primary team goes first
+	FindTeamsByTeammateID(ctx context.Context, userID ID) ([]*Team, error)
+	// SetPrimaryTeam() makes the team primary for its member.
+	// Returns ErrNotTeamMember if user is not a member of the team.
+	SetPrimaryTeam(ctx context.Context, userID ID, teamID ID) error
+	FindActiveUsersByTeamID(ctx context.Context, teamID ID) ([]*User, error)
+	// FindAncestors() returns parent of the team, parent of the parent and so on, nearest first
+	FindAncestors(ctx context.Context, teamID ID) ([]*Team, error)
+	// DeactivateUsersAndReassignReviews() saves users and pull requests with changed reviewers
+	// and moves rotation cursors of teams of pull requests in a single transaction.
+	// Returns ErrRotationConflict if cursor was moved concurrently.
+	DeactivateUsersAndReassignReviews(ctx context.Context, users []*User, pullRequests []*PullRequest, advances []*RotationAdvance) error
+	// UpdateMembershipAndReassignReviews() saves teams in given order, pull requests with changed reviewers
+	// and moves rotation cursor (if advance is not nil) in a single transaction.
+	// Returns ErrRotationConflict if cursor was moved concurrently.
+	UpdateMembershipAndReassignReviews(ctx context.Context, teams []*Team, pullRequests []*PullRequest, advance *RotationAdvance) error
+	// DeleteAndReassignReviews() deletes team with stored version equal to the team one, saves users
+	// and pull requests with changed reviewers and moves rotation cursors of other teams in a single transaction.
+	// Returns ErrVersionConflict if team was modified and ErrRotationConflict if cursor was moved concurrently.
+	DeleteAndReassignReviews(ctx context.Context, team *Team, users []*User, pullRequests []*PullRequest, advances []*RotationAdvance) error
 }
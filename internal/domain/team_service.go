@@ -0,0 +1,722 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type TeamDomainService interface {
+	// DeactivateUsers() marks team members inactive and reassigns open pull requests they review
+	// to other active teammates. Reviews without replacement candidate stay assigned and are
+	// reported in result.
+	DeactivateUsers(ctx context.Context, teamID ID, userIDs []ID) (*DeactivationResult, error)
+
+	// AddMember() adds user to the team. The team becomes primary for user without other teams.
+	// Adding of current member changes nothing. If expectedVersions are set, team must have one of them.
+	AddMember(ctx context.Context, teamID ID, userID ID, expectedVersions []int64) (*Team, error)
+
+	// RemoveMember() removes user from the team. If reassignReviews is set, open pull requests
+	// of the team reviewed by the user are reassigned to other active teammates.
+	// If expectedVersions are set, team must have one of them.
+	RemoveMember(ctx context.Context, teamID ID, userID ID, reassignReviews bool, expectedVersions []int64) (*MembershipResult, error)
+
+	// MoveMember() removes user from one team and adds to another in a single transaction.
+	// Reviews are reassigned as by RemoveMember(). Target team becomes primary, if the source one was.
+	// Resulting team is the target one. If expectedVersions are set, source team must have one of them.
+	MoveMember(ctx context.Context, fromTeamID ID, toTeamID ID, userID ID, reassignReviews bool, expectedVersions []int64) (*MembershipResult, error)
+
+	// DeleteTeam() deletes the team according to the policy. Members of other teams get
+	// another primary team, the rest stay without team. If expectedVersions are set, team must have one of them.
+	DeleteTeam(ctx context.Context, teamID ID, policy TeamDeletionPolicy, expectedVersions []int64) (*TeamDeletionResult, error)
+}
+
+var (
+	ErrNotTeamMember = errors.New("user is not a team member")
+	// ErrTeamHasOpenPullRequests is returned on deletion of team, which has open pull requests
+	ErrTeamHasOpenPullRequests = errors.New("team has open pull requests")
+)
+
+// TeamDeletionPolicy defines handling of open pull requests on team deletion
+type TeamDeletionPolicy string
+
+const (
+	// DeletionRefuse refuses deletion if the team has open pull requests
+	DeletionRefuse TeamDeletionPolicy = "refuse"
+	// DeletionDeactivate deactivates members without other teams and reassigns their open reviews
+	// to active members of teams of pull requests
+	DeletionDeactivate TeamDeletionPolicy = "deactivate"
+)
+
+var TeamDeletionPolicies = []TeamDeletionPolicy{DeletionRefuse, DeletionDeactivate}
+
+func NewTeamDeletionPolicy(value string) (TeamDeletionPolicy, error) {
+	policy := TeamDeletionPolicy(strings.ToLower(strings.TrimSpace(value)))
+	if !slices.Contains(TeamDeletionPolicies, policy) {
+		return "", NewValidationError("policy", fmt.Sprintf("unknown value %q", value))
+	}
+
+	return policy, nil
+}
+
+func (p TeamDeletionPolicy) String() string {
+	return string(p)
+}
+
+type ReviewReassignment struct {
+	PullRequest   *PullRequest
+	OldReviewerID ID
+	NewReviewerID ID
+}
+
+type FailedReassignment struct {
+	PullRequest *PullRequest
+	ReviewerID  ID
+	Err         error
+}
+
+type DeactivationResult struct {
+	DeactivatedUsers []*User
+	Reassigned       []ReviewReassignment
+	Failed           []FailedReassignment
+}
+
+// MembershipResult is a team after membership change with reviews of the user,
+// who left another team
+type MembershipResult struct {
+	Team *Team
+	// team, which the user left on move, nil for other changes
+	SourceTeam *Team
+	Reassigned []ReviewReassignment
+	Failed     []FailedReassignment
+}
+
+// TeamDeletionResult is a deleted team with its former members
+// and their reviews reassigned by DeletionDeactivate policy
+type TeamDeletionResult struct {
+	Team    *Team
+	Members []*User
+	// members deactivated by DeletionDeactivate policy
+	Deactivated []*User
+	Reassigned  []ReviewReassignment
+	Failed      []FailedReassignment
+}
+
+type DefaultTeamDomainService struct {
+	userRepo   UserRepository
+	teamRepo   TeamRepository
+	prRepo     PullRequestRepository
+	selectors  ReviewerSelectorProvider
+	eventRepo  AssignmentEventRepository
+	transactor Transactor
+}
+
+func NewDefaultTeamDomainService(
+	userRepository UserRepository,
+	pullRequestRepository PullRequestRepository,
+	teamRepository TeamRepository,
+	reviewerSelectorProvider ReviewerSelectorProvider,
+	assignmentEventRepository AssignmentEventRepository,
+	transactor Transactor,
+) (*DefaultTeamDomainService, error) {
+	if userRepository == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if pullRequestRepository == nil {
+		return nil, errors.New("pullRequestRepository cannot be nil")
+	}
+	if teamRepository == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if reviewerSelectorProvider == nil {
+		return nil, errors.New("reviewerSelectorProvider cannot be nil")
+	}
+	if assignmentEventRepository == nil {
+		return nil, errors.New("assignmentEventRepository cannot be nil")
+	}
+	if transactor == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+
+	return &DefaultTeamDomainService{
+		userRepo:   userRepository,
+		prRepo:     pullRequestRepository,
+		teamRepo:   teamRepository,
+		selectors:  reviewerSelectorProvider,
+		eventRepo:  assignmentEventRepository,
+		transactor: transactor,
+	}, nil
+}
+
+func (s *DefaultTeamDomainService) DeactivateUsers(ctx context.Context, teamID ID, userIDs []ID) (*DeactivationResult, error) {
+	var result *DeactivationResult
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			var (
+				advances []*RotationAdvance
+				err      error
+			)
+			result, advances, err = s.planDeactivation(ctx, teamID, userIDs)
+			if err != nil {
+				return err
+			}
+
+			pullRequests := make([]*PullRequest, 0, len(result.Reassigned))
+			for _, r := range result.Reassigned {
+				if !slices.Contains(pullRequests, r.PullRequest) {
+					pullRequests = append(pullRequests, r.PullRequest)
+				}
+			}
+
+			err = s.teamRepo.DeactivateUsersAndReassignReviews(ctx, result.DeactivatedUsers, pullRequests, advances)
+			if err != nil {
+				return err
+			}
+
+			return s.eventRepo.Append(ctx, deactivationEvents(ctx, teamID, result)...)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// planDeactivation() deactivates users and reassigns their reviews in memory inside teams
+// of pull requests. Returned rotation advances accumulate selections made by rotating strategy in each team.
+// Must be called within transaction, affected pull requests stay locked until its end.
+func (s *DefaultTeamDomainService) planDeactivation(ctx context.Context, teamID ID, userIDs []ID) (*DeactivationResult, []*RotationAdvance, error) {
+	team, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if team == nil {
+		return nil, nil, ErrTeamNotFound
+	}
+
+	members := team.UserIDs()
+	for _, userID := range userIDs {
+		if !slices.Contains(members, userID) {
+			return nil, nil, fmt.Errorf("%w: id=%v", ErrNotTeamMember, userID)
+		}
+	}
+
+	users, err := s.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, u := range users {
+		u.SetActive(false)
+	}
+
+	pullRequests, err := s.lockOpenReviews(ctx, userIDs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := s.reassignReviewsByTeam(ctx, pullRequests, userIDs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &DeactivationResult{
+		DeactivatedUsers: users,
+		Reassigned:       plan.reassigned,
+		Failed:           plan.failed,
+	}, plan.advances, nil
+}
+
+// lockOpenReviews() returns open pull requests reviewed by given users and locks them
+// until the end of transaction. If authorIDs is not nil, only pull requests of these authors are returned.
+func (s *DefaultTeamDomainService) lockOpenReviews(ctx context.Context, userIDs []ID, authorIDs []ID) ([]*PullRequest, error) {
+	// single pull request may be reviewed by several users
+	pullRequests := make([]*PullRequest, 0)
+	seen := make(map[ID]bool)
+	for _, userID := range userIDs {
+		reviews, err := s.prRepo.FindPullRequestsByReviewer(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range reviews {
+			if seen[pr.ID()] {
+				continue
+			}
+			seen[pr.ID()] = true
+			if authorIDs != nil && !slices.Contains(authorIDs, pr.AuthorID()) {
+				continue
+			}
+
+			locked, err := s.prRepo.FindByIDForUpdate(ctx, pr.ID())
+			if err != nil {
+				return nil, err
+			}
+			if locked == nil || locked.Status() != PROpen {
+				continue
+			}
+			pullRequests = append(pullRequests, locked)
+		}
+	}
+
+	return pullRequests, nil
+}
+
+type reassignmentPlan struct {
+	// pullRequests with changed reviewers
+	pullRequests []*PullRequest
+	reassigned   []ReviewReassignment
+	failed       []FailedReassignment
+	// advance accumulates all selections made by rotating strategy
+	advance *RotationAdvance
+}
+
+// reassignReviews() replaces given reviewers of pull requests with other active
+// members of the team in memory, or with members of its ancestors, if the team has no candidate.
+// Reviews without candidate stay assigned.
+func (s *DefaultTeamDomainService) reassignReviews(ctx context.Context, team *Team, pullRequests []*PullRequest, reviewerIDs []ID) (*reassignmentPlan, error) {
+	activeUsers, err := s.teamRepo.FindActiveUsersByTeamID(ctx, team.ID())
+	if err != nil {
+		return nil, err
+	}
+	candidates := excludeUsers(activeUsers, reviewerIDs...)
+
+	plan := &reassignmentPlan{}
+	selector := s.selectors.SelectorForTeam(team)
+	for _, pr := range pullRequests {
+		for _, reviewerID := range pr.ReviewerIDs() {
+			if !slices.Contains(reviewerIDs, reviewerID) {
+				continue
+			}
+
+			except := append(pr.ReviewerIDs(), pr.AuthorID())
+			selection, err := selectNextReviewer(ctx, selector, plan.advance, team, candidates, except...)
+			if err != nil {
+				return nil, err
+			}
+
+			var newReviewer *User
+			var fallbackTeamID *ID
+			if len(selection.Reviewers) > 0 {
+				newReviewer = selection.Reviewers[0]
+			} else {
+				// replaced reviewers may still be active members of ancestors
+				fallback, err := selectFallbackReviewers(ctx, s.teamRepo, team, 1, append(except, reviewerIDs...)...)
+				if err != nil {
+					return nil, err
+				}
+				if len(fallback) == 0 {
+					plan.failed = append(plan.failed, FailedReassignment{
+						PullRequest: pr,
+						ReviewerID:  reviewerID,
+						Err:         ErrNoReviewCandidates,
+					})
+					continue
+				}
+				newReviewer = fallback[0].user
+				fallbackTeamID = &fallback[0].teamID
+			}
+
+			if err := pr.ReassignReviewer(reviewerID, newReviewer.ID()); err != nil {
+				return nil, err
+			}
+			if fallbackTeamID != nil {
+				pr.setFallbackTeam(newReviewer.ID(), *fallbackTeamID)
+			}
+
+			plan.reassigned = append(plan.reassigned, ReviewReassignment{
+				PullRequest:   pr,
+				OldReviewerID: reviewerID,
+				NewReviewerID: newReviewer.ID(),
+			})
+			if !slices.Contains(plan.pullRequests, pr) {
+				plan.pullRequests = append(plan.pullRequests, pr)
+			}
+			plan.advance = chainRotation(plan.advance, selection.Rotation)
+		}
+	}
+
+	return plan, nil
+}
+
+type teamsReassignmentPlan struct {
+	reassigned []ReviewReassignment
+	failed     []FailedReassignment
+	// advances accumulate selections made by rotating strategy, one per team
+	advances []*RotationAdvance
+}
+
+// reassignReviewsByTeam() groups pull requests by their teams and replaces given reviewers
+// with active members of the team of each pull request in memory. Reviews on pull requests
+// without team or of the excluded team stay assigned.
+func (s *DefaultTeamDomainService) reassignReviewsByTeam(ctx context.Context, pullRequests []*PullRequest, reviewerIDs []ID, excludedTeamID *ID) (*teamsReassignmentPlan, error) {
+	plan := &teamsReassignmentPlan{}
+	prTeams := make([]*Team, 0)
+	byTeam := make(map[ID][]*PullRequest)
+	for _, pr := range pullRequests {
+		prTeam, err := findPullRequestTeam(ctx, s.teamRepo, pr)
+		if err != nil {
+			return nil, err
+		}
+		if prTeam == nil || (excludedTeamID != nil && prTeam.ID() == *excludedTeamID) {
+			for _, reviewerID := range pr.ReviewerIDs() {
+				if slices.Contains(reviewerIDs, reviewerID) {
+					plan.failed = append(plan.failed, FailedReassignment{
+						PullRequest: pr,
+						ReviewerID:  reviewerID,
+						Err:         ErrNoReviewCandidates,
+					})
+				}
+			}
+			continue
+		}
+		if _, ok := byTeam[prTeam.ID()]; !ok {
+			prTeams = append(prTeams, prTeam)
+		}
+		byTeam[prTeam.ID()] = append(byTeam[prTeam.ID()], pr)
+	}
+
+	for _, prTeam := range prTeams {
+		teamPlan, err := s.reassignReviews(ctx, prTeam, byTeam[prTeam.ID()], reviewerIDs)
+		if err != nil {
+			return nil, err
+		}
+		plan.reassigned = append(plan.reassigned, teamPlan.reassigned...)
+		plan.failed = append(plan.failed, teamPlan.failed...)
+		if teamPlan.advance != nil {
+			plan.advances = append(plan.advances, teamPlan.advance)
+		}
+	}
+
+	return plan, nil
+}
+
+func (s *DefaultTeamDomainService) AddMember(ctx context.Context, teamID ID, userID ID, expectedVersions []int64) (*Team, error) {
+	var team *Team
+	err := s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		team, err = s.findTeam(ctx, teamID, expectedVersions)
+		if err != nil {
+			return err
+		}
+		if slices.Contains(team.UserIDs(), userID) {
+			// user is already a member, nothing to change
+			return nil
+		}
+		if err := s.addMember(ctx, team, userID); err != nil {
+			return err
+		}
+
+		if err := s.teamRepo.UpdateMembershipAndReassignReviews(ctx, []*Team{team}, nil, nil); err != nil {
+			return err
+		}
+		return s.eventRepo.Append(ctx, TeamMemberAddedEvent(ctx, team.ID(), userID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+// addMember() adds existing user to the team in memory
+func (s *DefaultTeamDomainService) addMember(ctx context.Context, team *Team, userID ID) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	return team.AddUser(userID)
+}
+
+func (s *DefaultTeamDomainService) RemoveMember(ctx context.Context, teamID ID, userID ID, reassignReviews bool, expectedVersions []int64) (*MembershipResult, error) {
+	var result *MembershipResult
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			team, err := s.findTeam(ctx, teamID, expectedVersions)
+			if err != nil {
+				return err
+			}
+			plan, err := s.removeMember(ctx, team, userID, reassignReviews)
+			if err != nil {
+				return err
+			}
+
+			err = s.teamRepo.UpdateMembershipAndReassignReviews(ctx, []*Team{team}, plan.pullRequests, plan.advance)
+			if err != nil {
+				return err
+			}
+
+			events := []*AssignmentEvent{TeamMemberRemovedEvent(ctx, team.ID(), userID)}
+			events = append(events, reassignmentEvents(ctx, plan.reassigned)...)
+			if err := s.eventRepo.Append(ctx, events...); err != nil {
+				return err
+			}
+
+			result = &MembershipResult{Team: team, Reassigned: plan.reassigned, Failed: plan.failed}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *DefaultTeamDomainService) MoveMember(ctx context.Context, fromTeamID ID, toTeamID ID, userID ID, reassignReviews bool, expectedVersions []int64) (*MembershipResult, error) {
+	var result *MembershipResult
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			from, err := s.findTeam(ctx, fromTeamID, expectedVersions)
+			if err != nil {
+				return err
+			}
+			to, err := s.findTeam(ctx, toTeamID, nil)
+			if err != nil {
+				return err
+			}
+			if from.ID() == to.ID() {
+				return fmt.Errorf("%w: user is moved to the same team", ErrAlreadyTeamMember)
+			}
+
+			primary, err := s.teamRepo.FindTeamByTeammateID(ctx, userID)
+			if err != nil {
+				return err
+			}
+			plan, err := s.removeMember(ctx, from, userID, reassignReviews)
+			if err != nil {
+				return err
+			}
+			if err := to.AddUser(userID); err != nil {
+				return err
+			}
+
+			err = s.teamRepo.UpdateMembershipAndReassignReviews(ctx, []*Team{from, to}, plan.pullRequests, plan.advance)
+			if err != nil {
+				return err
+			}
+			if primary != nil && primary.ID() == from.ID() {
+				if err := s.teamRepo.SetPrimaryTeam(ctx, userID, to.ID()); err != nil {
+					return err
+				}
+			}
+
+			events := []*AssignmentEvent{
+				TeamMemberRemovedEvent(ctx, from.ID(), userID),
+				TeamMemberAddedEvent(ctx, to.ID(), userID),
+			}
+			events = append(events, reassignmentEvents(ctx, plan.reassigned)...)
+			if err := s.eventRepo.Append(ctx, events...); err != nil {
+				return err
+			}
+
+			result = &MembershipResult{Team: to, SourceTeam: from, Reassigned: plan.reassigned, Failed: plan.failed}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// removeMember() removes user from the team in memory and, if reassignReviews is set,
+// reassigns open reviews of the user on pull requests of the team. Pull requests without team
+// belong to the team if their authors are its members.
+// Must be called within transaction, affected pull requests stay locked until its end.
+func (s *DefaultTeamDomainService) removeMember(ctx context.Context, team *Team, userID ID, reassignReviews bool) (*reassignmentPlan, error) {
+	members := team.UserIDs()
+	if !slices.Contains(members, userID) {
+		return nil, fmt.Errorf("%w: id=%v", ErrNotTeamMember, userID)
+	}
+	if err := team.RemoveUser(userID); err != nil {
+		return nil, err
+	}
+	if !reassignReviews {
+		return &reassignmentPlan{}, nil
+	}
+
+	pullRequests, err := s.lockOpenReviews(ctx, []ID{userID}, members)
+	if err != nil {
+		return nil, err
+	}
+	// authors may draw reviewers from their other teams
+	pullRequests = slices.DeleteFunc(pullRequests, func(pr *PullRequest) bool {
+		teamID := pr.TeamID()
+		return teamID != nil && *teamID != team.ID()
+	})
+	return s.reassignReviews(ctx, team, pullRequests, []ID{userID})
+}
+
+func (s *DefaultTeamDomainService) DeleteTeam(ctx context.Context, teamID ID, policy TeamDeletionPolicy, expectedVersions []int64) (*TeamDeletionResult, error) {
+	var result *TeamDeletionResult
+	err := withRotationRetry(ctx, func(ctx context.Context) error {
+		return s.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+			team, err := s.findTeam(ctx, teamID, expectedVersions)
+			if err != nil {
+				return err
+			}
+
+			var advances []*RotationAdvance
+			result, advances, err = s.planDeletion(ctx, team, policy)
+			if err != nil {
+				return err
+			}
+
+			pullRequests := make([]*PullRequest, 0, len(result.Reassigned))
+			for _, r := range result.Reassigned {
+				if !slices.Contains(pullRequests, r.PullRequest) {
+					pullRequests = append(pullRequests, r.PullRequest)
+				}
+			}
+
+			err = s.teamRepo.DeleteAndReassignReviews(ctx, team, result.Deactivated, pullRequests, advances)
+			if err != nil {
+				return err
+			}
+
+			events := []*AssignmentEvent{TeamChangedEvent(ctx, team, EventTeamDeleted)}
+			if policy == DeletionDeactivate {
+				events = append(events, deactivationEvents(ctx, team.ID(), &DeactivationResult{
+					DeactivatedUsers: result.Deactivated,
+					Reassigned:       result.Reassigned,
+				})...)
+			}
+			return s.eventRepo.Append(ctx, events...)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// planDeletion() checks open pull requests of the team or deactivates its members without
+// other teams and reassigns their reviews in memory according to the policy.
+// Must be called within transaction, affected pull requests stay locked until its end.
+func (s *DefaultTeamDomainService) planDeletion(ctx context.Context, team *Team, policy TeamDeletionPolicy) (*TeamDeletionResult, []*RotationAdvance, error) {
+	members, err := s.userRepo.FindByIDs(ctx, team.UserIDs())
+	if err != nil {
+		return nil, nil, err
+	}
+	result := &TeamDeletionResult{Team: team, Members: members}
+
+	switch policy {
+	case DeletionRefuse:
+		count, err := s.prRepo.CountOpenPullRequestsByTeamID(ctx, team.ID())
+		if err != nil {
+			return nil, nil, err
+		}
+		if count > 0 {
+			return nil, nil, fmt.Errorf("%w: count=%d", ErrTeamHasOpenPullRequests, count)
+		}
+		return result, nil, nil
+	case DeletionDeactivate:
+	default:
+		return nil, nil, fmt.Errorf("unknown team deletion policy %q", policy)
+	}
+
+	memberIDs := make([]ID, 0, len(members))
+	for _, u := range members {
+		teams, err := s.teamRepo.FindTeamsByTeammateID(ctx, u.ID())
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(teams) > 1 {
+			// user stays active in other teams
+			continue
+		}
+		u.SetActive(false)
+		result.Deactivated = append(result.Deactivated, u)
+		memberIDs = append(memberIDs, u.ID())
+	}
+	pullRequests, err := s.lockOpenReviews(ctx, memberIDs, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// reviews are reassigned inside teams of pull requests, pull requests of the deleted team have no candidates
+	teamID := team.ID()
+	plan, err := s.reassignReviewsByTeam(ctx, pullRequests, memberIDs, &teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+	result.Reassigned = plan.reassigned
+	result.Failed = plan.failed
+
+	return result, plan.advances, nil
+}
+
+func (s *DefaultTeamDomainService) findTeam(ctx context.Context, teamID ID, expectedVersions []int64) (*Team, error) {
+	team, err := s.teamRepo.FindByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if team == nil {
+		return nil, ErrTeamNotFound
+	}
+	if err := CheckVersion(team.Version(), expectedVersions); err != nil {
+		return nil, err
+	}
+
+	return team, nil
+}
+
+func reassignmentEvents(ctx context.Context, reassigned []ReviewReassignment) []*AssignmentEvent {
+	events := make([]*AssignmentEvent, len(reassigned))
+	for i, r := range reassigned {
+		events[i] = ReviewerReassignedEvent(ctx, r.PullRequest.ID(), r.OldReviewerID, r.NewReviewerID)
+	}
+
+	return events
+}
+
+func deactivationEvents(ctx context.Context, teamID ID, result *DeactivationResult) []*AssignmentEvent {
+	events := make([]*AssignmentEvent, 0, len(result.DeactivatedUsers)+len(result.Reassigned))
+	for _, u := range result.DeactivatedUsers {
+		events = append(events, UserActivityChangedEvent(ctx, u, &teamID))
+	}
+	events = append(events, reassignmentEvents(ctx, result.Reassigned)...)
+
+	return events
+}
+
+// selectNextReviewer() chooses single reviewer continuing rotation from pending advance
+// if selector supports it
+func selectNextReviewer(
+	ctx context.Context,
+	selector ReviewerSelector,
+	pending *RotationAdvance,
+	team *Team,
+	candidates []*User,
+	except ...ID,
+) (*ReviewerSelection, error) {
+	rotating, ok := selector.(RotatingReviewerSelector)
+	if !ok || pending == nil {
+		return selector.SelectReviewers(ctx, team, candidates, 1, except...)
+	}
+
+	cursor := pending.To
+	return rotating.SelectReviewersFrom(ctx, &cursor, team, candidates, 1, except...)
+}
+
+func chainRotation(pending *RotationAdvance, next *RotationAdvance) *RotationAdvance {
+	if next == nil {
+		return pending
+	}
+	if pending == nil {
+		return next
+	}
+
+	return &RotationAdvance{
+		TeamID: pending.TeamID,
+		From:   pending.From,
+		To:     next.To,
+	}
+}
@@ -0,0 +1,120 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+func TestDeleteTeam(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy domain.TeamDeletionPolicy
+		// closeOpen closes pull request of the deleted team before deletion
+		closeOpen       bool
+		wantErr         error
+		wantDeactivated []domain.ID
+		// reviewers of pull request of another team after deletion
+		wantOtherReviewers []domain.ID
+		wantFailed         int
+	}{
+		{
+			name:               "refuse with open pull requests",
+			policy:             domain.DeletionRefuse,
+			wantErr:            domain.ErrTeamHasOpenPullRequests,
+			wantOtherReviewers: []domain.ID{testID(3)},
+		},
+		{
+			name:               "refuse without open pull requests",
+			policy:             domain.DeletionRefuse,
+			closeOpen:          true,
+			wantOtherReviewers: []domain.ID{testID(3)},
+		},
+		{
+			name:   "deactivate members without other teams",
+			policy: domain.DeletionDeactivate,
+			// member of another team stays active
+			wantDeactivated: []domain.ID{testID(1), testID(3)},
+			// review of deactivated member is reassigned inside team of pull request
+			wantOtherReviewers: []domain.ID{testID(2)},
+			// pull request of deleted team has no candidates
+			wantFailed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := newFixture(t)
+			deleted := f.team(t, domain.DefaultTeamSettings(), testUser(1, true), testUser(2, true), testUser(3, true))
+			other := f.team(t, domain.DefaultTeamSettings(), testUser(2, true), testUser(4, true))
+			own := f.storedPullRequest(t, 1, deleted.ID(), testID(1), testID(2), testID(3))
+			foreign := f.storedPullRequest(t, 2, other.ID(), testID(4), testID(3))
+			if tt.closeOpen {
+				if _, err := f.prService.Close(ctx, own.ID(), nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := f.teamService.DeleteTeam(ctx, deleted.ID(), tt.policy, nil)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DeleteTeam() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored, err := f.teams.FindByID(ctx, deleted.ID())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != nil {
+				if stored == nil {
+					t.Error("team is deleted, want it kept")
+				}
+			} else {
+				if stored != nil {
+					t.Error("team is kept, want it deleted")
+				}
+				gotDeactivated := make([]domain.ID, len(got.Deactivated))
+				for i, u := range got.Deactivated {
+					gotDeactivated[i] = u.ID()
+				}
+				if !slices.Equal(gotDeactivated, tt.wantDeactivated) {
+					t.Errorf("DeleteTeam() deactivated = %v, want %v", gotDeactivated, tt.wantDeactivated)
+				}
+				if len(got.Failed) != tt.wantFailed {
+					t.Errorf("DeleteTeam() failed = %d, want %d", len(got.Failed), tt.wantFailed)
+				}
+			}
+
+			for _, n := range []int{1, 2, 3, 4} {
+				user, err := f.users.FindByID(ctx, testID(n))
+				if err != nil {
+					t.Fatal(err)
+				}
+				wantActive := !slices.Contains(tt.wantDeactivated, testID(n))
+				if user.Active() != wantActive {
+					t.Errorf("user %d active = %t, want %t", n, user.Active(), wantActive)
+				}
+			}
+			if reviewers := f.findPullRequest(t, foreign.ID()).ReviewerIDs(); !slices.Equal(reviewers, tt.wantOtherReviewers) {
+				t.Errorf("reviewers of pull request of another team = %v, want %v", reviewers, tt.wantOtherReviewers)
+			}
+		})
+	}
+}
+
+func TestDeleteTeamStaleVersion(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	team := f.team(t, domain.DefaultTeamSettings(), testUser(1, true))
+
+	_, err := f.teamService.DeleteTeam(ctx, team.ID(), domain.DeletionRefuse, []int64{team.Version() + 1})
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("DeleteTeam() error = %v, want %v", err, domain.ErrVersionConflict)
+	}
+	if stored, err := f.teams.FindByID(ctx, team.ID()); err != nil || stored == nil {
+		t.Errorf("FindByID() = %v, %v, want kept team", stored, err)
+	}
+}
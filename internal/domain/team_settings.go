@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultMinReviewersCount int = 0
+	DefaultMaxReviewersCount int = 2
+	// DefaultRequiredApprovals disables approvals check on merge
+	DefaultRequiredApprovals int = 0
+)
+
+var (
+	ErrInvalidReviewersRange    = errors.New("invalid reviewers count range")
+	ErrInvalidRequiredApprovals = errors.New("invalid required approvals count")
+)
+
+// TeamSettings holds team-level policy of pull request reviewing
+type TeamSettings struct {
+	minReviewers      int
+	maxReviewers      int
+	requiredApprovals int
+}
+
+func NewTeamSettings(minReviewers, maxReviewers, requiredApprovals int) (TeamSettings, error) {
+	settings := ExistingTeamSettings(minReviewers, maxReviewers, requiredApprovals)
+	if err := settings.Validate(); err != nil {
+		return TeamSettings{}, err
+	}
+
+	return settings, nil
+}
+
+func DefaultTeamSettings() TeamSettings {
+	return ExistingTeamSettings(DefaultMinReviewersCount, DefaultMaxReviewersCount, DefaultRequiredApprovals)
+}
+
+func ExistingTeamSettings(minReviewers, maxReviewers, requiredApprovals int) TeamSettings {
+	return TeamSettings{
+		minReviewers:      minReviewers,
+		maxReviewers:      maxReviewers,
+		requiredApprovals: requiredApprovals,
+	}
+}
+
+// MinReviewers() returns number of reviewers without which pull request cannot be created
+func (s TeamSettings) MinReviewers() int {
+	return s.minReviewers
+}
+
+// MaxReviewers() returns number of reviewers assigned to pull request if enough candidates exist
+func (s TeamSettings) MaxReviewers() int {
+	return s.maxReviewers
+}
+
+// RequiredApprovals() returns number of approvals without which pull request
+// of team member cannot be merged. Zero disables the check.
+func (s TeamSettings) RequiredApprovals() int {
+	return s.requiredApprovals
+}
+
+// CheckApprovals() returns ErrNotEnoughApprovals if pull request lacks required approvals
+func (s TeamSettings) CheckApprovals(pullRequest *PullRequest) error {
+	if s.MissingApprovals(pullRequest) > 0 {
+		return fmt.Errorf("%w: %d of %d required", ErrNotEnoughApprovals, pullRequest.Approvals(), s.requiredApprovals)
+	}
+
+	return nil
+}
+
+// MissingApprovals() returns number of approvals pull request lacks to be merged
+func (s TeamSettings) MissingApprovals(pullRequest *PullRequest) int {
+	return max(s.requiredApprovals-pullRequest.Approvals(), 0)
+}
+
+func (s TeamSettings) Validate() error {
+	if s.minReviewers < 0 {
+		return &ValidationError{
+			Field:  "min_reviewers",
+			Reason: "cannot be negative",
+			Err:    ErrInvalidReviewersRange,
+		}
+	}
+	if s.minReviewers > s.maxReviewers {
+		return &ValidationError{
+			Field:  "min_reviewers",
+			Reason: fmt.Sprintf("%d is greater than maximum %d", s.minReviewers, s.maxReviewers),
+			Err:    ErrInvalidReviewersRange,
+		}
+	}
+	if s.requiredApprovals < 0 {
+		return &ValidationError{
+			Field:  "required_approvals",
+			Reason: "cannot be negative",
+			Err:    ErrInvalidRequiredApprovals,
+		}
+	}
+	// pull request gets at least minReviewers reviewers, fewer ones could never approve it
+	if s.requiredApprovals > s.minReviewers {
+		return &ValidationError{
+			Field:  "required_approvals",
+			Reason: fmt.Sprintf("%d is greater than minimum reviewers %d", s.requiredApprovals, s.minReviewers),
+			Err:    ErrInvalidRequiredApprovals,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrNoTransaction = errors.New("operation must be run within transaction")
+
+// Transactor runs multi-repository operations in a single transaction.
+// Repositories take part in the transaction when called with ctx passed to fn.
+type Transactor interface {
+	// WithinTransaction() commits changes made by fn if it succeeds and rolls them back otherwise.
+	// Nested calls join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -2,25 +2,29 @@ package domain
 
 type User struct {
 	id     ID
+	key    ExternalKey
 	name   UserName
 	active bool
 }
 
 func ExistingUser(
 	id ID,
+	key ExternalKey,
 	name UserName,
 	active bool,
 ) *User {
 	return &User{
 		id:     id,
+		key:    key,
 		name:   name,
 		active: active,
 	}
 }
 
-func NewUser(name UserName, active bool) (*User, error) {
+func NewUser(key ExternalKey, name UserName, active bool) (*User, error) {
 	return &User{
 		id:     NewID(),
+		key:    key,
 		name:   name,
 		active: active,
 	}, nil
@@ -30,6 +34,10 @@ func (u *User) ID() ID {
 	return u.id
 }
 
+func (u *User) Key() ExternalKey {
+	return u.key
+}
+
 func (u *User) Name() UserName {
 	return u.name
 }
@@ -43,6 +51,9 @@ func (u *User) SetActive(active bool) {
 }
 
 func (u *User) Validate() error {
+	if err := u.key.Validate(); err != nil {
+		return err
+	}
 	if err := u.name.Validate(); err != nil {
 		return err
 	}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+const maxLoginLength = 255
+
+// IdentityProvider is a code hosting, where users have accounts
+type IdentityProvider string
+
+const (
+	ProviderGitHub IdentityProvider = "github"
+	ProviderGitLab IdentityProvider = "gitlab"
+)
+
+func NewIdentityProvider(value string) (IdentityProvider, error) {
+	processed := strings.ToLower(strings.TrimSpace(value))
+	switch processed {
+	case "github":
+		return ProviderGitHub, nil
+	case "gitlab":
+		return ProviderGitLab, nil
+	default:
+		return IdentityProvider(""), NewValidationError("provider", fmt.Sprintf("unknown value %q", processed))
+	}
+}
+
+func (p IdentityProvider) String() string {
+	return string(p)
+}
+
+// UserIdentity links user to account on code hosting.
+// User has at most one account per provider.
+type UserIdentity struct {
+	provider IdentityProvider
+	// logins are case-insensitive, so they are stored lower-cased
+	login  string
+	userID ID
+}
+
+func NewUserIdentity(provider IdentityProvider, login string, userID ID) (*UserIdentity, error) {
+	processed := NormalizeLogin(login)
+	if processed == "" {
+		return nil, NewValidationError("login", "cannot be empty")
+	}
+	if len(processed) > maxLoginLength {
+		return nil, NewValidationError("login", fmt.Sprintf("cannot be longer than %d characters", maxLoginLength))
+	}
+
+	return ExistingUserIdentity(provider, processed, userID), nil
+}
+
+func ExistingUserIdentity(provider IdentityProvider, login string, userID ID) *UserIdentity {
+	return &UserIdentity{
+		provider: provider,
+		login:    login,
+		userID:   userID,
+	}
+}
+
+// NormalizeLogin() converts login to the form identities are stored in
+func NormalizeLogin(login string) string {
+	return strings.ToLower(strings.TrimSpace(login))
+}
+
+func (i *UserIdentity) Provider() IdentityProvider {
+	return i.provider
+}
+
+func (i *UserIdentity) Login() string {
+	return i.login
+}
+
+func (i *UserIdentity) UserID() ID {
+	return i.userID
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+type UserIdentityRepository interface {
+	// Save() links login to user, replacing previous account of the user
+	// and previous owner of the login at the same provider
+	Save(ctx context.Context, identity *UserIdentity) error
+	// FindByLogin() expects login normalized with NormalizeLogin()
+	FindByLogin(ctx context.Context, provider IdentityProvider, login string) (*UserIdentity, error)
+	// FindByUserIDs() returns accounts of given users at provider. Users without account are skipped.
+	FindByUserIDs(ctx context.Context, provider IdentityProvider, userIDs []ID) ([]*UserIdentity, error)
+}
@@ -1,7 +1,6 @@
 package domain
 
 import (
-	"errors"
 	"strings"
 )
 
@@ -32,7 +31,7 @@ func (n UserName) String() string {
 
 func (n UserName) Validate() error {
 	if len(n.String()) == 0 {
-		return errors.New("user name cannot be empty")
+		return NewValidationError("user_name", "cannot be empty")
 	}
 
 	return nil
@@ -1,5 +1,10 @@
 package domain
 
+import "context"
+
 type UserRepository interface {
 	Repository[User, ID]
+	FindByKey(ctx context.Context, key ExternalKey) (*User, error)
+	// FindByIDs() returns existing users with given ids. Missing ids are skipped.
+	FindByIDs(ctx context.Context, ids []ID) ([]*User, error)
 }
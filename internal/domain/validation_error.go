@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrValidation = errors.New("validation failed")
+
+// ValidationError reports a single attribute value violating domain invariants.
+// It matches ErrValidation and, if set, Err with errors.Is
+type ValidationError struct {
+	Field  string
+	Reason string
+	Err    error
+}
+
+func NewValidationError(field, reason string) *ValidationError {
+	return &ValidationError{
+		Field:  field,
+		Reason: reason,
+	}
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() []error {
+	if e.Err == nil {
+		return []error{ErrValidation}
+	}
+
+	return []error{ErrValidation, e.Err}
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryDead marks deliveries, which ran out of attempts
+	DeliveryDead DeliveryStatus = "dead"
+)
+
+func NewDeliveryStatus(value string) (DeliveryStatus, error) {
+	processed := strings.ToLower(strings.TrimSpace(value))
+	switch processed {
+	case "pending":
+		return DeliveryPending, nil
+	case "delivered":
+		return DeliveryDelivered, nil
+	case "dead":
+		return DeliveryDead, nil
+	default:
+		return DeliveryStatus(""), NewValidationError("status", fmt.Sprintf("unknown value %q", processed))
+	}
+}
+
+func (s DeliveryStatus) String() string {
+	return string(s)
+}
+
+// RetryPolicy defines exponential backoff of failed deliveries
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Delay() returns time to wait after given number of failed attempts
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxDelay)
+}
+
+// WebhookDelivery is a single event posted to a single subscriber
+type WebhookDelivery struct {
+	id             ID
+	subscriptionID ID
+	eventID        ID
+	eventType      AssignmentEventType
+	payload        []byte
+	status         DeliveryStatus
+	attempts       int
+	nextAttemptAt  time.Time
+	lastError      string
+	createdAt      time.Time
+	deliveredAt    *time.Time
+}
+
+func NewWebhookDelivery(subscriptionID ID, event *AssignmentEvent, payload []byte, now time.Time) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:             NewID(),
+		subscriptionID: subscriptionID,
+		eventID:        event.ID,
+		eventType:      event.Type,
+		payload:        payload,
+		status:         DeliveryPending,
+		nextAttemptAt:  now,
+		createdAt:      now,
+	}
+}
+
+func ExistingWebhookDelivery(
+	id ID,
+	subscriptionID ID,
+	eventID ID,
+	eventType AssignmentEventType,
+	payload []byte,
+	status DeliveryStatus,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	createdAt time.Time,
+	deliveredAt *time.Time,
+) *WebhookDelivery {
+	return &WebhookDelivery{
+		id:             id,
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+func (d *WebhookDelivery) ID() ID {
+	return d.id
+}
+
+func (d *WebhookDelivery) SubscriptionID() ID {
+	return d.subscriptionID
+}
+
+func (d *WebhookDelivery) EventID() ID {
+	return d.eventID
+}
+
+func (d *WebhookDelivery) EventType() AssignmentEventType {
+	return d.eventType
+}
+
+// Payload() returns JSON body posted to subscriber
+func (d *WebhookDelivery) Payload() []byte {
+	return d.payload
+}
+
+func (d *WebhookDelivery) Status() DeliveryStatus {
+	return d.status
+}
+
+func (d *WebhookDelivery) Attempts() int {
+	return d.attempts
+}
+
+func (d *WebhookDelivery) NextAttemptAt() time.Time {
+	return d.nextAttemptAt
+}
+
+// LastError() returns reason of the last failed attempt
+func (d *WebhookDelivery) LastError() string {
+	return d.lastError
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+func (d *WebhookDelivery) DeliveredAt() *time.Time {
+	if d.deliveredAt == nil {
+		return nil
+	}
+	copy := *d.deliveredAt
+	return &copy
+}
+
+func (d *WebhookDelivery) RecordSuccess(now time.Time) {
+	d.attempts++
+	d.status = DeliveryDelivered
+	d.lastError = ""
+	d.deliveredAt = &now
+}
+
+// RecordFailure() schedules next attempt according to policy or marks delivery dead
+// if there are no attempts left
+func (d *WebhookDelivery) RecordFailure(reason string, now time.Time, policy RetryPolicy) {
+	d.attempts++
+	d.lastError = reason
+	if d.attempts >= policy.MaxAttempts {
+		d.status = DeliveryDead
+		return
+	}
+
+	d.nextAttemptAt = now.Add(policy.Delay(d.attempts))
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *WebhookSubscription) error
+	FindByID(ctx context.Context, id ID) (*WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]*WebhookSubscription, error)
+	// DeleteByID() removes subscription together with its deliveries
+	DeleteByID(ctx context.Context, id ID) error
+}
+
+// WebhookDeliveryFilter restricts found deliveries, nil fields do not restrict
+type WebhookDeliveryFilter struct {
+	SubscriptionID *ID
+	Status         *DeliveryStatus
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, deliveries ...*WebhookDelivery) error
+	// ClaimDue() returns at most limit pending deliveries, which are due at now,
+	// and postpones their next attempt by lease, so concurrent dispatchers
+	// do not send them twice
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*WebhookDelivery, error)
+	Update(ctx context.Context, delivery *WebhookDelivery) error
+	FindAll(ctx context.Context, filter WebhookDeliveryFilter) ([]*WebhookDelivery, error)
+}
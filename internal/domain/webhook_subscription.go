@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"net/url"
+	"slices"
+	"time"
+)
+
+// WebhookSubscription is a registration of URL, to which events of given types are posted
+type WebhookSubscription struct {
+	id     ID
+	url    string
+	secret string
+	// empty if subscriber receives events of all types
+	eventTypes []AssignmentEventType
+	createdAt  time.Time
+}
+
+func NewWebhookSubscription(rawURL string, secret string, eventTypes []AssignmentEventType) (*WebhookSubscription, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, NewValidationError("url", "must be absolute http(s) URL")
+	}
+	if secret == "" {
+		return nil, NewValidationError("secret", "cannot be empty")
+	}
+
+	types := make([]AssignmentEventType, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	return &WebhookSubscription{
+		id:         NewID(),
+		url:        rawURL,
+		secret:     secret,
+		eventTypes: types,
+		createdAt:  time.Now(),
+	}, nil
+}
+
+func ExistingWebhookSubscription(
+	id ID,
+	url string,
+	secret string,
+	eventTypes []AssignmentEventType,
+	createdAt time.Time,
+) *WebhookSubscription {
+	return &WebhookSubscription{
+		id:         id,
+		url:        url,
+		secret:     secret,
+		eventTypes: eventTypes,
+		createdAt:  createdAt,
+	}
+}
+
+func (s *WebhookSubscription) ID() ID {
+	return s.id
+}
+
+func (s *WebhookSubscription) URL() string {
+	return s.url
+}
+
+// Secret() returns key used to sign payloads posted to subscriber
+func (s *WebhookSubscription) Secret() string {
+	return s.secret
+}
+
+func (s *WebhookSubscription) EventTypes() []AssignmentEventType {
+	return slices.Clone(s.eventTypes)
+}
+
+func (s *WebhookSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// Accepts() reports whether events of given type are posted to subscriber
+func (s *WebhookSubscription) Accepts(eventType AssignmentEventType) bool {
+	return len(s.eventTypes) == 0 || slices.Contains(s.eventTypes, eventType)
+}
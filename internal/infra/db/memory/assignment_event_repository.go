@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type AssignmentEventRepository struct {
+	store *Store
+}
+
+func NewAssignmentEventRepository(store *Store) (*AssignmentEventRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &AssignmentEventRepository{store: store}, nil
+}
+
+func (r *AssignmentEventRepository) Append(ctx context.Context, events ...*domain.AssignmentEvent) error {
+	return r.store.update(ctx, func(st *state) error {
+		for _, event := range events {
+			st.events = append(st.events, *event)
+		}
+		return nil
+	})
+}
+
+func (r *AssignmentEventRepository) FindByPullRequestID(ctx context.Context, pullRequestID domain.ID) ([]*domain.AssignmentEvent, error) {
+	events := make([]*domain.AssignmentEvent, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, event := range st.events {
+			if event.PullRequestID != nil && *event.PullRequestID == pullRequestID {
+				events = append(events, &event)
+			}
+		}
+		return nil
+	})
+
+	slices.SortStableFunc(events, func(a, b *domain.AssignmentEvent) int {
+		return a.OccurredAt.Compare(b.OccurredAt)
+	})
+
+	return events, err
+}
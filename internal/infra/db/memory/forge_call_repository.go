@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type ForgeCallRepository struct {
+	store *Store
+}
+
+func NewForgeCallRepository(store *Store) (*ForgeCallRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &ForgeCallRepository{store: store}, nil
+}
+
+func (r *ForgeCallRepository) Create(ctx context.Context, call *domain.ForgeCall) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.pullRequests[call.PullRequestID()]; !ok {
+			return fmt.Errorf("%w: pull request with id=%s", ErrNotFound, call.PullRequestID())
+		}
+		st.forgeCalls[call.ID()] = *call
+		return nil
+	})
+}
+
+func (r *ForgeCallRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.ForgeCall, error) {
+	claimed := make([]*domain.ForgeCall, 0)
+	err := r.store.update(ctx, func(st *state) error {
+		due := make([]domain.ForgeCall, 0)
+		for _, c := range st.forgeCalls {
+			if c.Status() == domain.DeliveryPending && !c.NextAttemptAt().After(now) {
+				due = append(due, c)
+			}
+		}
+		slices.SortFunc(due, func(a, b domain.ForgeCall) int {
+			return a.NextAttemptAt().Compare(b.NextAttemptAt())
+		})
+
+		for _, c := range due[:min(limit, len(due))] {
+			leased := domain.ExistingForgeCall(
+				c.ID(),
+				c.PullRequestID(),
+				c.Action(),
+				c.ReviewerIDs(),
+				c.Status(),
+				c.Attempts(),
+				now.Add(lease),
+				c.LastError(),
+				c.CreatedAt(),
+				c.CompletedAt(),
+			)
+			st.forgeCalls[c.ID()] = *leased
+			claimed = append(claimed, leased)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *ForgeCallRepository) Update(ctx context.Context, call *domain.ForgeCall) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.forgeCalls[call.ID()]; !ok {
+			return fmt.Errorf("%w: forge call with id=%s", ErrNotFound, call.ID())
+		}
+		st.forgeCalls[call.ID()] = *call
+		return nil
+	})
+}
@@ -0,0 +1,35 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type InboundDeliveryRepository struct {
+	store *Store
+}
+
+func NewInboundDeliveryRepository(store *Store) (*InboundDeliveryRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &InboundDeliveryRepository{store: store}, nil
+}
+
+func (r *InboundDeliveryRepository) Claim(ctx context.Context, provider domain.IdentityProvider, deliveryID string, receivedAt time.Time) (bool, error) {
+	var claimed bool
+	err := r.store.update(ctx, func(st *state) error {
+		key := inboundDeliveryKey{provider: provider, deliveryID: deliveryID}
+		if _, ok := st.inboundDeliveries[key]; !ok {
+			st.inboundDeliveries[key] = receivedAt
+			claimed = true
+		}
+		return nil
+	})
+
+	return claimed, err
+}
@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type OutboxRepository struct {
+	store *Store
+}
+
+func NewOutboxRepository(store *Store) (*OutboxRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &OutboxRepository{store: store}, nil
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, events ...*domain.AssignmentEvent) error {
+	return r.store.update(ctx, func(st *state) error {
+		for _, event := range events {
+			st.outbox = append(st.outbox, event.ID)
+		}
+		return nil
+	})
+}
+
+// FindPendingForUpdate() returns pending events. Transaction already has exclusive access
+// to the store, so no additional locking is needed.
+func (r *OutboxRepository) FindPendingForUpdate(ctx context.Context, limit int) ([]*domain.AssignmentEvent, error) {
+	if r.store.transaction(ctx) == nil {
+		return nil, domain.ErrNoTransaction
+	}
+
+	events := make([]*domain.AssignmentEvent, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, id := range st.outbox {
+			if len(events) == limit {
+				break
+			}
+			idx := slices.IndexFunc(st.events, func(e domain.AssignmentEvent) bool {
+				return e.ID == id
+			})
+			if idx == -1 {
+				continue
+			}
+			event := st.events[idx]
+			events = append(events, &event)
+		}
+		return nil
+	})
+
+	return events, err
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventIDs ...domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		st.outbox = slices.DeleteFunc(st.outbox, func(id domain.ID) bool {
+			return slices.Contains(eventIDs, id)
+		})
+		return nil
+	})
+}
@@ -0,0 +1,204 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type PullRequestRepository struct {
+	store *Store
+}
+
+func NewPullRequestRepository(store *Store) (*PullRequestRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &PullRequestRepository{store: store}, nil
+}
+
+func (r *PullRequestRepository) Create(ctx context.Context, pullRequest *domain.PullRequest) error {
+	return r.CreateAndAdvanceRotation(ctx, pullRequest, nil)
+}
+
+func (r *PullRequestRepository) CreateAndAdvanceRotation(ctx context.Context, pullRequest *domain.PullRequest, advance *domain.RotationAdvance) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.pullRequests[pullRequest.ID()]; ok {
+			return fmt.Errorf("%w: pull request with id=%s", ErrAlreadyExists, pullRequest.ID())
+		}
+		if err := st.savePullRequest(pullRequest); err != nil {
+			return err
+		}
+
+		if advance != nil {
+			return st.advanceRotation(advance)
+		}
+
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) FindByID(ctx context.Context, id domain.ID) (*domain.PullRequest, error) {
+	var pullRequest *domain.PullRequest
+	err := r.store.view(ctx, func(st *state) error {
+		if record, ok := st.pullRequests[id]; ok {
+			pullRequest = record.toEntity()
+		}
+		return nil
+	})
+
+	return pullRequest, err
+}
+
+// FindByIDForUpdate() works like FindByID(). Transaction already has exclusive access
+// to the store, so no additional locking is needed.
+func (r *PullRequestRepository) FindByIDForUpdate(ctx context.Context, id domain.ID) (*domain.PullRequest, error) {
+	if r.store.transaction(ctx) == nil {
+		return nil, domain.ErrNoTransaction
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *PullRequestRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.PullRequest, error) {
+	var pullRequest *domain.PullRequest
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.pullRequests {
+			if record.key == key {
+				pullRequest = record.toEntity()
+				break
+			}
+		}
+		return nil
+	})
+
+	return pullRequest, err
+}
+
+func (r *PullRequestRepository) FindAll(ctx context.Context) ([]*domain.PullRequest, error) {
+	var pullRequests []*domain.PullRequest
+	err := r.store.view(ctx, func(st *state) error {
+		pullRequests = make([]*domain.PullRequest, 0, len(st.pullRequests))
+		for _, record := range st.pullRequests {
+			pullRequests = append(pullRequests, record.toEntity())
+		}
+		return nil
+	})
+
+	sortPullRequests(pullRequests)
+	return pullRequests, err
+}
+
+func (r *PullRequestRepository) Update(ctx context.Context, pullRequest *domain.PullRequest) error {
+	return r.UpdateAndAdvanceRotation(ctx, pullRequest, nil)
+}
+
+func (r *PullRequestRepository) UpdateAndAdvanceRotation(ctx context.Context, pullRequest *domain.PullRequest, advance *domain.RotationAdvance) error {
+	return r.store.update(ctx, func(st *state) error {
+		if err := updatePullRequest(st, pullRequest); err != nil {
+			return err
+		}
+
+		if advance != nil {
+			return st.advanceRotation(advance)
+		}
+
+		return nil
+	})
+}
+
+// updatePullRequest() saves existing pull request with its reviewers into given state
+// and increments its version. Fails with domain.ErrVersionConflict if the stored
+// version differs from the version pull request was loaded with.
+func updatePullRequest(st *state, pullRequest *domain.PullRequest) error {
+	stored, ok := st.pullRequests[pullRequest.ID()]
+	if !ok {
+		return fmt.Errorf("%w: pull request with id=%s", ErrNotFound, pullRequest.ID())
+	}
+	if stored.version != pullRequest.Version() {
+		return fmt.Errorf("%w: pull request with id=%s", domain.ErrVersionConflict, pullRequest.ID())
+	}
+
+	if err := st.savePullRequest(pullRequest); err != nil {
+		return err
+	}
+
+	record := st.pullRequests[pullRequest.ID()]
+	record.version++
+	st.pullRequests[pullRequest.ID()] = record
+	pullRequest.SetVersion(record.version)
+
+	return nil
+}
+
+func (r *PullRequestRepository) DeleteByID(ctx context.Context, id domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		st.deletePullRequest(id)
+		return nil
+	})
+}
+
+func (r *PullRequestRepository) FindPullRequestsByReviewer(ctx context.Context, userID domain.ID) ([]*domain.PullRequest, error) {
+	pullRequests := make([]*domain.PullRequest, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.pullRequests {
+			if slices.Contains(record.reviewerIDs(), userID) {
+				pullRequests = append(pullRequests, record.toEntity())
+			}
+		}
+		return nil
+	})
+
+	sortPullRequests(pullRequests)
+	return pullRequests, err
+}
+
+func (r *PullRequestRepository) CountOpenReviewsByTeamID(ctx context.Context, teamID domain.ID) (map[domain.ID]int, error) {
+	counts := make(map[domain.ID]int)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, userID := range st.teams[teamID].userIDs {
+			counts[userID] = 0
+		}
+		for _, record := range st.pullRequests {
+			if record.status != domain.PROpen {
+				continue
+			}
+			for _, reviewerID := range record.reviewerIDs() {
+				if _, ok := counts[reviewerID]; ok {
+					counts[reviewerID]++
+				}
+			}
+		}
+		return nil
+	})
+
+	return counts, err
+}
+
+func (r *PullRequestRepository) CountOpenPullRequestsByTeamID(ctx context.Context, teamID domain.ID) (int, error) {
+	var count int
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.pullRequests {
+			if record.status == domain.PROpen && record.teamID != nil && *record.teamID == teamID {
+				count++
+			}
+		}
+		return nil
+	})
+
+	return count, err
+}
+
+func sortPullRequests(pullRequests []*domain.PullRequest) {
+	slices.SortFunc(pullRequests, func(a, b *domain.PullRequest) int {
+		if c := a.CreatedAt().Compare(b.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key().Value(), b.Key().Value())
+	})
+}
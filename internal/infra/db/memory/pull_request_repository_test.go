@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+func newTestPullRequest(t *testing.T, n int, authorID domain.ID, reviewerIDs ...domain.ID) *domain.PullRequest {
+	t.Helper()
+
+	pr, err := domain.NewPullRequest(
+		domain.ExistingExternalKey(fmt.Sprintf("pr-%d", n)),
+		domain.ExistingPRTitle("title"),
+		authorID,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range reviewerIDs {
+		if err := pr.AssignReviewer(id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return pr
+}
+
+func TestCountOpenReviewsByTeamID(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	team := newTestTeam(t, store, testUser(1, true), testUser(2, true), testUser(3, false))
+	// reviewer outside the team is not counted
+	other := newTestTeam(t, store, testUser(4, true))
+
+	repo, err := NewPullRequestRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	open := newTestPullRequest(t, 1, testID(4), testID(1), testID(2))
+	merged := newTestPullRequest(t, 2, testID(4), testID(1))
+	if err := merged.MarkAsMerged(); err != nil {
+		t.Fatal(err)
+	}
+	closed := newTestPullRequest(t, 3, testID(4), testID(2))
+	if err := closed.Close(); err != nil {
+		t.Fatal(err)
+	}
+	foreign := newTestPullRequest(t, 4, testID(1), testID(4), testID(1))
+	for _, pr := range []*domain.PullRequest{open, merged, closed, foreign} {
+		if err := repo.Create(ctx, pr); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	got, err := repo.CountOpenReviewsByTeamID(ctx, team.ID())
+	if err != nil {
+		t.Fatalf("CountOpenReviewsByTeamID() error = %v", err)
+	}
+	// every member is present, including inactive ones and those without reviews
+	want := map[domain.ID]int{testID(1): 2, testID(2): 1, testID(3): 0}
+	if !maps.Equal(got, want) {
+		t.Errorf("CountOpenReviewsByTeamID() = %v, want %v", got, want)
+	}
+
+	got, err = repo.CountOpenReviewsByTeamID(ctx, other.ID())
+	if err != nil {
+		t.Fatalf("CountOpenReviewsByTeamID() error = %v", err)
+	}
+	want = map[domain.ID]int{testID(4): 1}
+	if !maps.Equal(got, want) {
+		t.Errorf("CountOpenReviewsByTeamID() = %v, want %v", got, want)
+	}
+}
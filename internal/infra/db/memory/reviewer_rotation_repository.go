@@ -0,0 +1,33 @@
+package memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type ReviewerRotationRepository struct {
+	store *Store
+}
+
+func NewReviewerRotationRepository(store *Store) (*ReviewerRotationRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &ReviewerRotationRepository{store: store}, nil
+}
+
+func (r *ReviewerRotationRepository) FindRotationCursor(ctx context.Context, teamID domain.ID) (*domain.ID, error) {
+	var cursor *domain.ID
+	err := r.store.view(ctx, func(st *state) error {
+		if lastReviewerID := st.rotations[teamID]; lastReviewerID != nil {
+			id := *lastReviewerID
+			cursor = &id
+		}
+		return nil
+	})
+
+	return cursor, err
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type StatsRepository struct {
+	store *Store
+}
+
+func NewStatsRepository(store *Store) (*StatsRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &StatsRepository{store: store}, nil
+}
+
+func (r *StatsRepository) FindUserReviewStats(ctx context.Context, teamID *domain.ID) ([]*domain.UserReviewStats, error) {
+	stats := make([]*domain.UserReviewStats, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		byUser := make(map[domain.ID]*domain.UserReviewStats)
+		for _, user := range st.users {
+			if teamID != nil && !slices.Contains(st.teams[*teamID].userIDs, user.id) {
+				continue
+			}
+			userStats := &domain.UserReviewStats{
+				UserID:   user.id,
+				UserKey:  user.key,
+				UserName: user.name,
+			}
+			byUser[user.id] = userStats
+			stats = append(stats, userStats)
+		}
+
+		for _, pr := range st.pullRequests {
+			if !st.belongsToTeam(pr, teamID) {
+				continue
+			}
+			for _, reviewerID := range pr.reviewerIDs() {
+				userStats, ok := byUser[reviewerID]
+				if !ok {
+					continue
+				}
+				userStats.TotalAssignments++
+				switch pr.status {
+				case domain.PROpen:
+					userStats.OpenAssignments++
+				case domain.PRMerged:
+					userStats.MergedReviews++
+				}
+			}
+		}
+
+		for _, record := range st.reassignments {
+			if !st.belongsToTeam(st.pullRequests[record.pullRequestID], teamID) {
+				continue
+			}
+			if userStats, ok := byUser[record.reassignment.OldReviewerID]; ok {
+				userStats.TotalAssignments++
+				userStats.ReassignedAway++
+			}
+		}
+
+		return nil
+	})
+
+	slices.SortFunc(stats, func(a, b *domain.UserReviewStats) int {
+		return strings.Compare(a.UserKey.Value(), b.UserKey.Value())
+	})
+
+	return stats, err
+}
+
+func (r *StatsRepository) FindPullRequestReviewStats(ctx context.Context, teamID *domain.ID) ([]*domain.PullRequestReviewStats, error) {
+	stats := make([]*domain.PullRequestReviewStats, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		byPullRequest := make(map[domain.ID]*domain.PullRequestReviewStats)
+		for _, pr := range st.pullRequests {
+			if !st.belongsToTeam(pr, teamID) {
+				continue
+			}
+			var mergedAt *time.Time
+			if pr.mergedAt != nil {
+				t := *pr.mergedAt
+				mergedAt = &t
+			}
+
+			prStats := &domain.PullRequestReviewStats{
+				PullRequestID:     pr.id,
+				PullRequestKey:    pr.key,
+				Title:             pr.title,
+				Status:            pr.status,
+				CreatedAt:         pr.createdAt,
+				MergedAt:          mergedAt,
+				ReviewersAssigned: len(pr.reviewerIDs()),
+			}
+			byPullRequest[pr.id] = prStats
+			stats = append(stats, prStats)
+		}
+
+		for _, record := range st.reassignments {
+			if prStats, ok := byPullRequest[record.pullRequestID]; ok {
+				prStats.Reassignments++
+			}
+		}
+
+		return nil
+	})
+
+	slices.SortFunc(stats, func(a, b *domain.PullRequestReviewStats) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.PullRequestID.String(), b.PullRequestID.String())
+	})
+
+	return stats, err
+}
@@ -0,0 +1,514 @@
+// Package memory provides repositories keeping data in process memory.
+// It is intended for tests and local runs without database.
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+var (
+	ErrAlreadyExists = errors.New("entity already exists")
+	ErrNotFound      = errors.New("entity not found")
+)
+
+type userRecord struct {
+	id     domain.ID
+	key    domain.ExternalKey
+	name   domain.UserName
+	active bool
+}
+
+type teamRecord struct {
+	id       domain.ID
+	key      domain.ExternalKey
+	name     domain.TeamName
+	parentID *domain.ID
+	settings domain.TeamSettings
+	version  int64
+	// ordered by time of joining the team
+	userIDs []domain.ID
+}
+
+type pullRequestRecord struct {
+	id           domain.ID
+	key          domain.ExternalKey
+	title        domain.PRTitle
+	authorID     domain.ID
+	teamID       *domain.ID
+	createdAt    time.Time
+	status       domain.PRStatus
+	mergedAt     *time.Time
+	reviewers    []domain.Reviewer
+	maxReviewers int
+	version      int64
+}
+
+func (r pullRequestRecord) reviewerIDs() []domain.ID {
+	ids := make([]domain.ID, len(r.reviewers))
+	for i, reviewer := range r.reviewers {
+		ids[i] = reviewer.UserID
+	}
+	return ids
+}
+
+type identityKey struct {
+	provider domain.IdentityProvider
+	login    string
+}
+
+type inboundDeliveryKey struct {
+	provider   domain.IdentityProvider
+	deliveryID string
+}
+
+type reassignmentRecord struct {
+	pullRequestID domain.ID
+	reassignment  domain.ReviewerReassignment
+}
+
+// state is a snapshot of all stored data.
+// Records are never modified in place, so snapshots can share them.
+type state struct {
+	users         map[domain.ID]userRecord
+	teams         map[domain.ID]teamRecord
+	primaryTeams  map[domain.ID]domain.ID // by user, only users with teams are present
+	pullRequests  map[domain.ID]pullRequestRecord
+	rotations     map[domain.ID]*domain.ID
+	reassignments []reassignmentRecord
+	identities    map[identityKey]domain.ID
+	// received time by delivery
+	inboundDeliveries map[inboundDeliveryKey]time.Time
+	events            []domain.AssignmentEvent
+	// ids of events not yet published, in order of enqueueing
+	outbox        []domain.ID
+	subscriptions map[domain.ID]*domain.WebhookSubscription
+	deliveries    map[domain.ID]domain.WebhookDelivery
+	forgeCalls    map[domain.ID]domain.ForgeCall
+}
+
+func (st *state) clone() *state {
+	return &state{
+		users:             maps.Clone(st.users),
+		teams:             maps.Clone(st.teams),
+		primaryTeams:      maps.Clone(st.primaryTeams),
+		pullRequests:      maps.Clone(st.pullRequests),
+		rotations:         maps.Clone(st.rotations),
+		reassignments:     slices.Clone(st.reassignments),
+		identities:        maps.Clone(st.identities),
+		inboundDeliveries: maps.Clone(st.inboundDeliveries),
+		events:            slices.Clone(st.events),
+		outbox:            slices.Clone(st.outbox),
+		subscriptions:     maps.Clone(st.subscriptions),
+		deliveries:        maps.Clone(st.deliveries),
+		forgeCalls:        maps.Clone(st.forgeCalls),
+	}
+}
+
+// Store is shared by memory repositories. Every write is applied to a copy
+// of the current state, which replaces it only if the whole operation succeeds,
+// so multi-entity operations are atomic like database transactions.
+type Store struct {
+	mu    sync.RWMutex
+	state *state
+}
+
+func NewStore() *Store {
+	return &Store{
+		state: &state{
+			users:             make(map[domain.ID]userRecord),
+			teams:             make(map[domain.ID]teamRecord),
+			primaryTeams:      make(map[domain.ID]domain.ID),
+			pullRequests:      make(map[domain.ID]pullRequestRecord),
+			rotations:         make(map[domain.ID]*domain.ID),
+			identities:        make(map[identityKey]domain.ID),
+			inboundDeliveries: make(map[inboundDeliveryKey]time.Time),
+			subscriptions:     make(map[domain.ID]*domain.WebhookSubscription),
+			deliveries:        make(map[domain.ID]domain.WebhookDelivery),
+			forgeCalls:        make(map[domain.ID]domain.ForgeCall),
+		},
+	}
+}
+
+// view() runs read-only fn over current state
+func (s *Store) view(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tx := s.transaction(ctx); tx != nil {
+		return fn(tx.state)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return fn(s.state)
+}
+
+// update() runs fn over a copy of current state and commits the copy if fn succeeds
+func (s *Store) update(ctx context.Context, fn func(st *state) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if tx := s.transaction(ctx); tx != nil {
+		next := tx.state.clone()
+		if err := fn(next); err != nil {
+			return err
+		}
+		tx.state = next
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := s.state.clone()
+	if err := fn(next); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.state = next
+	return nil
+}
+
+func newUserRecord(user *domain.User) userRecord {
+	return userRecord{
+		id:     user.ID(),
+		key:    user.Key(),
+		name:   user.Name(),
+		active: user.Active(),
+	}
+}
+
+func (r userRecord) toEntity() *domain.User {
+	return domain.ExistingUser(r.id, r.key, r.name, r.active)
+}
+
+func newTeamRecord(team *domain.Team) teamRecord {
+	return teamRecord{
+		id:       team.ID(),
+		key:      team.Key(),
+		name:     team.Name(),
+		parentID: team.ParentID(),
+		settings: team.Settings(),
+		version:  team.Version(),
+		userIDs:  sortedIDs(team.UserIDs()),
+	}
+}
+
+// sortedIDs() returns ids in the order of postgres ORDER BY on uuid column, so members
+// of teams are rotated by reviewer selectors in the same order with both storages
+func sortedIDs(ids []domain.ID) []domain.ID {
+	sorted := slices.Clone(ids)
+	slices.SortFunc(sorted, func(a, b domain.ID) int {
+		return strings.Compare(a.String(), b.String())
+	})
+	return sorted
+}
+
+func (r teamRecord) toEntity() *domain.Team {
+	return domain.ExistingTeam(r.id, r.key, r.name, r.parentID, r.userIDs, r.settings, r.version)
+}
+
+func newPullRequestRecord(pullRequest *domain.PullRequest) pullRequestRecord {
+	return pullRequestRecord{
+		id:           pullRequest.ID(),
+		key:          pullRequest.Key(),
+		title:        pullRequest.Title(),
+		authorID:     pullRequest.AuthorID(),
+		teamID:       pullRequest.TeamID(),
+		createdAt:    pullRequest.CreatedAt(),
+		status:       pullRequest.Status(),
+		mergedAt:     pullRequest.MergedAt(),
+		reviewers:    pullRequest.Reviewers(),
+		maxReviewers: pullRequest.MaxReviewers(),
+		version:      pullRequest.Version(),
+	}
+}
+
+func (r pullRequestRecord) toEntity() *domain.PullRequest {
+	var mergedAt *time.Time
+	if r.mergedAt != nil {
+		t := *r.mergedAt
+		mergedAt = &t
+	}
+
+	return domain.ExistingPullRequest(
+		r.id,
+		r.key,
+		r.title,
+		r.authorID,
+		r.teamID,
+		r.createdAt,
+		r.status,
+		mergedAt,
+		r.reviewers,
+		r.maxReviewers,
+		r.version,
+	)
+}
+
+// saveUser() inserts or replaces user, checking uniqueness of its key and name
+func (st *state) saveUser(user *domain.User) error {
+	for _, u := range st.users {
+		if u.id == user.ID() {
+			continue
+		}
+		if u.key == user.Key() {
+			return fmt.Errorf("%w: user with key=%s", ErrAlreadyExists, user.Key())
+		}
+		if u.name == user.Name() {
+			return fmt.Errorf("%w: user with name=%s", ErrAlreadyExists, user.Name())
+		}
+	}
+
+	st.users[user.ID()] = newUserRecord(user)
+	return nil
+}
+
+// saveTeam() inserts or replaces team, checking uniqueness of its key and name
+// and that members exist. The team becomes primary for new members without other teams.
+func (st *state) saveTeam(team *domain.Team) error {
+	for _, t := range st.teams {
+		if t.id == team.ID() {
+			continue
+		}
+		if t.key == team.Key() {
+			return fmt.Errorf("%w: team with key=%s", ErrAlreadyExists, team.Key())
+		}
+		if t.name == team.Name() {
+			return fmt.Errorf("%w: team with name=%s", ErrAlreadyExists, team.Name())
+		}
+	}
+
+	for _, userID := range team.UserIDs() {
+		if _, ok := st.users[userID]; !ok {
+			return fmt.Errorf("%w: user with id=%s", ErrNotFound, userID)
+		}
+	}
+	if parentID := team.ParentID(); parentID != nil {
+		if _, ok := st.teams[*parentID]; !ok {
+			return fmt.Errorf("%w: parent team with id=%s", ErrNotFound, *parentID)
+		}
+	}
+
+	previous := st.teams[team.ID()].userIDs
+	st.teams[team.ID()] = newTeamRecord(team)
+
+	for _, userID := range team.UserIDs() {
+		if _, ok := st.primaryTeams[userID]; !ok {
+			st.primaryTeams[userID] = team.ID()
+		}
+	}
+	for _, userID := range previous {
+		if !slices.Contains(team.UserIDs(), userID) {
+			st.restorePrimaryTeam(userID)
+		}
+	}
+
+	return nil
+}
+
+// savePullRequest() inserts or replaces pull request, checking uniqueness of its key,
+// that author and reviewers exist, and records pending reassignments
+func (st *state) savePullRequest(pullRequest *domain.PullRequest) error {
+	for _, pr := range st.pullRequests {
+		if pr.id != pullRequest.ID() && pr.key == pullRequest.Key() {
+			return fmt.Errorf("%w: pull request with key=%s", ErrAlreadyExists, pullRequest.Key())
+		}
+	}
+
+	if _, ok := st.users[pullRequest.AuthorID()]; !ok {
+		return fmt.Errorf("%w: author with id=%s", ErrNotFound, pullRequest.AuthorID())
+	}
+	for _, reviewerID := range pullRequest.ReviewerIDs() {
+		if _, ok := st.users[reviewerID]; !ok {
+			return fmt.Errorf("%w: reviewer with id=%s", ErrNotFound, reviewerID)
+		}
+	}
+
+	st.pullRequests[pullRequest.ID()] = newPullRequestRecord(pullRequest)
+
+	for _, reassignment := range pullRequest.PendingReassignments() {
+		st.reassignments = append(st.reassignments, reassignmentRecord{
+			pullRequestID: pullRequest.ID(),
+			reassignment:  reassignment,
+		})
+	}
+
+	return nil
+}
+
+// advanceRotation() moves team rotation cursor if it still points to advance.From
+func (st *state) advanceRotation(advance *domain.RotationAdvance) error {
+	if _, ok := st.teams[advance.TeamID]; !ok {
+		return fmt.Errorf("%w: team with id=%s", ErrNotFound, advance.TeamID)
+	}
+
+	current := st.rotations[advance.TeamID]
+	switch {
+	case current == nil && advance.From == nil:
+	case current != nil && advance.From != nil && *current == *advance.From:
+	default:
+		return domain.ErrRotationConflict
+	}
+
+	to := advance.To
+	st.rotations[advance.TeamID] = &to
+	return nil
+}
+
+// teamIDsForUser() returns teams of the user, primary team goes first
+func (st *state) teamIDsForUser(userID domain.ID) []domain.ID {
+	teams := make([]teamRecord, 0)
+	for _, t := range st.teams {
+		if slices.Contains(t.userIDs, userID) {
+			teams = append(teams, t)
+		}
+	}
+
+	primaryID := st.primaryTeams[userID]
+	slices.SortFunc(teams, func(a, b teamRecord) int {
+		switch {
+		case a.id == primaryID:
+			return -1
+		case b.id == primaryID:
+			return 1
+		default:
+			return strings.Compare(a.name.Value(), b.name.Value())
+		}
+	})
+
+	ids := make([]domain.ID, len(teams))
+	for i, t := range teams {
+		ids[i] = t.id
+	}
+	return ids
+}
+
+// pullRequestTeamID() returns team of pull request or primary team of its author,
+// if team is not set. Returns false if there is no such team.
+func (st *state) pullRequestTeamID(pr pullRequestRecord) (domain.ID, bool) {
+	if pr.teamID != nil {
+		return *pr.teamID, true
+	}
+
+	teamID, ok := st.primaryTeams[pr.authorID]
+	return teamID, ok
+}
+
+// belongsToTeam() reports whether pull request belongs to the team, any pull request
+// belongs to nil team. Pull request without team belongs to primary team of the author.
+func (st *state) belongsToTeam(pr pullRequestRecord, teamID *domain.ID) bool {
+	if teamID == nil {
+		return true
+	}
+
+	prTeamID, ok := st.pullRequestTeamID(pr)
+	return ok && prTeamID == *teamID
+}
+
+// restorePrimaryTeam() makes the first by name of remaining teams primary,
+// if user is not a member of primary team anymore
+func (st *state) restorePrimaryTeam(userID domain.ID) {
+	if primary, ok := st.teams[st.primaryTeams[userID]]; ok && slices.Contains(primary.userIDs, userID) {
+		return
+	}
+	delete(st.primaryTeams, userID)
+
+	if teamIDs := st.teamIDsForUser(userID); len(teamIDs) > 0 {
+		st.primaryTeams[userID] = teamIDs[0]
+	}
+}
+
+func (st *state) deleteUser(userID domain.ID) {
+	delete(st.users, userID)
+	delete(st.primaryTeams, userID)
+
+	for id, t := range st.teams {
+		if idx := slices.Index(t.userIDs, userID); idx != -1 {
+			t.userIDs = slices.Delete(slices.Clone(t.userIDs), idx, idx+1)
+			st.teams[id] = t
+		}
+	}
+
+	for id, pr := range st.pullRequests {
+		if pr.authorID == userID {
+			st.deletePullRequest(id)
+			continue
+		}
+		if idx := slices.Index(pr.reviewerIDs(), userID); idx != -1 {
+			pr.reviewers = slices.Delete(slices.Clone(pr.reviewers), idx, idx+1)
+			st.pullRequests[id] = pr
+		}
+	}
+
+	st.reassignments = slices.DeleteFunc(st.reassignments, func(r reassignmentRecord) bool {
+		return r.reassignment.OldReviewerID == userID || r.reassignment.NewReviewerID == userID
+	})
+
+	for teamID, cursor := range st.rotations {
+		if cursor != nil && *cursor == userID {
+			st.rotations[teamID] = nil
+		}
+	}
+
+	maps.DeleteFunc(st.identities, func(_ identityKey, id domain.ID) bool {
+		return id == userID
+	})
+}
+
+func (st *state) deleteTeam(teamID domain.ID) {
+	members := st.teams[teamID].userIDs
+	delete(st.teams, teamID)
+	delete(st.rotations, teamID)
+
+	for _, userID := range members {
+		st.restorePrimaryTeam(userID)
+	}
+	for id, team := range st.teams {
+		if team.parentID != nil && *team.parentID == teamID {
+			team.parentID = nil
+			st.teams[id] = team
+		}
+	}
+	for id, pr := range st.pullRequests {
+		if pr.teamID != nil && *pr.teamID == teamID {
+			pr.teamID = nil
+			st.pullRequests[id] = pr
+		}
+		if slices.ContainsFunc(pr.reviewers, func(r domain.Reviewer) bool { return drawnFrom(r, teamID) }) {
+			pr.reviewers = slices.Clone(pr.reviewers)
+			for i := range pr.reviewers {
+				if drawnFrom(pr.reviewers[i], teamID) {
+					pr.reviewers[i].FallbackTeamID = nil
+				}
+			}
+			st.pullRequests[id] = pr
+		}
+	}
+}
+
+// drawnFrom() reports whether reviewer was drawn from the ancestor team with given id
+func drawnFrom(reviewer domain.Reviewer, teamID domain.ID) bool {
+	return reviewer.FallbackTeamID != nil && *reviewer.FallbackTeamID == teamID
+}
+
+func (st *state) deletePullRequest(pullRequestID domain.ID) {
+	delete(st.pullRequests, pullRequestID)
+
+	st.reassignments = slices.DeleteFunc(st.reassignments, func(r reassignmentRecord) bool {
+		return r.pullRequestID == pullRequestID
+	})
+}
@@ -0,0 +1,343 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type TeamRepository struct {
+	store *Store
+}
+
+func NewTeamRepository(store *Store) (*TeamRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &TeamRepository{store: store}, nil
+}
+
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	return r.store.update(ctx, func(st *state) error {
+		return createTeam(st, team)
+	})
+}
+
+func createTeam(st *state, team *domain.Team) error {
+	if _, ok := st.teams[team.ID()]; ok {
+		return fmt.Errorf("%w: team with id=%s", ErrAlreadyExists, team.ID())
+	}
+
+	return st.saveTeam(team)
+}
+
+func (r *TeamRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Team, error) {
+	var team *domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		if record, ok := st.teams[id]; ok {
+			team = record.toEntity()
+		}
+		return nil
+	})
+
+	return team, err
+}
+
+func (r *TeamRepository) FindAll(ctx context.Context) ([]*domain.Team, error) {
+	var teams []*domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		teams = make([]*domain.Team, 0, len(st.teams))
+		for _, record := range st.teams {
+			teams = append(teams, record.toEntity())
+		}
+		return nil
+	})
+
+	slices.SortFunc(teams, func(a, b *domain.Team) int {
+		return strings.Compare(a.Name().Value(), b.Name().Value())
+	})
+
+	return teams, err
+}
+
+func (r *TeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	err := r.store.update(ctx, func(st *state) error {
+		return updateTeam(st, team)
+	})
+	if err != nil {
+		return err
+	}
+
+	team.SetVersion(team.Version() + 1)
+	return nil
+}
+
+// updateTeam() saves team if stored version matches the team one and increments stored version.
+// Version of entity is not changed.
+func updateTeam(st *state, team *domain.Team) error {
+	stored, ok := st.teams[team.ID()]
+	if !ok {
+		return fmt.Errorf("%w: team with id=%s", ErrNotFound, team.ID())
+	}
+	if stored.version != team.Version() {
+		return fmt.Errorf("%w: team with id=%s", domain.ErrVersionConflict, team.ID())
+	}
+
+	if err := st.saveTeam(team); err != nil {
+		return err
+	}
+
+	record := st.teams[team.ID()]
+	record.version = stored.version + 1
+	st.teams[team.ID()] = record
+
+	return nil
+}
+
+func (r *TeamRepository) DeleteByID(ctx context.Context, id domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		st.deleteTeam(id)
+		return nil
+	})
+}
+
+func (r *TeamRepository) FindByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	var team *domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.teams {
+			if record.name.Value() == teamName {
+				team = record.toEntity()
+				break
+			}
+		}
+		return nil
+	})
+
+	return team, err
+}
+
+func (r *TeamRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.Team, error) {
+	var team *domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.teams {
+			if record.key == key {
+				team = record.toEntity()
+				break
+			}
+		}
+		return nil
+	})
+
+	return team, err
+}
+
+func (r *TeamRepository) CreateTeamAndModifyUsers(ctx context.Context, team *domain.Team, users []*domain.User) error {
+	return r.store.update(ctx, func(st *state) error {
+		for _, user := range users {
+			if err := st.saveUser(user); err != nil {
+				return err
+			}
+		}
+
+		return createTeam(st, team)
+	})
+}
+
+func (r *TeamRepository) UpdateTeamAndModifyUsers(ctx context.Context, team *domain.Team, users []*domain.User) error {
+	err := r.store.update(ctx, func(st *state) error {
+		for _, user := range users {
+			if err := st.saveUser(user); err != nil {
+				return err
+			}
+		}
+
+		return updateTeam(st, team)
+	})
+	if err != nil {
+		return err
+	}
+
+	team.SetVersion(team.Version() + 1)
+	return nil
+}
+
+func (r *TeamRepository) FindTeamByTeammateID(ctx context.Context, userID domain.ID) (*domain.Team, error) {
+	var team *domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		if teamID, ok := st.primaryTeams[userID]; ok {
+			team = st.teams[teamID].toEntity()
+		}
+		return nil
+	})
+
+	return team, err
+}
+
+func (r *TeamRepository) FindTeamsByTeammateID(ctx context.Context, userID domain.ID) ([]*domain.Team, error) {
+	var teams []*domain.Team
+	err := r.store.view(ctx, func(st *state) error {
+		teamIDs := st.teamIDsForUser(userID)
+		teams = make([]*domain.Team, len(teamIDs))
+		for i, teamID := range teamIDs {
+			teams[i] = st.teams[teamID].toEntity()
+		}
+		return nil
+	})
+
+	return teams, err
+}
+
+func (r *TeamRepository) SetPrimaryTeam(ctx context.Context, userID domain.ID, teamID domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		team, ok := st.teams[teamID]
+		if !ok || !slices.Contains(team.userIDs, userID) {
+			return fmt.Errorf("%w: user with id=%s, team with id=%s", domain.ErrNotTeamMember, userID, teamID)
+		}
+
+		st.primaryTeams[userID] = teamID
+		return nil
+	})
+}
+
+func (r *TeamRepository) FindActiveUsersByTeamID(ctx context.Context, teamID domain.ID) ([]*domain.User, error) {
+	users := make([]*domain.User, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, userID := range st.teams[teamID].userIDs {
+			if record, ok := st.users[userID]; ok && record.active {
+				users = append(users, record.toEntity())
+			}
+		}
+		return nil
+	})
+
+	return users, err
+}
+
+func (r *TeamRepository) FindAncestors(ctx context.Context, teamID domain.ID) ([]*domain.Team, error) {
+	ancestors := make([]*domain.Team, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		// visited guards against cycles, though SetParent() of team rejects them
+		visited := map[domain.ID]bool{teamID: true}
+		parentID := st.teams[teamID].parentID
+		for parentID != nil && !visited[*parentID] {
+			parent, ok := st.teams[*parentID]
+			if !ok {
+				break
+			}
+			visited[parent.id] = true
+			ancestors = append(ancestors, parent.toEntity())
+			parentID = parent.parentID
+		}
+		return nil
+	})
+
+	return ancestors, err
+}
+
+func (r *TeamRepository) DeactivateUsersAndReassignReviews(
+	ctx context.Context,
+	users []*domain.User,
+	pullRequests []*domain.PullRequest,
+	advances []*domain.RotationAdvance,
+) error {
+	return r.store.update(ctx, func(st *state) error {
+		for _, user := range users {
+			if _, ok := st.users[user.ID()]; !ok {
+				return fmt.Errorf("%w: user with id=%s", ErrNotFound, user.ID())
+			}
+			if err := st.saveUser(user); err != nil {
+				return err
+			}
+		}
+
+		for _, pullRequest := range pullRequests {
+			if err := updatePullRequest(st, pullRequest); err != nil {
+				return err
+			}
+		}
+
+		for _, advance := range advances {
+			if err := st.advanceRotation(advance); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *TeamRepository) DeleteAndReassignReviews(
+	ctx context.Context,
+	team *domain.Team,
+	users []*domain.User,
+	pullRequests []*domain.PullRequest,
+	advances []*domain.RotationAdvance,
+) error {
+	return r.store.update(ctx, func(st *state) error {
+		stored, ok := st.teams[team.ID()]
+		if !ok || stored.version != team.Version() {
+			return fmt.Errorf("%w: team with id=%s", domain.ErrVersionConflict, team.ID())
+		}
+		st.deleteTeam(team.ID())
+
+		for _, user := range users {
+			if err := st.saveUser(user); err != nil {
+				return err
+			}
+		}
+
+		for _, pullRequest := range pullRequests {
+			if err := updatePullRequest(st, pullRequest); err != nil {
+				return err
+			}
+		}
+
+		for _, advance := range advances {
+			if err := st.advanceRotation(advance); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *TeamRepository) UpdateMembershipAndReassignReviews(
+	ctx context.Context,
+	teams []*domain.Team,
+	pullRequests []*domain.PullRequest,
+	advance *domain.RotationAdvance,
+) error {
+	err := r.store.update(ctx, func(st *state) error {
+		for _, team := range teams {
+			if err := updateTeam(st, team); err != nil {
+				return err
+			}
+		}
+
+		for _, pullRequest := range pullRequests {
+			if err := updatePullRequest(st, pullRequest); err != nil {
+				return err
+			}
+		}
+
+		if advance != nil {
+			return st.advanceRotation(advance)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		team.SetVersion(team.Version() + 1)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	"github.com/google/uuid"
+)
+
+// testID() returns id, which is ordered by n like postgres orders uuid values
+func testID(n int) domain.ID {
+	return domain.ExistingID(uuid.MustParse(fmt.Sprintf("00000000-0000-0000-0000-%012d", n)))
+}
+
+func testUser(n int, active bool) *domain.User {
+	return domain.ExistingUser(
+		testID(n),
+		domain.ExistingExternalKey(fmt.Sprintf("u%d", n)),
+		domain.ExistingUserName(fmt.Sprintf("user %d", n)),
+		active,
+	)
+}
+
+// newTestTeam() stores team with users joined in given order
+func newTestTeam(t *testing.T, store *Store, users ...*domain.User) *domain.Team {
+	t.Helper()
+
+	userIDs := make([]domain.ID, len(users))
+	for i, u := range users {
+		userIDs[i] = u.ID()
+	}
+	id := domain.NewID()
+	team := domain.ExistingTeam(
+		id,
+		domain.ExistingExternalKey(id.String()),
+		domain.ExistingTeamName(id.String()),
+		nil,
+		userIDs,
+		domain.DefaultTeamSettings(),
+		domain.InitialVersion,
+	)
+
+	repo, err := NewTeamRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateTeamAndModifyUsers(context.Background(), team, users); err != nil {
+		t.Fatalf("CreateTeamAndModifyUsers() error = %v", err)
+	}
+	return team
+}
+
+func userIDs(users []*domain.User) []domain.ID {
+	ids := make([]domain.ID, len(users))
+	for i, u := range users {
+		ids[i] = u.ID()
+	}
+	return ids
+}
+
+func TestTeamRepositoryOrdersMembersByID(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	team := newTestTeam(t, store, testUser(3, true), testUser(4, false), testUser(1, true))
+	repo, err := NewTeamRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// member joined later goes in order of id too
+	if err := store.update(ctx, func(st *state) error { return st.saveUser(testUser(2, true)) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := team.AddUser(testID(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Update(ctx, team); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	found, err := repo.FindByID(ctx, team.ID())
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	want := []domain.ID{testID(1), testID(2), testID(3), testID(4)}
+	if !slices.Equal(found.UserIDs(), want) {
+		t.Errorf("FindByID() members = %v, want %v", found.UserIDs(), want)
+	}
+
+	active, err := repo.FindActiveUsersByTeamID(ctx, team.ID())
+	if err != nil {
+		t.Fatalf("FindActiveUsersByTeamID() error = %v", err)
+	}
+	want = []domain.ID{testID(1), testID(2), testID(3)}
+	if got := userIDs(active); !slices.Equal(got, want) {
+		t.Errorf("FindActiveUsersByTeamID() = %v, want %v", got, want)
+	}
+}
+
+func TestRoundRobinRotatesMembersInOrderOfID(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	team := newTestTeam(t, store, testUser(3, true), testUser(4, false), testUser(1, true), testUser(2, true))
+
+	teamRepo, err := NewTeamRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotationRepo, err := NewReviewerRotationRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prRepo, err := NewPullRequestRepository(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	selector, err := domain.NewRoundRobinReviewerSelector(rotationRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rounds := [][]domain.ID{
+		{testID(1), testID(2)},
+		{testID(3), testID(1)},
+		{testID(2), testID(3)},
+	}
+	for i, want := range rounds {
+		stored, err := teamRepo.FindByID(ctx, team.ID())
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		candidates, err := teamRepo.FindActiveUsersByTeamID(ctx, team.ID())
+		if err != nil {
+			t.Fatalf("FindActiveUsersByTeamID() error = %v", err)
+		}
+
+		selection, err := selector.SelectReviewers(ctx, stored, candidates, 2)
+		if err != nil {
+			t.Fatalf("SelectReviewers() error = %v", err)
+		}
+		if got := userIDs(selection.Reviewers); !slices.Equal(got, want) {
+			t.Fatalf("round %d: SelectReviewers() = %v, want %v", i, got, want)
+		}
+
+		pr, err := domain.NewPullRequest(
+			domain.ExistingExternalKey(fmt.Sprintf("pr-%d", i)),
+			domain.ExistingPRTitle("title"),
+			testID(4),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := prRepo.CreateAndAdvanceRotation(ctx, pr, selection.Rotation); err != nil {
+			t.Fatalf("CreateAndAdvanceRotation() error = %v", err)
+		}
+	}
+}
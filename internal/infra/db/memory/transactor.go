@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"errors"
+)
+
+type txKey struct{}
+
+// transaction holds uncommitted state of the store
+type transaction struct {
+	store *Store
+	state *state
+}
+
+// Transactor runs functions with exclusive access to the store.
+// Changes become visible to others only if function succeeds.
+type Transactor struct {
+	store *Store
+}
+
+func NewTransactor(store *Store) (*Transactor, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &Transactor{store: store}, nil
+}
+
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if t.store.transaction(ctx) != nil {
+		return fn(ctx)
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	t.store.mu.Lock()
+	defer t.store.mu.Unlock()
+
+	tx := &transaction{
+		store: t.store,
+		state: t.store.state.clone(),
+	}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	t.store.state = tx.state
+	return nil
+}
+
+// transaction() returns transaction of this store stored in ctx, if there is one
+func (s *Store) transaction(ctx context.Context) *transaction {
+	tx, _ := ctx.Value(txKey{}).(*transaction)
+	if tx == nil || tx.store != s {
+		return nil
+	}
+
+	return tx
+}
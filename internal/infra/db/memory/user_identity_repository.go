@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type UserIdentityRepository struct {
+	store *Store
+}
+
+func NewUserIdentityRepository(store *Store) (*UserIdentityRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &UserIdentityRepository{store: store}, nil
+}
+
+func (r *UserIdentityRepository) Save(ctx context.Context, identity *domain.UserIdentity) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.users[identity.UserID()]; !ok {
+			return fmt.Errorf("%w: user with id=%s", ErrNotFound, identity.UserID())
+		}
+
+		for key, userID := range st.identities {
+			if key.provider == identity.Provider() && userID == identity.UserID() {
+				delete(st.identities, key)
+			}
+		}
+		st.identities[identityKey{provider: identity.Provider(), login: identity.Login()}] = identity.UserID()
+		return nil
+	})
+}
+
+func (r *UserIdentityRepository) FindByLogin(ctx context.Context, provider domain.IdentityProvider, login string) (*domain.UserIdentity, error) {
+	var identity *domain.UserIdentity
+	err := r.store.view(ctx, func(st *state) error {
+		if userID, ok := st.identities[identityKey{provider: provider, login: login}]; ok {
+			identity = domain.ExistingUserIdentity(provider, login, userID)
+		}
+		return nil
+	})
+
+	return identity, err
+}
+
+func (r *UserIdentityRepository) FindByUserIDs(ctx context.Context, provider domain.IdentityProvider, userIDs []domain.ID) ([]*domain.UserIdentity, error) {
+	identities := make([]*domain.UserIdentity, 0, len(userIDs))
+	err := r.store.view(ctx, func(st *state) error {
+		for key, userID := range st.identities {
+			if key.provider == provider && slices.Contains(userIDs, userID) {
+				identities = append(identities, domain.ExistingUserIdentity(provider, key.login, userID))
+			}
+		}
+		return nil
+	})
+
+	return identities, err
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type UserRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) (*UserRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &UserRepository{store: store}, nil
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.users[user.ID()]; ok {
+			return fmt.Errorf("%w: user with id=%s", ErrAlreadyExists, user.ID())
+		}
+
+		return st.saveUser(user)
+	})
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id domain.ID) (*domain.User, error) {
+	var user *domain.User
+	err := r.store.view(ctx, func(st *state) error {
+		if record, ok := st.users[id]; ok {
+			user = record.toEntity()
+		}
+		return nil
+	})
+
+	return user, err
+}
+
+func (r *UserRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.User, error) {
+	var user *domain.User
+	err := r.store.view(ctx, func(st *state) error {
+		for _, record := range st.users {
+			if record.key == key {
+				user = record.toEntity()
+				break
+			}
+		}
+		return nil
+	})
+
+	return user, err
+}
+
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []domain.ID) ([]*domain.User, error) {
+	users := make([]*domain.User, 0, len(ids))
+	err := r.store.view(ctx, func(st *state) error {
+		for _, id := range ids {
+			if record, ok := st.users[id]; ok {
+				users = append(users, record.toEntity())
+			}
+		}
+		return nil
+	})
+
+	return users, err
+}
+
+func (r *UserRepository) FindAll(ctx context.Context) ([]*domain.User, error) {
+	var users []*domain.User
+	err := r.store.view(ctx, func(st *state) error {
+		users = make([]*domain.User, 0, len(st.users))
+		for _, record := range st.users {
+			users = append(users, record.toEntity())
+		}
+		return nil
+	})
+
+	slices.SortFunc(users, func(a, b *domain.User) int {
+		return strings.Compare(a.Key().Value(), b.Key().Value())
+	})
+
+	return users, err
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.users[user.ID()]; !ok {
+			return fmt.Errorf("%w: user with id=%s", ErrNotFound, user.ID())
+		}
+
+		return st.saveUser(user)
+	})
+}
+
+func (r *UserRepository) DeleteByID(ctx context.Context, id domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		st.deleteUser(id)
+		return nil
+	})
+}
@@ -0,0 +1,167 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+)
+
+type WebhookSubscriptionRepository struct {
+	store *Store
+}
+
+func NewWebhookSubscriptionRepository(store *Store) (*WebhookSubscriptionRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &WebhookSubscriptionRepository{store: store}, nil
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.subscriptions[subscription.ID()]; ok {
+			return fmt.Errorf("%w: webhook with id=%s", ErrAlreadyExists, subscription.ID())
+		}
+		st.subscriptions[subscription.ID()] = subscription
+		return nil
+	})
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id domain.ID) (*domain.WebhookSubscription, error) {
+	var subscription *domain.WebhookSubscription
+	err := r.store.view(ctx, func(st *state) error {
+		subscription = st.subscriptions[id]
+		return nil
+	})
+
+	return subscription, err
+}
+
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	subscriptions := make([]*domain.WebhookSubscription, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, s := range st.subscriptions {
+			subscriptions = append(subscriptions, s)
+		}
+		return nil
+	})
+
+	slices.SortFunc(subscriptions, func(a, b *domain.WebhookSubscription) int {
+		return a.CreatedAt().Compare(b.CreatedAt())
+	})
+
+	return subscriptions, err
+}
+
+func (r *WebhookSubscriptionRepository) DeleteByID(ctx context.Context, id domain.ID) error {
+	return r.store.update(ctx, func(st *state) error {
+		delete(st.subscriptions, id)
+		for deliveryID, d := range st.deliveries {
+			if d.SubscriptionID() == id {
+				delete(st.deliveries, deliveryID)
+			}
+		}
+		return nil
+	})
+}
+
+type WebhookDeliveryRepository struct {
+	store *Store
+}
+
+func NewWebhookDeliveryRepository(store *Store) (*WebhookDeliveryRepository, error) {
+	if store == nil {
+		return nil, errors.New("store cannot be nil")
+	}
+
+	return &WebhookDeliveryRepository{store: store}, nil
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, deliveries ...*domain.WebhookDelivery) error {
+	return r.store.update(ctx, func(st *state) error {
+		for _, d := range deliveries {
+			if _, ok := st.subscriptions[d.SubscriptionID()]; !ok {
+				return fmt.Errorf("%w: webhook with id=%s", ErrNotFound, d.SubscriptionID())
+			}
+			st.deliveries[d.ID()] = *d
+		}
+		return nil
+	})
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	claimed := make([]*domain.WebhookDelivery, 0)
+	err := r.store.update(ctx, func(st *state) error {
+		due := make([]domain.WebhookDelivery, 0)
+		for _, d := range st.deliveries {
+			if d.Status() == domain.DeliveryPending && !d.NextAttemptAt().After(now) {
+				due = append(due, d)
+			}
+		}
+		slices.SortFunc(due, func(a, b domain.WebhookDelivery) int {
+			return a.NextAttemptAt().Compare(b.NextAttemptAt())
+		})
+
+		for _, d := range due[:min(limit, len(due))] {
+			leased := domain.ExistingWebhookDelivery(
+				d.ID(),
+				d.SubscriptionID(),
+				d.EventID(),
+				d.EventType(),
+				d.Payload(),
+				d.Status(),
+				d.Attempts(),
+				now.Add(lease),
+				d.LastError(),
+				d.CreatedAt(),
+				d.DeliveredAt(),
+			)
+			st.deliveries[d.ID()] = *leased
+			claimed = append(claimed, leased)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return claimed, nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return r.store.update(ctx, func(st *state) error {
+		if _, ok := st.deliveries[delivery.ID()]; !ok {
+			// subscription was deleted while delivery was in flight
+			return nil
+		}
+		st.deliveries[delivery.ID()] = *delivery
+		return nil
+	})
+}
+
+func (r *WebhookDeliveryRepository) FindAll(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	err := r.store.view(ctx, func(st *state) error {
+		for _, d := range st.deliveries {
+			if filter.SubscriptionID != nil && d.SubscriptionID() != *filter.SubscriptionID {
+				continue
+			}
+			if filter.Status != nil && d.Status() != *filter.Status {
+				continue
+			}
+			deliveries = append(deliveries, &d)
+		}
+		return nil
+	})
+
+	slices.SortFunc(deliveries, func(a, b *domain.WebhookDelivery) int {
+		return a.CreatedAt().Compare(b.CreatedAt())
+	})
+
+	return deliveries, err
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type AssignmentEventRepository struct {
+	queries *db.Queries
+	dbPool  *pgxpool.Pool
+}
+
+func NewAssignmentEventRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*AssignmentEventRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+	if databasePool == nil {
+		return nil, errors.New("databasePool cannot be nil")
+	}
+
+	return &AssignmentEventRepository{
+		queries: queries,
+		dbPool:  databasePool,
+	}, nil
+}
+
+func (r *AssignmentEventRepository) Append(ctx context.Context, events ...*domain.AssignmentEvent) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	for _, event := range events {
+		err := qtx.CreateAssignmentEvent(ctx, db.CreateAssignmentEventParams{
+			ID:               event.ID.Value(),
+			EventType:        event.Type.String(),
+			Actor:            event.Actor,
+			OccurredAt:       TimestamptzFromTime(event.OccurredAt),
+			PullRequestID:    UUIDFromID(event.PullRequestID),
+			TeamID:           UUIDFromID(event.TeamID),
+			UserID:           UUIDFromID(event.UserID),
+			OldReviewerID:    UUIDFromID(event.OldReviewerID),
+			NewReviewerID:    UUIDFromID(event.NewReviewerID),
+			MissingApprovals: optionalInt4(event.MissingApprovals),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *AssignmentEventRepository) FindByPullRequestID(ctx context.Context, pullRequestID domain.ID) ([]*domain.AssignmentEvent, error) {
+	rows, err := queriesFor(ctx, r.queries).GetAssignmentEventsByPullRequestID(ctx, UUIDFromID(&pullRequestID))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.AssignmentEvent, len(rows))
+	for i, row := range rows {
+		events[i] = assignmentEventFromRow(row)
+	}
+
+	return events, nil
+}
+
+func assignmentEventFromRow(row db.AssignmentEvent) *domain.AssignmentEvent {
+	return &domain.AssignmentEvent{
+		ID:               domain.ExistingID(row.ID),
+		Type:             domain.AssignmentEventType(row.EventType),
+		Actor:            row.Actor,
+		OccurredAt:       TimeFromTimestamptz(row.OccurredAt),
+		PullRequestID:    IDFromUUID(row.PullRequestID),
+		TeamID:           IDFromUUID(row.TeamID),
+		UserID:           IDFromUUID(row.UserID),
+		OldReviewerID:    IDFromUUID(row.OldReviewerID),
+		NewReviewerID:    IDFromUUID(row.NewReviewerID),
+		MissingApprovals: intFromInt4(row.MissingApprovals),
+	}
+}
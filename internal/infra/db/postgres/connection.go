@@ -5,18 +5,24 @@ import (
 	"context"
 
 	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewConnection(ctx context.Context, dsn string) (*pgx.Conn, error) {
-	conn, err := pgx.Connect(ctx, dsn)
+// NewPool() opens connection pool and checks that database is reachable
+func NewPool(ctx context.Context, config *pgxpool.Config) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
 		return nil, err
 	}
 
-	return conn, nil
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	return pool, nil
 }
 
-func NewQueries(conn *pgx.Conn) *db.Queries {
-	return db.New(conn)
+func NewQueries(pool *pgxpool.Pool) *db.Queries {
+	return db.New(pool)
 }
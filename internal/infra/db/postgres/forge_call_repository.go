@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type ForgeCallRepository struct {
+	queries *db.Queries
+}
+
+func NewForgeCallRepository(queries *db.Queries) (*ForgeCallRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+
+	return &ForgeCallRepository{queries: queries}, nil
+}
+
+func (r *ForgeCallRepository) Create(ctx context.Context, call *domain.ForgeCall) error {
+	reviewerIDs := make([]uuid.UUID, len(call.ReviewerIDs()))
+	for i, id := range call.ReviewerIDs() {
+		reviewerIDs[i] = id.Value()
+	}
+
+	return queriesFor(ctx, r.queries).CreateForgeCall(ctx, db.CreateForgeCallParams{
+		ID:            call.ID().Value(),
+		PullRequestID: call.PullRequestID().Value(),
+		Action:        call.Action().String(),
+		ReviewerIds:   reviewerIDs,
+		Status:        call.Status().String(),
+		Attempts:      int32(call.Attempts()),
+		NextAttemptAt: TimestamptzFromTime(call.NextAttemptAt()),
+		LastError:     call.LastError(),
+		CreatedAt:     TimestamptzFromTime(call.CreatedAt()),
+		CompletedAt:   optionalTimestamptz(call.CompletedAt()),
+	})
+}
+
+func (r *ForgeCallRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.ForgeCall, error) {
+	rows, err := queriesFor(ctx, r.queries).ClaimDueForgeCalls(ctx, db.ClaimDueForgeCallsParams{
+		LeaseUntil: TimestamptzFromTime(now.Add(lease)),
+		DueAt:      TimestamptzFromTime(now),
+		BatchSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	calls := make([]*domain.ForgeCall, len(rows))
+	for i, row := range rows {
+		calls[i] = forgeCallFromRow(row)
+	}
+
+	return calls, nil
+}
+
+func (r *ForgeCallRepository) Update(ctx context.Context, call *domain.ForgeCall) error {
+	return queriesFor(ctx, r.queries).UpdateForgeCall(ctx, db.UpdateForgeCallParams{
+		ID:            call.ID().Value(),
+		Status:        call.Status().String(),
+		Attempts:      int32(call.Attempts()),
+		NextAttemptAt: TimestamptzFromTime(call.NextAttemptAt()),
+		LastError:     call.LastError(),
+		CompletedAt:   optionalTimestamptz(call.CompletedAt()),
+	})
+}
+
+func forgeCallFromRow(row db.ForgeCall) *domain.ForgeCall {
+	reviewerIDs := make([]domain.ID, len(row.ReviewerIds))
+	for i, id := range row.ReviewerIds {
+		reviewerIDs[i] = domain.ExistingID(id)
+	}
+
+	var completedAt *time.Time
+	if row.CompletedAt.Valid {
+		t := row.CompletedAt.Time
+		completedAt = &t
+	}
+
+	return domain.ExistingForgeCall(
+		domain.ExistingID(row.ID),
+		domain.ExistingID(row.PullRequestID),
+		domain.ForgeCallAction(row.Action),
+		reviewerIDs,
+		domain.DeliveryStatus(row.Status),
+		int(row.Attempts),
+		TimeFromTimestamptz(row.NextAttemptAt),
+		row.LastError,
+		TimeFromTimestamptz(row.CreatedAt),
+		completedAt,
+	)
+}
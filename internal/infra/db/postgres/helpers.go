@@ -3,6 +3,7 @@ package postgres
 import (
 	"time"
 
+	"github.com/alphameo/pr-reviewnager/internal/domain"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -13,6 +14,15 @@ func TimestamptzFromTime(t time.Time) pgtype.Timestamptz {
 	return ts
 }
 
+// optionalTimestamptz() converts nil time to NULL
+func optionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+
+	return TimestamptzFromTime(*t)
+}
+
 func TimeFromTimestamptz(ts pgtype.Timestamptz) time.Time {
 	if ts.Valid {
 		return ts.Time
@@ -20,3 +30,38 @@ func TimeFromTimestamptz(ts pgtype.Timestamptz) time.Time {
 
 	return time.Time{}
 }
+
+func UUIDFromID(id *domain.ID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{Valid: false}
+	}
+
+	return pgtype.UUID{Bytes: id.Value(), Valid: true}
+}
+
+func IDFromUUID(id pgtype.UUID) *domain.ID {
+	if !id.Valid {
+		return nil
+	}
+
+	value := domain.ExistingID(id.Bytes)
+	return &value
+}
+
+// optionalInt4() converts nil int to NULL
+func optionalInt4(value *int) pgtype.Int4 {
+	if value == nil {
+		return pgtype.Int4{Valid: false}
+	}
+
+	return pgtype.Int4{Int32: int32(*value), Valid: true}
+}
+
+func intFromInt4(value pgtype.Int4) *int {
+	if !value.Valid {
+		return nil
+	}
+
+	result := int(value.Int32)
+	return &result
+}
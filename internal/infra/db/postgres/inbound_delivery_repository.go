@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+)
+
+type InboundDeliveryRepository struct {
+	queries *db.Queries
+}
+
+func NewInboundDeliveryRepository(queries *db.Queries) (*InboundDeliveryRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+
+	return &InboundDeliveryRepository{queries: queries}, nil
+}
+
+func (r *InboundDeliveryRepository) Claim(ctx context.Context, provider domain.IdentityProvider, deliveryID string, receivedAt time.Time) (bool, error) {
+	// concurrent claim of the same delivery waits for this transaction and claims nothing
+	rows, err := queriesFor(ctx, r.queries).ClaimInboundDelivery(ctx, db.ClaimInboundDeliveryParams{
+		Provider:   provider.String(),
+		DeliveryID: deliveryID,
+		ReceivedAt: TimestamptzFromTime(receivedAt),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
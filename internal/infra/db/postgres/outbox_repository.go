@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	queries *db.Queries
+	dbPool  *pgxpool.Pool
+}
+
+func NewOutboxRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*OutboxRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+	if databasePool == nil {
+		return nil, errors.New("databasePool cannot be nil")
+	}
+
+	return &OutboxRepository{
+		queries: queries,
+		dbPool:  databasePool,
+	}, nil
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, events ...*domain.AssignmentEvent) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	for _, event := range events {
+		err := qtx.CreateOutboxMessage(ctx, db.CreateOutboxMessageParams{
+			EventID:   event.ID.Value(),
+			CreatedAt: TimestamptzFromTime(event.OccurredAt),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *OutboxRepository) FindPendingForUpdate(ctx context.Context, limit int) ([]*domain.AssignmentEvent, error) {
+	if txFromContext(ctx) == nil {
+		return nil, domain.ErrNoTransaction
+	}
+
+	rows, err := queriesFor(ctx, r.queries).GetPendingOutboxEventsForUpdate(ctx, int32(limit))
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*domain.AssignmentEvent, len(rows))
+	for i, row := range rows {
+		events[i] = assignmentEventFromRow(row)
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, eventIDs ...domain.ID) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(eventIDs))
+	for i, id := range eventIDs {
+		ids[i] = id.Value()
+	}
+
+	return queriesFor(ctx, r.queries).MarkOutboxMessagesPublished(ctx, db.MarkOutboxMessagesPublishedParams{
+		PublishedAt: TimestamptzFromTime(time.Now()),
+		EventIds:    ids,
+	})
+}
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
@@ -10,30 +11,34 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PullRequestRepository struct {
 	queries *db.Queries
-	dbConn  *pgx.Conn
+	dbPool  *pgxpool.Pool
 }
 
-func NewPullRequestRepository(queries *db.Queries, databaseConnection *pgx.Conn) (*PullRequestRepository, error) {
+func NewPullRequestRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*PullRequestRepository, error) {
 	if queries == nil {
 		return nil, errors.New("queries cannot be nil")
 	}
-	if databaseConnection == nil {
-		return nil, errors.New("database connection cannot be nil")
+	if databasePool == nil {
+		return nil, errors.New("database pool cannot be nil")
 	}
 
 	return &PullRequestRepository{
 		queries: queries,
-		dbConn:  databaseConnection,
+		dbPool:  databasePool,
 	}, nil
 }
 
-func (r *PullRequestRepository) Create(pullRequest *domain.PullRequest) error {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *PullRequestRepository) Create(ctx context.Context, pullRequest *domain.PullRequest) error {
+	return r.CreateAndAdvanceRotation(ctx, pullRequest, nil)
+}
+
+func (r *PullRequestRepository) CreateAndAdvanceRotation(ctx context.Context, pullRequest *domain.PullRequest, advance *domain.RotationAdvance) error {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return err
 	}
@@ -41,42 +46,93 @@ func (r *PullRequestRepository) Create(pullRequest *domain.PullRequest) error {
 
 	qtx := r.queries.WithTx(tx)
 
-	var mergedAt pgtype.Timestamptz
-	if pullRequest.MergedAt() == nil {
-		mergedAt = pgtype.Timestamptz{Valid: false}
-	} else {
-		mergedAt = TimestamptzFromTime(*pullRequest.MergedAt())
-	}
-
 	err = qtx.CreatePullRequest(ctx, db.CreatePullRequestParams{
-		ID:       pullRequest.ID().Value(),
-		Title:    pullRequest.Title().Value(),
-		AuthorID: pullRequest.AuthorID().Value(),
-		Status:   pullRequest.Status().String(),
-		MergedAt: mergedAt,
+		ID:           pullRequest.ID().Value(),
+		Title:        pullRequest.Title().Value(),
+		AuthorID:     pullRequest.AuthorID().Value(),
+		CreatedAt:    TimestamptzFromTime(pullRequest.CreatedAt()),
+		Status:       pullRequest.Status().String(),
+		MergedAt:     mergedAtTimestamptz(pullRequest),
+		MaxReviewers: int32(pullRequest.MaxReviewers()),
+		ExternalKey:  pullRequest.Key().Value(),
+		Version:      pullRequest.Version(),
+		TeamID:       UUIDFromID(pullRequest.TeamID()),
 	})
 	if err != nil {
 		return err
 	}
 
-	for _, reviewerID := range pullRequest.ReviewerIDs() {
-		err = qtx.CreatePullRequestReviewer(ctx, db.CreatePullRequestReviewerParams{
-			PullRequestID: pullRequest.ID().Value(),
-			ReviewerID:    reviewerID.Value(),
+	if err := createReviewers(ctx, qtx, pullRequest); err != nil {
+		return err
+	}
+
+	if advance != nil {
+		if err := advanceRotation(ctx, qtx, advance); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func createReviewers(ctx context.Context, qtx *db.Queries, pullRequest *domain.PullRequest) error {
+	for _, reviewer := range pullRequest.Reviewers() {
+		err := qtx.CreatePullRequestReviewer(ctx, db.CreatePullRequestReviewerParams{
+			PullRequestID:  pullRequest.ID().Value(),
+			ReviewerID:     reviewer.UserID.Value(),
+			State:          reviewer.State.String(),
+			AssignedAt:     TimestamptzFromTime(reviewer.AssignedAt),
+			ReviewedAt:     optionalTimestamptz(reviewer.ReviewedAt),
+			FallbackTeamID: UUIDFromID(reviewer.FallbackTeamID),
 		})
 		if err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
+
+// reviewerFromColumns() returns nil for row of pull request without reviewers
+func reviewerFromColumns(
+	reviewerID pgtype.UUID,
+	state pgtype.Text,
+	assignedAt pgtype.Timestamptz,
+	reviewedAt pgtype.Timestamptz,
+	fallbackTeamID pgtype.UUID,
+) (*domain.Reviewer, error) {
+	if !reviewerID.Valid {
+		return nil, nil
+	}
+	id, err := domain.ParseID(reviewerID.String())
+	if err != nil {
+		return nil, err
+	}
 
+	reviewer := &domain.Reviewer{
+		UserID:         id,
+		State:          domain.ReviewState(state.String),
+		AssignedAt:     TimeFromTimestamptz(assignedAt),
+		FallbackTeamID: IDFromUUID(fallbackTeamID),
+	}
+	if reviewedAt.Valid {
+		t := TimeFromTimestamptz(reviewedAt)
+		reviewer.ReviewedAt = &t
 	}
 
-	return tx.Commit(ctx)
+	return reviewer, nil
 }
 
-func (r *PullRequestRepository) FindByID(id domain.ID) (*domain.PullRequest, error) {
-	ctx := context.Background()
+func mergedAtTimestamptz(pullRequest *domain.PullRequest) pgtype.Timestamptz {
+	if pullRequest.MergedAt() == nil {
+		return pgtype.Timestamptz{Valid: false}
+	}
+
+	return TimestamptzFromTime(*pullRequest.MergedAt())
+}
 
-	rows, err := r.queries.GetPullRequestWithReviewersByID(ctx, id.Value())
+func (r *PullRequestRepository) FindByID(ctx context.Context, id domain.ID) (*domain.PullRequest, error) {
+	rows, err := queriesFor(ctx, r.queries).GetPullRequestWithReviewersByID(ctx, id.Value())
 	if err != nil {
 		return nil, err
 	}
@@ -85,15 +141,15 @@ func (r *PullRequestRepository) FindByID(id domain.ID) (*domain.PullRequest, err
 		return nil, nil
 	}
 
-	var reviewerIDs []domain.ID
+	var reviewers []domain.Reviewer
 
 	for _, row := range rows {
-		if row.ReviewerID.Valid {
-			reviewerID, err := domain.ParseID(row.ReviewerID.String())
-			if err != nil {
-				return nil, err
-			}
-			reviewerIDs = append(reviewerIDs, reviewerID)
+		reviewer, err := reviewerFromColumns(row.ReviewerID, row.ReviewerState, row.ReviewerAssignedAt, row.ReviewerReviewedAt, row.ReviewerFallbackTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewer != nil {
+			reviewers = append(reviewers, *reviewer)
 		}
 	}
 
@@ -105,29 +161,94 @@ func (r *PullRequestRepository) FindByID(id domain.ID) (*domain.PullRequest, err
 
 	return domain.ExistingPullRequest(
 		id,
+		domain.ExistingExternalKey(rows[0].ExternalKey),
 		domain.ExistingPRTitle(rows[0].Title),
 		domain.ID(rows[0].AuthorID),
+		IDFromUUID(rows[0].TeamID),
 		TimeFromTimestamptz(rows[0].CreatedAt),
 		domain.ExistingPRStatus(rows[0].Status),
 		mergedAt,
-		reviewerIDs,
+		reviewers,
+		int(rows[0].MaxReviewers),
+		rows[0].Version,
 	), nil
 }
 
-func (r *PullRequestRepository) FindAll() ([]*domain.PullRequest, error) {
-	ctx := context.Background()
-	rows, err := r.queries.GetPullRequestsWithReviewers(ctx)
+func (r *PullRequestRepository) FindByIDForUpdate(ctx context.Context, id domain.ID) (*domain.PullRequest, error) {
+	if txFromContext(ctx) == nil {
+		return nil, domain.ErrNoTransaction
+	}
+
+	_, err := queriesFor(ctx, r.queries).LockPullRequest(ctx, id.Value())
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *PullRequestRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.PullRequest, error) {
+	rows, err := queriesFor(ctx, r.queries).GetPullRequestWithReviewersByExternalKey(ctx, key.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	var reviewers []domain.Reviewer
+
+	for _, row := range rows {
+		reviewer, err := reviewerFromColumns(row.ReviewerID, row.ReviewerState, row.ReviewerAssignedAt, row.ReviewerReviewedAt, row.ReviewerFallbackTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewer != nil {
+			reviewers = append(reviewers, *reviewer)
+		}
+	}
+
+	var mergedAt *time.Time
+	if rows[0].MergedAt.Valid {
+		t := TimeFromTimestamptz(rows[0].MergedAt)
+		mergedAt = &t
+	}
+
+	return domain.ExistingPullRequest(
+		domain.ExistingID(rows[0].ID),
+		key,
+		domain.ExistingPRTitle(rows[0].Title),
+		domain.ID(rows[0].AuthorID),
+		IDFromUUID(rows[0].TeamID),
+		TimeFromTimestamptz(rows[0].CreatedAt),
+		domain.ExistingPRStatus(rows[0].Status),
+		mergedAt,
+		reviewers,
+		int(rows[0].MaxReviewers),
+		rows[0].Version,
+	), nil
+}
+
+func (r *PullRequestRepository) FindAll(ctx context.Context) ([]*domain.PullRequest, error) {
+	rows, err := queriesFor(ctx, r.queries).GetPullRequestsWithReviewers(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	type prData struct {
-		Title     string
-		AuthorID  uuid.UUID
-		CreatedAt time.Time
-		Status    string
-		MergedAt  *time.Time
-		Reviewers []domain.ID
+		Key          string
+		Title        string
+		AuthorID     uuid.UUID
+		TeamID       *domain.ID
+		CreatedAt    time.Time
+		Status       string
+		MergedAt     *time.Time
+		Reviewers    []domain.Reviewer
+		MaxReviewers int
+		Version      int64
 	}
 	prMap := make(map[uuid.UUID]*prData)
 
@@ -141,21 +262,25 @@ func (r *PullRequestRepository) FindAll() ([]*domain.PullRequest, error) {
 				mergedAt = &t
 			}
 			prMap[prID] = &prData{
-				Title:     row.Title,
-				AuthorID:  row.AuthorID,
-				CreatedAt: TimeFromTimestamptz(row.CreatedAt),
-				Status:    row.Status,
-				MergedAt:  mergedAt,
-				Reviewers: nil,
+				Key:          row.ExternalKey,
+				Title:        row.Title,
+				AuthorID:     row.AuthorID,
+				TeamID:       IDFromUUID(row.TeamID),
+				CreatedAt:    TimeFromTimestamptz(row.CreatedAt),
+				Status:       row.Status,
+				MergedAt:     mergedAt,
+				Reviewers:    nil,
+				MaxReviewers: int(row.MaxReviewers),
+				Version:      row.Version,
 			}
 		}
 
-		if row.ReviewerID.Valid {
-			reviewerID, err := domain.ParseID(row.ReviewerID.String())
-			if err != nil {
-				return nil, err
-			}
-			prMap[prID].Reviewers = append(prMap[prID].Reviewers, reviewerID)
+		reviewer, err := reviewerFromColumns(row.ReviewerID, row.ReviewerState, row.ReviewerAssignedAt, row.ReviewerReviewedAt, row.ReviewerFallbackTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewer != nil {
+			prMap[prID].Reviewers = append(prMap[prID].Reviewers, *reviewer)
 		}
 	}
 
@@ -163,12 +288,16 @@ func (r *PullRequestRepository) FindAll() ([]*domain.PullRequest, error) {
 	for id, data := range prMap {
 		pr := domain.ExistingPullRequest(
 			domain.ExistingID(id),
+			domain.ExistingExternalKey(data.Key),
 			domain.ExistingPRTitle(data.Title),
 			domain.ExistingID(data.AuthorID),
+			data.TeamID,
 			data.CreatedAt,
 			domain.ExistingPRStatus(data.Status),
 			data.MergedAt,
 			data.Reviewers,
+			data.MaxReviewers,
+			data.Version,
 		)
 		prs = append(prs, pr)
 	}
@@ -176,9 +305,12 @@ func (r *PullRequestRepository) FindAll() ([]*domain.PullRequest, error) {
 	return prs, nil
 }
 
-func (r *PullRequestRepository) Update(pullRequest *domain.PullRequest) error {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *PullRequestRepository) Update(ctx context.Context, pullRequest *domain.PullRequest) error {
+	return r.UpdateAndAdvanceRotation(ctx, pullRequest, nil)
+}
+
+func (r *PullRequestRepository) UpdateAndAdvanceRotation(ctx context.Context, pullRequest *domain.PullRequest, advance *domain.RotationAdvance) error {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return err
 	}
@@ -186,65 +318,91 @@ func (r *PullRequestRepository) Update(pullRequest *domain.PullRequest) error {
 
 	qtx := r.queries.WithTx(tx)
 
-	var mergedAt pgtype.Timestamptz
-	if pullRequest.MergedAt() == nil {
-		mergedAt = pgtype.Timestamptz{Valid: false}
-	} else {
-		mergedAt = TimestamptzFromTime(*pullRequest.MergedAt())
+	if err := updatePullRequest(ctx, qtx, pullRequest); err != nil {
+		return err
 	}
 
-	err = qtx.UpdatePullRequest(ctx, db.UpdatePullRequestParams{
-		ID:       pullRequest.ID().Value(),
-		Title:    pullRequest.Title().Value(),
-		AuthorID: pullRequest.AuthorID().Value(),
-		Status:   pullRequest.Status().String(),
-		MergedAt: mergedAt,
+	if advance != nil {
+		if err := advanceRotation(ctx, qtx, advance); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// updatePullRequest() saves pull request with its reviewers using given (transactional) queries
+// and increments its version. Fails with domain.ErrVersionConflict if the stored version
+// differs from the version pull request was loaded with.
+func updatePullRequest(ctx context.Context, qtx *db.Queries, pullRequest *domain.PullRequest) error {
+	updated, err := qtx.UpdatePullRequest(ctx, db.UpdatePullRequestParams{
+		ID:           pullRequest.ID().Value(),
+		Title:        pullRequest.Title().Value(),
+		AuthorID:     pullRequest.AuthorID().Value(),
+		CreatedAt:    TimestamptzFromTime(pullRequest.CreatedAt()),
+		Status:       pullRequest.Status().String(),
+		MergedAt:     mergedAtTimestamptz(pullRequest),
+		MaxReviewers: int32(pullRequest.MaxReviewers()),
+		ExternalKey:  pullRequest.Key().Value(),
+		Version:      pullRequest.Version(),
+		TeamID:       UUIDFromID(pullRequest.TeamID()),
 	})
 	if err != nil {
 		return err
 	}
+	if updated == 0 {
+		return fmt.Errorf("%w: pull request with id=%s", domain.ErrVersionConflict, pullRequest.ID())
+	}
 
 	err = qtx.DeletePullRequestReviewersByPRID(ctx, pullRequest.ID().Value())
 	if err != nil {
 		return err
 	}
 
-	for _, id := range pullRequest.ReviewerIDs() {
-		err := qtx.CreatePullRequestReviewer(
-			ctx,
-			db.CreatePullRequestReviewerParams{
-				PullRequestID: pullRequest.ID().Value(),
-				ReviewerID:    id.Value(),
-			})
+	if err := createReviewers(ctx, qtx, pullRequest); err != nil {
+		return err
+	}
+
+	for _, reassignment := range pullRequest.PendingReassignments() {
+		err := qtx.CreateReviewerReassignment(ctx, db.CreateReviewerReassignmentParams{
+			PullRequestID: pullRequest.ID().Value(),
+			OldReviewerID: reassignment.OldReviewerID.Value(),
+			NewReviewerID: reassignment.NewReviewerID.Value(),
+			ReassignedAt:  TimestamptzFromTime(reassignment.ReassignedAt),
+		})
 		if err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit(ctx)
+	pullRequest.SetVersion(pullRequest.Version() + 1)
+
+	return nil
 }
 
-func (r *PullRequestRepository) DeleteByID(id domain.ID) error {
-	ctx := context.Background()
+func (r *PullRequestRepository) DeleteByID(ctx context.Context, id domain.ID) error {
 
-	err := r.queries.DeletePullRequest(ctx, id.Value())
+	err := queriesFor(ctx, r.queries).DeletePullRequest(ctx, id.Value())
 	return err
 }
 
-func (r *PullRequestRepository) FindPullRequestsByReviewer(userID domain.ID) ([]*domain.PullRequest, error) {
-	ctx := context.Background()
-	rows, err := r.queries.GetPullRequestsWithReviewersByReviewerID(ctx, userID.Value())
+func (r *PullRequestRepository) FindPullRequestsByReviewer(ctx context.Context, userID domain.ID) ([]*domain.PullRequest, error) {
+	rows, err := queriesFor(ctx, r.queries).GetPullRequestsWithReviewersByReviewerID(ctx, userID.Value())
 	if err != nil {
 		return nil, err
 	}
 
 	type prData struct {
-		Title     string
-		AuthorID  uuid.UUID
-		CreatedAt time.Time
-		Status    string
-		MergedAt  *time.Time
-		Reviewers []domain.ID
+		Key          string
+		Title        string
+		AuthorID     uuid.UUID
+		TeamID       *domain.ID
+		CreatedAt    time.Time
+		Status       string
+		MergedAt     *time.Time
+		Reviewers    []domain.Reviewer
+		MaxReviewers int
+		Version      int64
 	}
 	prMap := make(map[uuid.UUID]*prData)
 
@@ -258,21 +416,25 @@ func (r *PullRequestRepository) FindPullRequestsByReviewer(userID domain.ID) ([]
 				mergedAt = &t
 			}
 			prMap[prID] = &prData{
-				Title:     row.Title,
-				AuthorID:  row.AuthorID,
-				CreatedAt: TimeFromTimestamptz(row.CreatedAt),
-				Status:    row.Status,
-				MergedAt:  mergedAt,
-				Reviewers: nil,
+				Key:          row.ExternalKey,
+				Title:        row.Title,
+				AuthorID:     row.AuthorID,
+				TeamID:       IDFromUUID(row.TeamID),
+				CreatedAt:    TimeFromTimestamptz(row.CreatedAt),
+				Status:       row.Status,
+				MergedAt:     mergedAt,
+				Reviewers:    nil,
+				MaxReviewers: int(row.MaxReviewers),
+				Version:      row.Version,
 			}
 		}
 
-		if row.ReviewerID.Valid {
-			reviewerID, err := domain.ParseID(row.ReviewerID.String())
-			if err != nil {
-				return nil, err
-			}
-			prMap[prID].Reviewers = append(prMap[prID].Reviewers, reviewerID)
+		reviewer, err := reviewerFromColumns(row.ReviewerID, row.ReviewerState, row.ReviewerAssignedAt, row.ReviewerReviewedAt, row.ReviewerFallbackTeamID)
+		if err != nil {
+			return nil, err
+		}
+		if reviewer != nil {
+			prMap[prID].Reviewers = append(prMap[prID].Reviewers, *reviewer)
 		}
 	}
 
@@ -280,15 +442,42 @@ func (r *PullRequestRepository) FindPullRequestsByReviewer(userID domain.ID) ([]
 	for id, data := range prMap {
 		pr := domain.ExistingPullRequest(
 			domain.ExistingID(id),
+			domain.ExistingExternalKey(data.Key),
 			domain.ExistingPRTitle(data.Title),
 			domain.ExistingID(data.AuthorID),
+			data.TeamID,
 			data.CreatedAt,
 			domain.ExistingPRStatus(data.Status),
 			data.MergedAt,
 			data.Reviewers,
+			data.MaxReviewers,
+			data.Version,
 		)
 		prs = append(prs, pr)
 	}
 
 	return prs, nil
 }
+
+func (r *PullRequestRepository) CountOpenReviewsByTeamID(ctx context.Context, teamID domain.ID) (map[domain.ID]int, error) {
+	rows, err := queriesFor(ctx, r.queries).CountOpenReviewsByTeamID(ctx, teamID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.ID]int, len(rows))
+	for _, row := range rows {
+		counts[domain.ExistingID(row.UserID)] = int(row.OpenReviews)
+	}
+
+	return counts, nil
+}
+
+func (r *PullRequestRepository) CountOpenPullRequestsByTeamID(ctx context.Context, teamID domain.ID) (int, error) {
+	count, err := queriesFor(ctx, r.queries).CountOpenPullRequestsByTeamID(ctx, teamID.Value())
+	if err != nil {
+		return 0, err
+	}
+
+	return int(count), nil
+}
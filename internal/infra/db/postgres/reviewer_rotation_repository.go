@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/jackc/pgx/v5"
+)
+
+type ReviewerRotationRepository struct {
+	queries *db.Queries
+}
+
+func NewReviewerRotationRepository(queries *db.Queries) (*ReviewerRotationRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+
+	return &ReviewerRotationRepository{queries: queries}, nil
+}
+
+func (r *ReviewerRotationRepository) FindRotationCursor(ctx context.Context, teamID domain.ID) (*domain.ID, error) {
+	lastReviewerID, err := queriesFor(ctx, r.queries).GetReviewerRotation(ctx, teamID.Value())
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return IDFromUUID(lastReviewerID), nil
+}
+
+func advanceRotation(ctx context.Context, qtx *db.Queries, advance *domain.RotationAdvance) error {
+	err := qtx.InitReviewerRotation(ctx, advance.TeamID.Value())
+	if err != nil {
+		return err
+	}
+
+	to := advance.To
+	affected, err := qtx.AdvanceReviewerRotation(ctx, db.AdvanceReviewerRotationParams{
+		LastReviewerID:     UUIDFromID(&to),
+		TeamID:             advance.TeamID.Value(),
+		ExpectedReviewerID: UUIDFromID(advance.From),
+	})
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrRotationConflict
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+)
+
+type StatsRepository struct {
+	queries *db.Queries
+}
+
+func NewStatsRepository(queries *db.Queries) (*StatsRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+
+	return &StatsRepository{queries: queries}, nil
+}
+
+func (r *StatsRepository) FindUserReviewStats(ctx context.Context, teamID *domain.ID) ([]*domain.UserReviewStats, error) {
+	rows, err := queriesFor(ctx, r.queries).GetUserReviewStats(ctx, UUIDFromID(teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*domain.UserReviewStats, len(rows))
+	for i, row := range rows {
+		stats[i] = &domain.UserReviewStats{
+			UserID:           domain.ExistingID(row.UserID),
+			UserKey:          domain.ExistingExternalKey(row.UserExternalKey),
+			UserName:         domain.ExistingUserName(row.UserName),
+			TotalAssignments: int(row.TotalAssignments),
+			OpenAssignments:  int(row.OpenAssignments),
+			MergedReviews:    int(row.MergedReviews),
+			ReassignedAway:   int(row.ReassignedAway),
+		}
+	}
+
+	return stats, nil
+}
+
+func (r *StatsRepository) FindPullRequestReviewStats(ctx context.Context, teamID *domain.ID) ([]*domain.PullRequestReviewStats, error) {
+	rows, err := queriesFor(ctx, r.queries).GetPullRequestReviewStats(ctx, UUIDFromID(teamID))
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]*domain.PullRequestReviewStats, len(rows))
+	for i, row := range rows {
+		var mergedAt *time.Time
+		if row.MergedAt.Valid {
+			t := TimeFromTimestamptz(row.MergedAt)
+			mergedAt = &t
+		}
+
+		stats[i] = &domain.PullRequestReviewStats{
+			PullRequestID:     domain.ExistingID(row.ID),
+			PullRequestKey:    domain.ExistingExternalKey(row.ExternalKey),
+			Title:             domain.ExistingPRTitle(row.Title),
+			Status:            domain.ExistingPRStatus(row.Status),
+			CreatedAt:         TimeFromTimestamptz(row.CreatedAt),
+			MergedAt:          mergedAt,
+			ReviewersAssigned: int(row.ReviewersAssigned),
+			Reassignments:     int(row.Reassignments),
+		}
+	}
+
+	return stats, nil
+}
@@ -9,30 +9,30 @@ import (
 	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type TeamRepository struct {
 	queries *db.Queries
-	dbConn  *pgx.Conn
+	dbPool  *pgxpool.Pool
 }
 
-func NewTeamRepository(queries *db.Queries, databaseConnection *pgx.Conn) (*TeamRepository, error) {
+func NewTeamRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*TeamRepository, error) {
 	if queries == nil {
 		return nil, errors.New("queries cannot be nil")
 	}
-	if databaseConnection == nil {
-		return nil, errors.New("database connection cannot be nil")
+	if databasePool == nil {
+		return nil, errors.New("database pool cannot be nil")
 	}
 
 	return &TeamRepository{
 		queries: queries,
-		dbConn:  databaseConnection,
+		dbPool:  databasePool,
 	}, nil
 }
 
-func (r *TeamRepository) Create(team *domain.Team) error {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) Create(ctx context.Context, team *domain.Team) error {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return err
 	}
@@ -41,23 +41,31 @@ func (r *TeamRepository) Create(team *domain.Team) error {
 	qtx := r.queries.WithTx(tx)
 
 	err = qtx.CreateTeam(ctx, db.CreateTeamParams{
-		ID:   team.ID().Value(),
-		Name: team.Name().Value(),
+		ID:                team.ID().Value(),
+		Name:              team.Name().Value(),
+		MinReviewers:      int32(team.Settings().MinReviewers()),
+		MaxReviewers:      int32(team.Settings().MaxReviewers()),
+		ExternalKey:       team.Key().Value(),
+		Version:           team.Version(),
+		RequiredApprovals: int32(team.Settings().RequiredApprovals()),
+		ParentID:          UUIDFromID(team.ParentID()),
 	})
 	if err != nil {
 		return err
 	}
 
-	for _, userID := range team.UserIDs() {
-		userTeamID, err := qtx.GetTeamIDForUser(ctx, userID.Value())
-		if err != nil {
-			return err
-		}
-		if userTeamID != uuid.Nil {
-			return fmt.Errorf("user with id=%s is already in team=%s", userID, userTeamID)
-		}
+	if err := addTeamUsers(ctx, qtx, team); err != nil {
+		return err
+	}
 
-		err = qtx.CreateTeamUser(ctx, db.CreateTeamUserParams{
+	return tx.Commit(ctx)
+}
+
+// addTeamUsers() adds members of team missing in it.
+// The team becomes primary for users without other teams.
+func addTeamUsers(ctx context.Context, qtx *db.Queries, team *domain.Team) error {
+	for _, userID := range team.UserIDs() {
+		err := qtx.CreateTeamUser(ctx, db.CreateTeamUserParams{
 			TeamID: team.ID().Value(),
 			UserID: userID.Value(),
 		})
@@ -66,12 +74,11 @@ func (r *TeamRepository) Create(team *domain.Team) error {
 		}
 	}
 
-	return tx.Commit(ctx)
+	return nil
 }
 
-func (r *TeamRepository) FindByID(id domain.ID) (*domain.Team, error) {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) FindByID(ctx context.Context, id domain.ID) (*domain.Team, error) {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return nil, err
 	}
@@ -98,8 +105,16 @@ func (r *TeamRepository) FindByID(id domain.ID) (*domain.Team, error) {
 
 	team := domain.ExistingTeam(
 		domain.ExistingID(dbTeam.ID),
+		domain.ExistingExternalKey(dbTeam.ExternalKey),
 		domain.ExistingTeamName(dbTeam.Name),
+		IDFromUUID(dbTeam.ParentID),
 		userIDs,
+		domain.ExistingTeamSettings(
+			int(dbTeam.MinReviewers),
+			int(dbTeam.MaxReviewers),
+			int(dbTeam.RequiredApprovals),
+		),
+		dbTeam.Version,
 	)
 
 	err = tx.Commit(ctx)
@@ -111,14 +126,17 @@ func (r *TeamRepository) FindByID(id domain.ID) (*domain.Team, error) {
 }
 
 type TeamDTO struct {
-	ID      domain.ID
-	Name    string
-	UserIDs []domain.ID
+	ID       domain.ID
+	Key      string
+	Name     string
+	ParentID *domain.ID
+	UserIDs  []domain.ID
+	Settings domain.TeamSettings
+	Version  int64
 }
 
-func (r *TeamRepository) FindAll() ([]*domain.Team, error) {
-	ctx := context.Background()
-	rows, err := r.queries.GetTeamsWithUsers(ctx)
+func (r *TeamRepository) FindAll(ctx context.Context) ([]*domain.Team, error) {
+	rows, err := queriesFor(ctx, r.queries).GetTeamsWithUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +149,17 @@ func (r *TeamRepository) FindAll() ([]*domain.Team, error) {
 		team, exists := teamMap[teamID]
 		if !exists {
 			team = &TeamDTO{
-				ID:      domain.ExistingID(row.TeamID),
-				Name:    row.TeamName,
-				UserIDs: make([]domain.ID, 0),
+				ID:       domain.ExistingID(row.TeamID),
+				Key:      row.TeamExternalKey,
+				Name:     row.TeamName,
+				ParentID: IDFromUUID(row.TeamParentID),
+				UserIDs:  make([]domain.ID, 0),
+				Settings: domain.ExistingTeamSettings(
+					int(row.TeamMinReviewers),
+					int(row.TeamMaxReviewers),
+					int(row.TeamRequiredApprovals),
+				),
+				Version: row.TeamVersion,
 			}
 			teamMap[teamID] = team
 		}
@@ -151,8 +177,12 @@ func (r *TeamRepository) FindAll() ([]*domain.Team, error) {
 	for _, teamDTO := range teamMap {
 		team := domain.ExistingTeam(
 			teamDTO.ID,
+			domain.ExistingExternalKey(teamDTO.Key),
 			domain.ExistingTeamName(teamDTO.Name),
+			teamDTO.ParentID,
 			teamDTO.UserIDs,
+			teamDTO.Settings,
+			teamDTO.Version,
 		)
 		teams = append(teams, team)
 	}
@@ -160,9 +190,8 @@ func (r *TeamRepository) FindAll() ([]*domain.Team, error) {
 	return teams, nil
 }
 
-func (r *TeamRepository) Update(team *domain.Team) error {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) Update(ctx context.Context, team *domain.Team) error {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return err
 	}
@@ -170,46 +199,69 @@ func (r *TeamRepository) Update(team *domain.Team) error {
 
 	qtx := r.queries.WithTx(tx)
 
-	err = qtx.UpdateTeam(ctx, db.UpdateTeamParams{
-		ID:   team.ID().Value(),
-		Name: team.Name().Value(),
+	if err := updateTeam(ctx, qtx, team); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	team.SetVersion(team.Version() + 1)
+	return nil
+}
+
+// updateTeam() saves team with its users if stored version matches the team one.
+// Version of entity is not changed.
+func updateTeam(ctx context.Context, qtx *db.Queries, team *domain.Team) error {
+	updated, err := qtx.UpdateTeam(ctx, db.UpdateTeamParams{
+		ID:                team.ID().Value(),
+		Name:              team.Name().Value(),
+		MinReviewers:      int32(team.Settings().MinReviewers()),
+		MaxReviewers:      int32(team.Settings().MaxReviewers()),
+		ExternalKey:       team.Key().Value(),
+		Version:           team.Version(),
+		RequiredApprovals: int32(team.Settings().RequiredApprovals()),
+		ParentID:          UUIDFromID(team.ParentID()),
 	})
 	if err != nil {
 		return err
 	}
+	if updated == 0 {
+		return fmt.Errorf("%w: team with id=%s", domain.ErrVersionConflict, team.ID())
+	}
 
-	err = qtx.DeleteTeamUsersByTeamID(ctx, team.ID().Value())
+	userIDs := make([]uuid.UUID, len(team.UserIDs()))
+	for i, userID := range team.UserIDs() {
+		userIDs[i] = userID.Value()
+	}
+	removed, err := qtx.DeleteTeamUsersExcept(ctx, db.DeleteTeamUsersExceptParams{
+		TeamID:  team.ID().Value(),
+		UserIds: userIDs,
+	})
 	if err != nil {
 		return err
 	}
 
-	for _, userID := range team.UserIDs() {
-		userTeamID, err := qtx.GetTeamIDForUser(ctx, userID.Value())
-		if err != nil {
-			return err
-		}
-		if userTeamID != uuid.Nil {
-			return fmt.Errorf("user with id=%s is already in team=%s", userID, userTeamID)
-		}
+	if err := addTeamUsers(ctx, qtx, team); err != nil {
+		return err
+	}
 
-		err = qtx.CreateTeamUser(
-			ctx,
-			db.CreateTeamUserParams{
-				TeamID: team.ID().Value(),
-				UserID: userID.Value(),
-			})
-		if err != nil {
-			return err
-		}
+	return restorePrimaryTeams(ctx, qtx, removed)
+}
+
+// restorePrimaryTeams() makes one of remaining teams primary for users, who left their primary team
+func restorePrimaryTeams(ctx context.Context, qtx *db.Queries, userIDs []uuid.UUID) error {
+	if len(userIDs) == 0 {
+		return nil
 	}
 
-	return tx.Commit(ctx)
+	return qtx.RestorePrimaryTeams(ctx, userIDs)
 }
 
-func (r *TeamRepository) DeleteByID(id domain.ID) error {
-	ctx := context.Background()
+func (r *TeamRepository) DeleteByID(ctx context.Context, id domain.ID) error {
 
-	err := r.queries.DeleteTeam(ctx, id.Value())
+	err := queriesFor(ctx, r.queries).DeleteTeam(ctx, id.Value())
 	if err != nil {
 		return err
 	}
@@ -217,9 +269,21 @@ func (r *TeamRepository) DeleteByID(id domain.ID) error {
 	return nil
 }
 
-func (r *TeamRepository) FindByName(teamName string) (*domain.Team, error) {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) FindByName(ctx context.Context, teamName string) (*domain.Team, error) {
+	return r.findTeam(ctx, func(qtx *db.Queries) (db.Team, error) {
+		return qtx.GetTeamByName(ctx, teamName)
+	})
+}
+
+func (r *TeamRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.Team, error) {
+	return r.findTeam(ctx, func(qtx *db.Queries) (db.Team, error) {
+		return qtx.GetTeamByExternalKey(ctx, key.Value())
+	})
+}
+
+// findTeam() returns team read by get with its members, nil if get finds nothing
+func (r *TeamRepository) findTeam(ctx context.Context, get func(qtx *db.Queries) (db.Team, error)) (*domain.Team, error) {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return nil, err
 	}
@@ -227,7 +291,7 @@ func (r *TeamRepository) FindByName(teamName string) (*domain.Team, error) {
 
 	qtx := r.queries.WithTx(tx)
 
-	dbTeam, err := qtx.GetTeamByName(ctx, teamName)
+	dbTeam, err := get(qtx)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
@@ -244,8 +308,16 @@ func (r *TeamRepository) FindByName(teamName string) (*domain.Team, error) {
 
 	team := domain.ExistingTeam(
 		domain.ExistingID(dbTeam.ID),
+		domain.ExistingExternalKey(dbTeam.ExternalKey),
 		domain.ExistingTeamName(dbTeam.Name),
+		IDFromUUID(dbTeam.ParentID),
 		userIDs,
+		domain.ExistingTeamSettings(
+			int(dbTeam.MinReviewers),
+			int(dbTeam.MaxReviewers),
+			int(dbTeam.RequiredApprovals),
+		),
+		dbTeam.Version,
 	)
 
 	err = tx.Commit(ctx)
@@ -256,9 +328,8 @@ func (r *TeamRepository) FindByName(teamName string) (*domain.Team, error) {
 	return team, nil
 }
 
-func (r *TeamRepository) CreateTeamAndModifyUsers(team *domain.Team, users []*domain.User) error {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) CreateTeamAndModifyUsers(ctx context.Context, team *domain.Team, users []*domain.User) error {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return err
 	}
@@ -266,49 +337,76 @@ func (r *TeamRepository) CreateTeamAndModifyUsers(team *domain.Team, users []*do
 
 	qtx := r.queries.WithTx(tx)
 
+	// members must exist before they are added to the team
+	for _, user := range users {
+		err = qtx.UpsertUser(ctx, db.UpsertUserParams{
+			ID:          user.ID().Value(),
+			Name:        user.Name().Value(),
+			Active:      user.Active(),
+			ExternalKey: user.Key().Value(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
 	err = qtx.CreateTeam(ctx, db.CreateTeamParams{
-		ID:   team.ID().Value(),
-		Name: team.Name().Value(),
+		ID:                team.ID().Value(),
+		Name:              team.Name().Value(),
+		MinReviewers:      int32(team.Settings().MinReviewers()),
+		MaxReviewers:      int32(team.Settings().MaxReviewers()),
+		ExternalKey:       team.Key().Value(),
+		Version:           team.Version(),
+		RequiredApprovals: int32(team.Settings().RequiredApprovals()),
+		ParentID:          UUIDFromID(team.ParentID()),
 	})
 	if err != nil {
 		return err
 	}
 
-	for _, userID := range team.UserIDs() {
-		userTeamID, err := qtx.GetTeamIDForUser(ctx, userID.Value())
-		if err != nil {
-			return err
-		}
-		if userTeamID != uuid.Nil {
-			return fmt.Errorf("user with id=%s is already in team=%s", userID, userTeamID)
-		}
+	if err := addTeamUsers(ctx, qtx, team); err != nil {
+		return err
+	}
 
-		err = qtx.CreateTeamUser(ctx, db.CreateTeamUserParams{
-			TeamID: team.ID().Value(),
-			UserID: userID.Value(),
-		})
-		if err != nil {
-			return err
-		}
+	return tx.Commit(ctx)
+}
+
+func (r *TeamRepository) UpdateTeamAndModifyUsers(ctx context.Context, team *domain.Team, users []*domain.User) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
 	}
+	defer tx.Rollback(ctx)
 
+	qtx := r.queries.WithTx(tx)
+
+	// members must exist before they are added to the team
 	for _, user := range users {
 		err = qtx.UpsertUser(ctx, db.UpsertUserParams{
-			ID:     user.ID().Value(),
-			Name:   user.Name().Value(),
-			Active: user.Active(),
+			ID:          user.ID().Value(),
+			Name:        user.Name().Value(),
+			Active:      user.Active(),
+			ExternalKey: user.Key().Value(),
 		})
 		if err != nil {
 			return err
 		}
 	}
 
-	return tx.Commit(ctx)
+	if err := updateTeam(ctx, qtx, team); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	team.SetVersion(team.Version() + 1)
+	return nil
 }
 
-func (r *TeamRepository) FindTeamByTeammateID(userID domain.ID) (*domain.Team, error) {
-	ctx := context.Background()
-	tx, err := r.dbConn.Begin(ctx)
+func (r *TeamRepository) FindTeamByTeammateID(ctx context.Context, userID domain.ID) (*domain.Team, error) {
+	tx, err := beginTx(ctx, r.dbPool)
 	if err != nil {
 		return nil, err
 	}
@@ -333,8 +431,16 @@ func (r *TeamRepository) FindTeamByTeammateID(userID domain.ID) (*domain.Team, e
 
 	team := domain.ExistingTeam(
 		domain.ExistingID(dbTeam.ID),
+		domain.ExistingExternalKey(dbTeam.ExternalKey),
 		domain.ExistingTeamName(dbTeam.Name),
+		IDFromUUID(dbTeam.ParentID),
 		userIDs,
+		domain.ExistingTeamSettings(
+			int(dbTeam.MinReviewers),
+			int(dbTeam.MaxReviewers),
+			int(dbTeam.RequiredApprovals),
+		),
+		dbTeam.Version,
 	)
 
 	err = tx.Commit(ctx)
@@ -345,10 +451,83 @@ func (r *TeamRepository) FindTeamByTeammateID(userID domain.ID) (*domain.Team, e
 	return team, nil
 }
 
-func (r *TeamRepository) FindActiveUsersByTeamID(teamID domain.ID) ([]*domain.User, error) {
-	ctx := context.Background()
+func (r *TeamRepository) FindTeamsByTeammateID(ctx context.Context, userID domain.ID) ([]*domain.Team, error) {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	dbTeams, err := qtx.GetTeamsForUser(ctx, userID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*domain.Team, 0, len(dbTeams))
+	for _, dbTeam := range dbTeams {
+		uIDs, err := qtx.GetUserIDsInTeam(ctx, dbTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		userIDs := make([]domain.ID, 0, len(uIDs))
+		for _, userID := range uIDs {
+			userIDs = append(userIDs, domain.ExistingID(userID))
+		}
+
+		teams = append(teams, domain.ExistingTeam(
+			domain.ExistingID(dbTeam.ID),
+			domain.ExistingExternalKey(dbTeam.ExternalKey),
+			domain.ExistingTeamName(dbTeam.Name),
+			IDFromUUID(dbTeam.ParentID),
+			userIDs,
+			domain.ExistingTeamSettings(
+				int(dbTeam.MinReviewers),
+				int(dbTeam.MaxReviewers),
+				int(dbTeam.RequiredApprovals),
+			),
+			dbTeam.Version,
+		))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) SetPrimaryTeam(ctx context.Context, userID domain.ID, teamID domain.ID) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	// previous primary team is cleared first, because user can have only one
+	if err := qtx.ClearPrimaryTeam(ctx, userID.Value()); err != nil {
+		return err
+	}
+	updated, err := qtx.SetPrimaryTeam(ctx, db.SetPrimaryTeamParams{
+		TeamID: teamID.Value(),
+		UserID: userID.Value(),
+	})
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: user with id=%s, team with id=%s", domain.ErrNotTeamMember, userID, teamID)
+	}
+
+	return tx.Commit(ctx)
+}
 
-	users, err := r.queries.GetActiveUsersInTeam(ctx, teamID.Value())
+func (r *TeamRepository) FindActiveUsersByTeamID(ctx context.Context, teamID domain.ID) ([]*domain.User, error) {
+	users, err := queriesFor(ctx, r.queries).GetActiveUsersInTeam(ctx, teamID.Value())
 	if err != nil {
 		return nil, err
 	}
@@ -357,6 +536,7 @@ func (r *TeamRepository) FindActiveUsersByTeamID(teamID domain.ID) ([]*domain.Us
 	for i, user := range users {
 		entities[i] = domain.ExistingUser(
 			domain.ExistingID(user.ID),
+			domain.ExistingExternalKey(user.ExternalKey),
 			domain.ExistingUserName(user.Name),
 			user.Active,
 		)
@@ -364,3 +544,196 @@ func (r *TeamRepository) FindActiveUsersByTeamID(teamID domain.ID) ([]*domain.Us
 
 	return entities, nil
 }
+
+func (r *TeamRepository) FindAncestors(ctx context.Context, teamID domain.ID) ([]*domain.Team, error) {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	dbTeams, err := qtx.GetTeamAncestors(ctx, teamID.Value())
+	if err != nil {
+		return nil, err
+	}
+
+	teams := make([]*domain.Team, 0, len(dbTeams))
+	for _, dbTeam := range dbTeams {
+		uIDs, err := qtx.GetUserIDsInTeam(ctx, dbTeam.ID)
+		if err != nil {
+			return nil, err
+		}
+		userIDs := make([]domain.ID, 0, len(uIDs))
+		for _, userID := range uIDs {
+			userIDs = append(userIDs, domain.ExistingID(userID))
+		}
+
+		teams = append(teams, domain.ExistingTeam(
+			domain.ExistingID(dbTeam.ID),
+			domain.ExistingExternalKey(dbTeam.ExternalKey),
+			domain.ExistingTeamName(dbTeam.Name),
+			IDFromUUID(dbTeam.ParentID),
+			userIDs,
+			domain.ExistingTeamSettings(
+				int(dbTeam.MinReviewers),
+				int(dbTeam.MaxReviewers),
+				int(dbTeam.RequiredApprovals),
+			),
+			dbTeam.Version,
+		))
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) DeactivateUsersAndReassignReviews(
+	ctx context.Context,
+	users []*domain.User,
+	pullRequests []*domain.PullRequest,
+	advances []*domain.RotationAdvance,
+) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	for _, user := range users {
+		err = qtx.UpdateUser(ctx, db.UpdateUserParams{
+			ID:          user.ID().Value(),
+			Name:        user.Name().Value(),
+			Active:      user.Active(),
+			ExternalKey: user.Key().Value(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pullRequest := range pullRequests {
+		if err := updatePullRequest(ctx, qtx, pullRequest); err != nil {
+			return err
+		}
+	}
+
+	for _, advance := range advances {
+		if err := advanceRotation(ctx, qtx, advance); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TeamRepository) DeleteAndReassignReviews(
+	ctx context.Context,
+	team *domain.Team,
+	users []*domain.User,
+	pullRequests []*domain.PullRequest,
+	advances []*domain.RotationAdvance,
+) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	// team users and rotation are deleted by cascade
+	deleted, err := qtx.DeleteTeamWithVersion(ctx, db.DeleteTeamWithVersionParams{
+		ID:      team.ID().Value(),
+		Version: team.Version(),
+	})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: team with id=%s", domain.ErrVersionConflict, team.ID())
+	}
+
+	userIDs := make([]uuid.UUID, len(team.UserIDs()))
+	for i, userID := range team.UserIDs() {
+		userIDs[i] = userID.Value()
+	}
+	if err := restorePrimaryTeams(ctx, qtx, userIDs); err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		err = qtx.UpdateUser(ctx, db.UpdateUserParams{
+			ID:          user.ID().Value(),
+			Name:        user.Name().Value(),
+			Active:      user.Active(),
+			ExternalKey: user.Key().Value(),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, pullRequest := range pullRequests {
+		if err := updatePullRequest(ctx, qtx, pullRequest); err != nil {
+			return err
+		}
+	}
+
+	for _, advance := range advances {
+		if err := advanceRotation(ctx, qtx, advance); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TeamRepository) UpdateMembershipAndReassignReviews(
+	ctx context.Context,
+	teams []*domain.Team,
+	pullRequests []*domain.PullRequest,
+	advance *domain.RotationAdvance,
+) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	for _, team := range teams {
+		if err := updateTeam(ctx, qtx, team); err != nil {
+			return err
+		}
+	}
+
+	for _, pullRequest := range pullRequests {
+		if err := updatePullRequest(ctx, qtx, pullRequest); err != nil {
+			return err
+		}
+	}
+
+	if advance != nil {
+		if err := advanceRotation(ctx, qtx, advance); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	for _, team := range teams {
+		team.SetVersion(team.Version() + 1)
+	}
+	return nil
+}
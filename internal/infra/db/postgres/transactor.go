@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// Transactor runs functions within a database transaction, which is passed
+// to repositories through the context
+type Transactor struct {
+	dbPool *pgxpool.Pool
+}
+
+func NewTransactor(databasePool *pgxpool.Pool) (*Transactor, error) {
+	if databasePool == nil {
+		return nil, errors.New("database pool cannot be nil")
+	}
+
+	return &Transactor{dbPool: databasePool}, nil
+}
+
+// WithinTransaction() runs fn in a new transaction, or in the transaction already
+// stored in ctx. The transaction is committed if fn succeeds and rolled back otherwise.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	tx, err := t.dbPool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func txFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txKey{}).(pgx.Tx)
+	return tx
+}
+
+// beginTx() starts a transaction, or a savepoint if ctx already holds a transaction
+func beginTx(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx.Begin(ctx)
+	}
+
+	return pool.Begin(ctx)
+}
+
+// queriesFor() returns queries bound to the transaction stored in ctx, if there is one
+func queriesFor(ctx context.Context, queries *db.Queries) *db.Queries {
+	if tx := txFromContext(ctx); tx != nil {
+		return queries.WithTx(tx)
+	}
+
+	return queries
+}
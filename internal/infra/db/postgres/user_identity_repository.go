@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type UserIdentityRepository struct {
+	queries *db.Queries
+	dbPool  *pgxpool.Pool
+}
+
+func NewUserIdentityRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*UserIdentityRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+	if databasePool == nil {
+		return nil, errors.New("databasePool cannot be nil")
+	}
+
+	return &UserIdentityRepository{
+		queries: queries,
+		dbPool:  databasePool,
+	}, nil
+}
+
+func (r *UserIdentityRepository) Save(ctx context.Context, identity *domain.UserIdentity) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	err = qtx.DeleteUserIdentityByUserID(ctx, db.DeleteUserIdentityByUserIDParams{
+		Provider: identity.Provider().String(),
+		UserID:   identity.UserID().Value(),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = qtx.UpsertUserIdentity(ctx, db.UpsertUserIdentityParams{
+		Provider: identity.Provider().String(),
+		Login:    identity.Login(),
+		UserID:   identity.UserID().Value(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *UserIdentityRepository) FindByLogin(ctx context.Context, provider domain.IdentityProvider, login string) (*domain.UserIdentity, error) {
+	row, err := queriesFor(ctx, r.queries).GetUserIdentityByLogin(ctx, db.GetUserIdentityByLoginParams{
+		Provider: provider.String(),
+		Login:    login,
+	})
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return domain.ExistingUserIdentity(
+		domain.IdentityProvider(row.Provider),
+		row.Login,
+		domain.ExistingID(row.UserID),
+	), nil
+}
+
+func (r *UserIdentityRepository) FindByUserIDs(ctx context.Context, provider domain.IdentityProvider, userIDs []domain.ID) ([]*domain.UserIdentity, error) {
+	uuids := make([]uuid.UUID, len(userIDs))
+	for i, id := range userIDs {
+		uuids[i] = id.Value()
+	}
+
+	rows, err := queriesFor(ctx, r.queries).GetUserIdentitiesByUserIDs(ctx, db.GetUserIdentitiesByUserIDsParams{
+		Provider: provider.String(),
+		UserIds:  uuids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	identities := make([]*domain.UserIdentity, len(rows))
+	for i, row := range rows {
+		identities[i] = domain.ExistingUserIdentity(
+			domain.IdentityProvider(row.Provider),
+			row.Login,
+			domain.ExistingID(row.UserID),
+		)
+	}
+
+	return identities, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/alphameo/pr-reviewnager/internal/domain"
 	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 )
 
@@ -21,17 +22,17 @@ func NewUserRepository(queries *db.Queries) (*UserRepository, error) {
 	return &UserRepository{queries: queries}, nil
 }
 
-func (r *UserRepository) Create(user *domain.User) error {
-	ctx := context.Background()
+func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 
 	if user == nil {
 		return errors.New("user cannot be nil")
 	}
 
-	err := r.queries.CreateUser(ctx, db.CreateUserParams{
-		ID:     user.ID().Value(),
-		Name:   user.Name().Value(),
-		Active: user.Active(),
+	err := queriesFor(ctx, r.queries).CreateUser(ctx, db.CreateUserParams{
+		ID:          user.ID().Value(),
+		Name:        user.Name().Value(),
+		Active:      user.Active(),
+		ExternalKey: user.Key().Value(),
 	})
 	if err != nil {
 		return err
@@ -40,10 +41,24 @@ func (r *UserRepository) Create(user *domain.User) error {
 	return nil
 }
 
-func (r *UserRepository) FindByID(id domain.ID) (*domain.User, error) {
-	ctx := context.Background()
+func (r *UserRepository) FindByID(ctx context.Context, id domain.ID) (*domain.User, error) {
+	user, err := queriesFor(ctx, r.queries).GetUser(ctx, id.Value())
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
 
-	user, err := r.queries.GetUser(ctx, id.Value())
+	return domain.ExistingUser(
+		domain.ExistingID(user.ID),
+		domain.ExistingExternalKey(user.ExternalKey),
+		domain.ExistingUserName(user.Name),
+		user.Active,
+	), nil
+}
+
+func (r *UserRepository) FindByKey(ctx context.Context, key domain.ExternalKey) (*domain.User, error) {
+	user, err := queriesFor(ctx, r.queries).GetUserByExternalKey(ctx, key.Value())
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
@@ -52,15 +67,38 @@ func (r *UserRepository) FindByID(id domain.ID) (*domain.User, error) {
 
 	return domain.ExistingUser(
 		domain.ExistingID(user.ID),
+		domain.ExistingExternalKey(user.ExternalKey),
 		domain.ExistingUserName(user.Name),
 		user.Active,
 	), nil
 }
 
-func (r *UserRepository) FindAll() ([]*domain.User, error) {
-	ctx := context.Background()
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []domain.ID) ([]*domain.User, error) {
+	uuids := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		uuids[i] = id.Value()
+	}
+
+	users, err := queriesFor(ctx, r.queries).GetUsersByIDs(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	entities := make([]*domain.User, len(users))
+	for i, user := range users {
+		entities[i] = domain.ExistingUser(
+			domain.ExistingID(user.ID),
+			domain.ExistingExternalKey(user.ExternalKey),
+			domain.ExistingUserName(user.Name),
+			user.Active,
+		)
+	}
+
+	return entities, nil
+}
 
-	users, err := r.queries.GetUsers(ctx)
+func (r *UserRepository) FindAll(ctx context.Context) ([]*domain.User, error) {
+	users, err := queriesFor(ctx, r.queries).GetUsers(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -69,6 +107,7 @@ func (r *UserRepository) FindAll() ([]*domain.User, error) {
 	for i, user := range users {
 		entities[i] = domain.ExistingUser(
 			domain.ExistingID(user.ID),
+			domain.ExistingExternalKey(user.ExternalKey),
 			domain.ExistingUserName(user.Name),
 			user.Active,
 		)
@@ -77,17 +116,17 @@ func (r *UserRepository) FindAll() ([]*domain.User, error) {
 	return entities, nil
 }
 
-func (r *UserRepository) Update(user *domain.User) error {
-	ctx := context.Background()
+func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 
 	if user == nil {
 		return errors.New("user cannot be nil")
 	}
 
-	err := r.queries.UpdateUser(ctx, db.UpdateUserParams{
-		ID:     user.ID().Value(),
-		Name:   user.Name().Value(),
-		Active: user.Active(),
+	err := queriesFor(ctx, r.queries).UpdateUser(ctx, db.UpdateUserParams{
+		ID:          user.ID().Value(),
+		Name:        user.Name().Value(),
+		Active:      user.Active(),
+		ExternalKey: user.Key().Value(),
 	})
 	if err != nil {
 		return err
@@ -96,10 +135,9 @@ func (r *UserRepository) Update(user *domain.User) error {
 	return nil
 }
 
-func (r *UserRepository) DeleteByID(id domain.ID) error {
-	ctx := context.Background()
+func (r *UserRepository) DeleteByID(ctx context.Context, id domain.ID) error {
 
-	err := r.queries.DeleteUser(ctx, id.Value())
+	err := queriesFor(ctx, r.queries).DeleteUser(ctx, id.Value())
 	if err != nil {
 		return err
 	}
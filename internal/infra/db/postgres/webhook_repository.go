@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alphameo/pr-reviewnager/internal/domain"
+	db "github.com/alphameo/pr-reviewnager/internal/infra/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type WebhookSubscriptionRepository struct {
+	queries *db.Queries
+}
+
+func NewWebhookSubscriptionRepository(queries *db.Queries) (*WebhookSubscriptionRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+
+	return &WebhookSubscriptionRepository{queries: queries}, nil
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *domain.WebhookSubscription) error {
+	eventTypes := make([]string, 0, len(subscription.EventTypes()))
+	for _, t := range subscription.EventTypes() {
+		eventTypes = append(eventTypes, t.String())
+	}
+
+	return queriesFor(ctx, r.queries).CreateWebhookSubscription(ctx, db.CreateWebhookSubscriptionParams{
+		ID:         subscription.ID().Value(),
+		URL:        subscription.URL(),
+		Secret:     subscription.Secret(),
+		EventTypes: eventTypes,
+		CreatedAt:  TimestamptzFromTime(subscription.CreatedAt()),
+	})
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id domain.ID) (*domain.WebhookSubscription, error) {
+	row, err := queriesFor(ctx, r.queries).GetWebhookSubscription(ctx, id.Value())
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return webhookSubscriptionFromRow(row), nil
+}
+
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	rows, err := queriesFor(ctx, r.queries).GetWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := make([]*domain.WebhookSubscription, len(rows))
+	for i, row := range rows {
+		subscriptions[i] = webhookSubscriptionFromRow(row)
+	}
+
+	return subscriptions, nil
+}
+
+func (r *WebhookSubscriptionRepository) DeleteByID(ctx context.Context, id domain.ID) error {
+	return queriesFor(ctx, r.queries).DeleteWebhookSubscription(ctx, id.Value())
+}
+
+func webhookSubscriptionFromRow(row db.WebhookSubscription) *domain.WebhookSubscription {
+	eventTypes := make([]domain.AssignmentEventType, len(row.EventTypes))
+	for i, t := range row.EventTypes {
+		eventTypes[i] = domain.AssignmentEventType(t)
+	}
+
+	return domain.ExistingWebhookSubscription(
+		domain.ExistingID(row.ID),
+		row.URL,
+		row.Secret,
+		eventTypes,
+		TimeFromTimestamptz(row.CreatedAt),
+	)
+}
+
+type WebhookDeliveryRepository struct {
+	queries *db.Queries
+	dbPool  *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(queries *db.Queries, databasePool *pgxpool.Pool) (*WebhookDeliveryRepository, error) {
+	if queries == nil {
+		return nil, errors.New("queries cannot be nil")
+	}
+	if databasePool == nil {
+		return nil, errors.New("databasePool cannot be nil")
+	}
+
+	return &WebhookDeliveryRepository{
+		queries: queries,
+		dbPool:  databasePool,
+	}, nil
+}
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, deliveries ...*domain.WebhookDelivery) error {
+	tx, err := beginTx(ctx, r.dbPool)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := r.queries.WithTx(tx)
+
+	for _, delivery := range deliveries {
+		err := qtx.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+			ID:             delivery.ID().Value(),
+			SubscriptionID: delivery.SubscriptionID().Value(),
+			EventID:        delivery.EventID().Value(),
+			EventType:      delivery.EventType().String(),
+			Payload:        delivery.Payload(),
+			Status:         delivery.Status().String(),
+			Attempts:       int32(delivery.Attempts()),
+			NextAttemptAt:  TimestamptzFromTime(delivery.NextAttemptAt()),
+			LastError:      delivery.LastError(),
+			CreatedAt:      TimestamptzFromTime(delivery.CreatedAt()),
+			DeliveredAt:    optionalTimestamptz(delivery.DeliveredAt()),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.WebhookDelivery, error) {
+	rows, err := queriesFor(ctx, r.queries).ClaimDueWebhookDeliveries(ctx, db.ClaimDueWebhookDeliveriesParams{
+		LeaseUntil: TimestamptzFromTime(now.Add(lease)),
+		DueAt:      TimestamptzFromTime(now),
+		BatchSize:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookDeliveriesFromRows(rows), nil
+}
+
+func (r *WebhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	return queriesFor(ctx, r.queries).UpdateWebhookDelivery(ctx, db.UpdateWebhookDeliveryParams{
+		ID:            delivery.ID().Value(),
+		Status:        delivery.Status().String(),
+		Attempts:      int32(delivery.Attempts()),
+		NextAttemptAt: TimestamptzFromTime(delivery.NextAttemptAt()),
+		LastError:     delivery.LastError(),
+		DeliveredAt:   optionalTimestamptz(delivery.DeliveredAt()),
+	})
+}
+
+func (r *WebhookDeliveryRepository) FindAll(ctx context.Context, filter domain.WebhookDeliveryFilter) ([]*domain.WebhookDelivery, error) {
+	params := db.GetWebhookDeliveriesParams{
+		SubscriptionID: UUIDFromID(filter.SubscriptionID),
+	}
+	if filter.Status != nil {
+		params.Status = pgtype.Text{String: filter.Status.String(), Valid: true}
+	}
+
+	rows, err := queriesFor(ctx, r.queries).GetWebhookDeliveries(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookDeliveriesFromRows(rows), nil
+}
+
+func webhookDeliveriesFromRows(rows []db.WebhookDelivery) []*domain.WebhookDelivery {
+	deliveries := make([]*domain.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		var deliveredAt *time.Time
+		if row.DeliveredAt.Valid {
+			t := row.DeliveredAt.Time
+			deliveredAt = &t
+		}
+
+		deliveries[i] = domain.ExistingWebhookDelivery(
+			domain.ExistingID(row.ID),
+			domain.ExistingID(row.SubscriptionID),
+			domain.ExistingID(row.EventID),
+			domain.AssignmentEventType(row.EventType),
+			row.Payload,
+			domain.DeliveryStatus(row.Status),
+			int(row.Attempts),
+			TimeFromTimestamptz(row.NextAttemptAt),
+			row.LastError,
+			TimeFromTimestamptz(row.CreatedAt),
+			deliveredAt,
+		)
+	}
+
+	return deliveries
+}
@@ -17,6 +17,8 @@ type PullRequestStatus string
 const (
 	PullRequestStatusOpen   PullRequestStatus = "open"
 	PullRequestStatusMerged PullRequestStatus = "merged"
+	PullRequestStatusDraft  PullRequestStatus = "draft"
+	PullRequestStatusClosed PullRequestStatus = "closed"
 )
 
 func (e *PullRequestStatus) Scan(src interface{}) error {
@@ -54,32 +56,128 @@ func (ns NullPullRequestStatus) Value() (driver.Value, error) {
 	return string(ns.PullRequestStatus), nil
 }
 
+type AssignmentEvent struct {
+	ID               uuid.UUID          `db:"id" json:"id"`
+	EventType        string             `db:"event_type" json:"event_type"`
+	Actor            string             `db:"actor" json:"actor"`
+	OccurredAt       pgtype.Timestamptz `db:"occurred_at" json:"occurred_at"`
+	PullRequestID    pgtype.UUID        `db:"pull_request_id" json:"pull_request_id"`
+	TeamID           pgtype.UUID        `db:"team_id" json:"team_id"`
+	UserID           pgtype.UUID        `db:"user_id" json:"user_id"`
+	OldReviewerID    pgtype.UUID        `db:"old_reviewer_id" json:"old_reviewer_id"`
+	NewReviewerID    pgtype.UUID        `db:"new_reviewer_id" json:"new_reviewer_id"`
+	MissingApprovals pgtype.Int4        `db:"missing_approvals" json:"missing_approvals"`
+}
+
+type ForgeCall struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	PullRequestID uuid.UUID          `db:"pull_request_id" json:"pull_request_id"`
+	Action        string             `db:"action" json:"action"`
+	ReviewerIds   []uuid.UUID        `db:"reviewer_ids" json:"reviewer_ids"`
+	Status        string             `db:"status" json:"status"`
+	Attempts      int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError     string             `db:"last_error" json:"last_error"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	CompletedAt   pgtype.Timestamptz `db:"completed_at" json:"completed_at"`
+}
+
+type InboundDelivery struct {
+	Provider   string             `db:"provider" json:"provider"`
+	DeliveryID string             `db:"delivery_id" json:"delivery_id"`
+	ReceivedAt pgtype.Timestamptz `db:"received_at" json:"received_at"`
+}
+
+type OutboxMessage struct {
+	EventID     uuid.UUID          `db:"event_id" json:"event_id"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	PublishedAt pgtype.Timestamptz `db:"published_at" json:"published_at"`
+}
+
 type PullRequest struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Title     string             `db:"title" json:"title"`
-	AuthorID  uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status    string             `db:"status" json:"status"`
-	MergedAt  pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	Title        string             `db:"title" json:"title"`
+	AuthorID     uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status       string             `db:"status" json:"status"`
+	MergedAt     pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey  string             `db:"external_key" json:"external_key"`
+	Version      int64              `db:"version" json:"version"`
+	TeamID       pgtype.UUID        `db:"team_id" json:"team_id"`
 }
 
 type PullRequestReviewer struct {
-	PullRequestID uuid.UUID `db:"pull_request_id" json:"pull_request_id"`
-	ReviewerID    uuid.UUID `db:"reviewer_id" json:"reviewer_id"`
+	PullRequestID  uuid.UUID          `db:"pull_request_id" json:"pull_request_id"`
+	ReviewerID     uuid.UUID          `db:"reviewer_id" json:"reviewer_id"`
+	State          string             `db:"state" json:"state"`
+	AssignedAt     pgtype.Timestamptz `db:"assigned_at" json:"assigned_at"`
+	ReviewedAt     pgtype.Timestamptz `db:"reviewed_at" json:"reviewed_at"`
+	FallbackTeamID pgtype.UUID        `db:"fallback_team_id" json:"fallback_team_id"`
+}
+
+type ReviewerReassignment struct {
+	ID            uuid.UUID          `db:"id" json:"id"`
+	PullRequestID uuid.UUID          `db:"pull_request_id" json:"pull_request_id"`
+	OldReviewerID uuid.UUID          `db:"old_reviewer_id" json:"old_reviewer_id"`
+	NewReviewerID uuid.UUID          `db:"new_reviewer_id" json:"new_reviewer_id"`
+	ReassignedAt  pgtype.Timestamptz `db:"reassigned_at" json:"reassigned_at"`
+}
+
+type ReviewerRotation struct {
+	TeamID         uuid.UUID          `db:"team_id" json:"team_id"`
+	LastReviewerID pgtype.UUID        `db:"last_reviewer_id" json:"last_reviewer_id"`
+	UpdatedAt      pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
 
 type Team struct {
-	ID   uuid.UUID `db:"id" json:"id"`
-	Name string    `db:"name" json:"name"`
+	ID                uuid.UUID   `db:"id" json:"id"`
+	Name              string      `db:"name" json:"name"`
+	MinReviewers      int32       `db:"min_reviewers" json:"min_reviewers"`
+	MaxReviewers      int32       `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey       string      `db:"external_key" json:"external_key"`
+	Version           int64       `db:"version" json:"version"`
+	RequiredApprovals int32       `db:"required_approvals" json:"required_approvals"`
+	ParentID          pgtype.UUID `db:"parent_id" json:"parent_id"`
 }
 
 type TeamUser struct {
-	TeamID uuid.UUID `db:"team_id" json:"team_id"`
-	UserID uuid.UUID `db:"user_id" json:"user_id"`
+	TeamID    uuid.UUID `db:"team_id" json:"team_id"`
+	UserID    uuid.UUID `db:"user_id" json:"user_id"`
+	IsPrimary bool      `db:"is_primary" json:"is_primary"`
 }
 
 type User struct {
-	ID     uuid.UUID `db:"id" json:"id"`
-	Name   string    `db:"name" json:"name"`
-	Active bool      `db:"active" json:"active"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Active      bool      `db:"active" json:"active"`
+	ExternalKey string    `db:"external_key" json:"external_key"`
+}
+
+type UserIdentity struct {
+	Provider string    `db:"provider" json:"provider"`
+	Login    string    `db:"login" json:"login"`
+	UserID   uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+type WebhookDelivery struct {
+	ID             uuid.UUID          `db:"id" json:"id"`
+	SubscriptionID uuid.UUID          `db:"subscription_id" json:"subscription_id"`
+	EventID        uuid.UUID          `db:"event_id" json:"event_id"`
+	EventType      string             `db:"event_type" json:"event_type"`
+	Payload        []byte             `db:"payload" json:"payload"`
+	Status         string             `db:"status" json:"status"`
+	Attempts       int32              `db:"attempts" json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `db:"next_attempt_at" json:"next_attempt_at"`
+	LastError      string             `db:"last_error" json:"last_error"`
+	CreatedAt      pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	DeliveredAt    pgtype.Timestamptz `db:"delivered_at" json:"delivered_at"`
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID          `db:"id" json:"id"`
+	URL        string             `db:"url" json:"url"`
+	Secret     string             `db:"secret" json:"secret"`
+	EventTypes []string           `db:"event_types" json:"event_types"`
+	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
 }
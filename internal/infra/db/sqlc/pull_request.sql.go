@@ -12,18 +12,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countOpenPullRequestsByTeamID = `-- name: CountOpenPullRequestsByTeamID :one
+SELECT COUNT(id) AS open_pull_requests
+FROM pull_request
+WHERE team_id = $1 AND status = 'open'
+`
+
+func (q *Queries) CountOpenPullRequestsByTeamID(ctx context.Context, teamID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countOpenPullRequestsByTeamID, teamID)
+	var open_pull_requests int64
+	err := row.Scan(&open_pull_requests)
+	return open_pull_requests, err
+}
+
 const createPullRequest = `-- name: CreatePullRequest :exec
-INSERT INTO pull_request (id, title, author_id, created_at, status, merged_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO pull_request (
+    id,
+    title,
+    author_id,
+    created_at,
+    status,
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 `
 
 type CreatePullRequestParams struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Title     string             `db:"title" json:"title"`
-	AuthorID  uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status    string             `db:"status" json:"status"`
-	MergedAt  pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	Title        string             `db:"title" json:"title"`
+	AuthorID     uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status       string             `db:"status" json:"status"`
+	MergedAt     pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey  string             `db:"external_key" json:"external_key"`
+	Version      int64              `db:"version" json:"version"`
+	TeamID       pgtype.UUID        `db:"team_id" json:"team_id"`
 }
 
 func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestParams) error {
@@ -34,6 +62,10 @@ func (q *Queries) CreatePullRequest(ctx context.Context, arg CreatePullRequestPa
 		arg.CreatedAt,
 		arg.Status,
 		arg.MergedAt,
+		arg.MaxReviewers,
+		arg.ExternalKey,
+		arg.Version,
+		arg.TeamID,
 	)
 	return err
 }
@@ -49,7 +81,19 @@ func (q *Queries) DeletePullRequest(ctx context.Context, id uuid.UUID) error {
 }
 
 const getPullRequest = `-- name: GetPullRequest :one
-SELECT id, title, author_id, created_at, status, merged_at FROM pull_request WHERE id = $1
+SELECT
+    id,
+    title,
+    author_id,
+    created_at,
+    status,
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
+FROM pull_request
+WHERE id = $1
 `
 
 func (q *Queries) GetPullRequest(ctx context.Context, id uuid.UUID) (PullRequest, error) {
@@ -62,12 +106,27 @@ func (q *Queries) GetPullRequest(ctx context.Context, id uuid.UUID) (PullRequest
 		&i.CreatedAt,
 		&i.Status,
 		&i.MergedAt,
+		&i.MaxReviewers,
+		&i.ExternalKey,
+		&i.Version,
+		&i.TeamID,
 	)
 	return i, err
 }
 
 const getPullRequests = `-- name: GetPullRequests :many
-SELECT id, title, author_id, created_at, status, merged_at FROM pull_request
+SELECT
+    id,
+    title,
+    author_id,
+    created_at,
+    status,
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
+FROM pull_request
 `
 
 func (q *Queries) GetPullRequests(ctx context.Context) ([]PullRequest, error) {
@@ -86,6 +145,10 @@ func (q *Queries) GetPullRequests(ctx context.Context) ([]PullRequest, error) {
 			&i.CreatedAt,
 			&i.Status,
 			&i.MergedAt,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.TeamID,
 		); err != nil {
 			return nil, err
 		}
@@ -97,31 +160,64 @@ func (q *Queries) GetPullRequests(ctx context.Context) ([]PullRequest, error) {
 	return items, nil
 }
 
-const updatePullRequest = `-- name: UpdatePullRequest :exec
-UPDATE pull_request
-SET title = $2, author_id = $3, created_at = $4, status = $5, merged_at = $6
+const lockPullRequest = `-- name: LockPullRequest :one
+SELECT id
+FROM pull_request
 WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockPullRequest(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockPullRequest, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const updatePullRequest = `-- name: UpdatePullRequest :execrows
+UPDATE pull_request
+SET
+    title = $2,
+    author_id = $3,
+    created_at = $4,
+    status = $5,
+    merged_at = $6,
+    max_reviewers = $7,
+    external_key = $8,
+    version = version + 1,
+    team_id = $10
+WHERE id = $1 AND version = $9
 `
 
 type UpdatePullRequestParams struct {
-	ID        uuid.UUID          `db:"id" json:"id"`
-	Title     string             `db:"title" json:"title"`
-	AuthorID  uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status    string             `db:"status" json:"status"`
-	MergedAt  pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	ID           uuid.UUID          `db:"id" json:"id"`
+	Title        string             `db:"title" json:"title"`
+	AuthorID     uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt    pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status       string             `db:"status" json:"status"`
+	MergedAt     pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey  string             `db:"external_key" json:"external_key"`
+	Version      int64              `db:"version" json:"version"`
+	TeamID       pgtype.UUID        `db:"team_id" json:"team_id"`
 }
 
-func (q *Queries) UpdatePullRequest(ctx context.Context, arg UpdatePullRequestParams) error {
-	_, err := q.db.Exec(ctx, updatePullRequest,
+func (q *Queries) UpdatePullRequest(ctx context.Context, arg UpdatePullRequestParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updatePullRequest,
 		arg.ID,
 		arg.Title,
 		arg.AuthorID,
 		arg.CreatedAt,
 		arg.Status,
 		arg.MergedAt,
+		arg.MaxReviewers,
+		arg.ExternalKey,
+		arg.Version,
+		arg.TeamID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updatePullRequestStatus = `-- name: UpdatePullRequestStatus :exec
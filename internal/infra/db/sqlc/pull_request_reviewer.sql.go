@@ -12,18 +12,68 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countOpenReviewsByTeamID = `-- name: CountOpenReviewsByTeamID :many
+SELECT
+    tu.user_id,
+    COUNT(pr.id) AS open_reviews
+FROM team_user AS tu
+LEFT JOIN pull_request_reviewer AS prr ON tu.user_id = prr.reviewer_id
+LEFT JOIN pull_request AS pr
+    ON prr.pull_request_id = pr.id AND pr.status = 'open'
+WHERE tu.team_id = $1
+GROUP BY tu.user_id
+`
+
+type CountOpenReviewsByTeamIDRow struct {
+	UserID      uuid.UUID `db:"user_id" json:"user_id"`
+	OpenReviews int64     `db:"open_reviews" json:"open_reviews"`
+}
+
+func (q *Queries) CountOpenReviewsByTeamID(ctx context.Context, teamID uuid.UUID) ([]CountOpenReviewsByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, countOpenReviewsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountOpenReviewsByTeamIDRow{}
+	for rows.Next() {
+		var i CountOpenReviewsByTeamIDRow
+		if err := rows.Scan(&i.UserID, &i.OpenReviews); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createPullRequestReviewer = `-- name: CreatePullRequestReviewer :exec
-INSERT INTO pull_request_reviewer (pull_request_id, reviewer_id)
-VALUES ($1, $2)
+INSERT INTO pull_request_reviewer (
+    pull_request_id, reviewer_id, state, assigned_at, reviewed_at, fallback_team_id
+)
+VALUES ($1, $2, $3, $4, $5, $6)
 `
 
 type CreatePullRequestReviewerParams struct {
-	PullRequestID uuid.UUID `db:"pull_request_id" json:"pull_request_id"`
-	ReviewerID    uuid.UUID `db:"reviewer_id" json:"reviewer_id"`
+	PullRequestID  uuid.UUID          `db:"pull_request_id" json:"pull_request_id"`
+	ReviewerID     uuid.UUID          `db:"reviewer_id" json:"reviewer_id"`
+	State          string             `db:"state" json:"state"`
+	AssignedAt     pgtype.Timestamptz `db:"assigned_at" json:"assigned_at"`
+	ReviewedAt     pgtype.Timestamptz `db:"reviewed_at" json:"reviewed_at"`
+	FallbackTeamID pgtype.UUID        `db:"fallback_team_id" json:"fallback_team_id"`
 }
 
 func (q *Queries) CreatePullRequestReviewer(ctx context.Context, arg CreatePullRequestReviewerParams) error {
-	_, err := q.db.Exec(ctx, createPullRequestReviewer, arg.PullRequestID, arg.ReviewerID)
+	_, err := q.db.Exec(ctx, createPullRequestReviewer,
+		arg.PullRequestID,
+		arg.ReviewerID,
+		arg.State,
+		arg.AssignedAt,
+		arg.ReviewedAt,
+		arg.FallbackTeamID,
+	)
 	return err
 }
 
@@ -63,7 +113,8 @@ func (q *Queries) DeletePullRequestReviewersByReviewerID(ctx context.Context, re
 }
 
 const getPullRequestReviewerReviewerIDs = `-- name: GetPullRequestReviewerReviewerIDs :many
-SELECT reviewer_id FROM pull_request_reviewer WHERE pull_request_id = $1
+SELECT reviewer_id FROM pull_request_reviewer
+WHERE pull_request_id = $1
 `
 
 func (q *Queries) GetPullRequestReviewerReviewerIDs(ctx context.Context, pullRequestID uuid.UUID) ([]uuid.UUID, error) {
@@ -86,33 +137,132 @@ func (q *Queries) GetPullRequestReviewerReviewerIDs(ctx context.Context, pullReq
 	return items, nil
 }
 
+const getPullRequestWithReviewersByExternalKey = `-- name: GetPullRequestWithReviewersByExternalKey :many
+SELECT
+    pr.id,
+    pr.title,
+    pr.author_id,
+    pr.created_at,
+    pr.status,
+    pr.merged_at,
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
+FROM
+    pull_request AS pr
+LEFT JOIN
+    pull_request_reviewer AS prr
+    ON pr.id = prr.pull_request_id
+WHERE
+    pr.external_key = $1
+ORDER BY
+    pr.id, prr.assigned_at, prr.reviewer_id
+`
+
+type GetPullRequestWithReviewersByExternalKeyRow struct {
+	ID                     uuid.UUID          `db:"id" json:"id"`
+	Title                  string             `db:"title" json:"title"`
+	AuthorID               uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt              pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status                 string             `db:"status" json:"status"`
+	MergedAt               pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers           int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey            string             `db:"external_key" json:"external_key"`
+	Version                int64              `db:"version" json:"version"`
+	TeamID                 pgtype.UUID        `db:"team_id" json:"team_id"`
+	ReviewerID             pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ReviewerState          pgtype.Text        `db:"reviewer_state" json:"reviewer_state"`
+	ReviewerAssignedAt     pgtype.Timestamptz `db:"reviewer_assigned_at" json:"reviewer_assigned_at"`
+	ReviewerReviewedAt     pgtype.Timestamptz `db:"reviewer_reviewed_at" json:"reviewer_reviewed_at"`
+	ReviewerFallbackTeamID pgtype.UUID        `db:"reviewer_fallback_team_id" json:"reviewer_fallback_team_id"`
+}
+
+func (q *Queries) GetPullRequestWithReviewersByExternalKey(ctx context.Context, externalKey string) ([]GetPullRequestWithReviewersByExternalKeyRow, error) {
+	rows, err := q.db.Query(ctx, getPullRequestWithReviewersByExternalKey, externalKey)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetPullRequestWithReviewersByExternalKeyRow{}
+	for rows.Next() {
+		var i GetPullRequestWithReviewersByExternalKeyRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.AuthorID,
+			&i.CreatedAt,
+			&i.Status,
+			&i.MergedAt,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.TeamID,
+			&i.ReviewerID,
+			&i.ReviewerState,
+			&i.ReviewerAssignedAt,
+			&i.ReviewerReviewedAt,
+			&i.ReviewerFallbackTeamID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getPullRequestWithReviewersByID = `-- name: GetPullRequestWithReviewersByID :many
-SELECT 
+SELECT
     pr.id,
     pr.title,
     pr.author_id,
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
-FROM 
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
+FROM
     pull_request AS pr
-LEFT JOIN 
-    pull_request_reviewer AS prr ON pr.id = prr.pull_request_id
+LEFT JOIN
+    pull_request_reviewer AS prr
+    ON pr.id = prr.pull_request_id
 WHERE
     pr.id = $1
-ORDER BY 
-    pr.id, prr.reviewer_id
+ORDER BY
+    pr.id, prr.assigned_at, prr.reviewer_id
 `
 
 type GetPullRequestWithReviewersByIDRow struct {
-	ID         uuid.UUID          `db:"id" json:"id"`
-	Title      string             `db:"title" json:"title"`
-	AuthorID   uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status     string             `db:"status" json:"status"`
-	MergedAt   pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
-	ReviewerID pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ID                     uuid.UUID          `db:"id" json:"id"`
+	Title                  string             `db:"title" json:"title"`
+	AuthorID               uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt              pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status                 string             `db:"status" json:"status"`
+	MergedAt               pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers           int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey            string             `db:"external_key" json:"external_key"`
+	Version                int64              `db:"version" json:"version"`
+	TeamID                 pgtype.UUID        `db:"team_id" json:"team_id"`
+	ReviewerID             pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ReviewerState          pgtype.Text        `db:"reviewer_state" json:"reviewer_state"`
+	ReviewerAssignedAt     pgtype.Timestamptz `db:"reviewer_assigned_at" json:"reviewer_assigned_at"`
+	ReviewerReviewedAt     pgtype.Timestamptz `db:"reviewer_reviewed_at" json:"reviewer_reviewed_at"`
+	ReviewerFallbackTeamID pgtype.UUID        `db:"reviewer_fallback_team_id" json:"reviewer_fallback_team_id"`
 }
 
 func (q *Queries) GetPullRequestWithReviewersByID(ctx context.Context, id uuid.UUID) ([]GetPullRequestWithReviewersByIDRow, error) {
@@ -131,7 +281,15 @@ func (q *Queries) GetPullRequestWithReviewersByID(ctx context.Context, id uuid.U
 			&i.CreatedAt,
 			&i.Status,
 			&i.MergedAt,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.TeamID,
 			&i.ReviewerID,
+			&i.ReviewerState,
+			&i.ReviewerAssignedAt,
+			&i.ReviewerReviewedAt,
+			&i.ReviewerFallbackTeamID,
 		); err != nil {
 			return nil, err
 		}
@@ -144,7 +302,12 @@ func (q *Queries) GetPullRequestWithReviewersByID(ctx context.Context, id uuid.U
 }
 
 const getPullRequestsByReviewer = `-- name: GetPullRequestsByReviewer :many
-SELECT pr.id, pr.title, pr.author_id, pr.status, pr.merged_at
+SELECT
+    pr.id,
+    pr.title,
+    pr.author_id,
+    pr.status,
+    pr.merged_at
 FROM pull_request pr
 JOIN pull_request_reviewer prr ON pr.id = prr.pull_request_id
 WHERE prr.reviewer_id = $1
@@ -185,30 +348,47 @@ func (q *Queries) GetPullRequestsByReviewer(ctx context.Context, reviewerID uuid
 }
 
 const getPullRequestsWithReviewers = `-- name: GetPullRequestsWithReviewers :many
-SELECT 
+SELECT
     pr.id,
     pr.title,
     pr.author_id,
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
-FROM 
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
+FROM
     pull_request AS pr
-LEFT JOIN 
-    pull_request_reviewer AS prr ON pr.id = prr.pull_request_id
-ORDER BY 
-    pr.id, prr.reviewer_id
+LEFT JOIN
+    pull_request_reviewer AS prr
+    ON pr.id = prr.pull_request_id
+ORDER BY
+    pr.id, prr.assigned_at, prr.reviewer_id
 `
 
 type GetPullRequestsWithReviewersRow struct {
-	ID         uuid.UUID          `db:"id" json:"id"`
-	Title      string             `db:"title" json:"title"`
-	AuthorID   uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status     string             `db:"status" json:"status"`
-	MergedAt   pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
-	ReviewerID pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ID                     uuid.UUID          `db:"id" json:"id"`
+	Title                  string             `db:"title" json:"title"`
+	AuthorID               uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt              pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status                 string             `db:"status" json:"status"`
+	MergedAt               pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers           int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey            string             `db:"external_key" json:"external_key"`
+	Version                int64              `db:"version" json:"version"`
+	TeamID                 pgtype.UUID        `db:"team_id" json:"team_id"`
+	ReviewerID             pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ReviewerState          pgtype.Text        `db:"reviewer_state" json:"reviewer_state"`
+	ReviewerAssignedAt     pgtype.Timestamptz `db:"reviewer_assigned_at" json:"reviewer_assigned_at"`
+	ReviewerReviewedAt     pgtype.Timestamptz `db:"reviewer_reviewed_at" json:"reviewer_reviewed_at"`
+	ReviewerFallbackTeamID pgtype.UUID        `db:"reviewer_fallback_team_id" json:"reviewer_fallback_team_id"`
 }
 
 func (q *Queries) GetPullRequestsWithReviewers(ctx context.Context) ([]GetPullRequestsWithReviewersRow, error) {
@@ -227,7 +407,15 @@ func (q *Queries) GetPullRequestsWithReviewers(ctx context.Context) ([]GetPullRe
 			&i.CreatedAt,
 			&i.Status,
 			&i.MergedAt,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.TeamID,
 			&i.ReviewerID,
+			&i.ReviewerState,
+			&i.ReviewerAssignedAt,
+			&i.ReviewerReviewedAt,
+			&i.ReviewerFallbackTeamID,
 		); err != nil {
 			return nil, err
 		}
@@ -240,32 +428,49 @@ func (q *Queries) GetPullRequestsWithReviewers(ctx context.Context) ([]GetPullRe
 }
 
 const getPullRequestsWithReviewersByReviewerID = `-- name: GetPullRequestsWithReviewersByReviewerID :many
-SELECT 
+SELECT
     pr.id,
     pr.title,
     pr.author_id,
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
-FROM 
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
+FROM
     pull_request AS pr
-LEFT JOIN 
-    pull_request_reviewer AS prr ON pr.id = prr.pull_request_id
+LEFT JOIN
+    pull_request_reviewer AS prr
+    ON pr.id = prr.pull_request_id
 WHERE
     prr.reviewer_id = $1
-ORDER BY 
-    pr.id, prr.reviewer_id
+ORDER BY
+    pr.id, prr.assigned_at, prr.reviewer_id
 `
 
 type GetPullRequestsWithReviewersByReviewerIDRow struct {
-	ID         uuid.UUID          `db:"id" json:"id"`
-	Title      string             `db:"title" json:"title"`
-	AuthorID   uuid.UUID          `db:"author_id" json:"author_id"`
-	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	Status     string             `db:"status" json:"status"`
-	MergedAt   pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
-	ReviewerID pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ID                     uuid.UUID          `db:"id" json:"id"`
+	Title                  string             `db:"title" json:"title"`
+	AuthorID               uuid.UUID          `db:"author_id" json:"author_id"`
+	CreatedAt              pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	Status                 string             `db:"status" json:"status"`
+	MergedAt               pgtype.Timestamptz `db:"merged_at" json:"merged_at"`
+	MaxReviewers           int32              `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey            string             `db:"external_key" json:"external_key"`
+	Version                int64              `db:"version" json:"version"`
+	TeamID                 pgtype.UUID        `db:"team_id" json:"team_id"`
+	ReviewerID             pgtype.UUID        `db:"reviewer_id" json:"reviewer_id"`
+	ReviewerState          pgtype.Text        `db:"reviewer_state" json:"reviewer_state"`
+	ReviewerAssignedAt     pgtype.Timestamptz `db:"reviewer_assigned_at" json:"reviewer_assigned_at"`
+	ReviewerReviewedAt     pgtype.Timestamptz `db:"reviewer_reviewed_at" json:"reviewer_reviewed_at"`
+	ReviewerFallbackTeamID pgtype.UUID        `db:"reviewer_fallback_team_id" json:"reviewer_fallback_team_id"`
 }
 
 func (q *Queries) GetPullRequestsWithReviewersByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]GetPullRequestsWithReviewersByReviewerIDRow, error) {
@@ -284,7 +489,15 @@ func (q *Queries) GetPullRequestsWithReviewersByReviewerID(ctx context.Context,
 			&i.CreatedAt,
 			&i.Status,
 			&i.MergedAt,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.TeamID,
 			&i.ReviewerID,
+			&i.ReviewerState,
+			&i.ReviewerAssignedAt,
+			&i.ReviewerReviewedAt,
+			&i.ReviewerFallbackTeamID,
 		); err != nil {
 			return nil, err
 		}
@@ -8,46 +8,87 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AdvanceReviewerRotation(ctx context.Context, arg AdvanceReviewerRotationParams) (int64, error)
+	ClaimDueForgeCalls(ctx context.Context, arg ClaimDueForgeCallsParams) ([]ForgeCall, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ClaimInboundDelivery(ctx context.Context, arg ClaimInboundDeliveryParams) (int64, error)
+	ClearPrimaryTeam(ctx context.Context, userID uuid.UUID) error
+	CountOpenPullRequestsByTeamID(ctx context.Context, teamID uuid.UUID) (int64, error)
+	CountOpenReviewsByTeamID(ctx context.Context, teamID uuid.UUID) ([]CountOpenReviewsByTeamIDRow, error)
+	CreateAssignmentEvent(ctx context.Context, arg CreateAssignmentEventParams) error
+	CreateForgeCall(ctx context.Context, arg CreateForgeCallParams) error
+	CreateOutboxMessage(ctx context.Context, arg CreateOutboxMessageParams) error
 	CreatePullRequest(ctx context.Context, arg CreatePullRequestParams) error
 	CreatePullRequestReviewer(ctx context.Context, arg CreatePullRequestReviewerParams) error
+	CreateReviewerReassignment(ctx context.Context, arg CreateReviewerReassignmentParams) error
 	CreateTeam(ctx context.Context, arg CreateTeamParams) error
 	CreateTeamUser(ctx context.Context, arg CreateTeamUserParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) error
 	DeletePullRequest(ctx context.Context, id uuid.UUID) error
 	DeletePullRequestReviewer(ctx context.Context, arg DeletePullRequestReviewerParams) error
 	DeletePullRequestReviewersByPRID(ctx context.Context, pullRequestID uuid.UUID) error
 	DeletePullRequestReviewersByReviewerID(ctx context.Context, reviewerID uuid.UUID) error
 	DeleteTeam(ctx context.Context, id uuid.UUID) error
 	DeleteTeamUsersByTeamID(ctx context.Context, teamID uuid.UUID) error
+	DeleteTeamUsersExcept(ctx context.Context, arg DeleteTeamUsersExceptParams) ([]uuid.UUID, error)
+	DeleteTeamWithVersion(ctx context.Context, arg DeleteTeamWithVersionParams) (int64, error)
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserIdentityByUserID(ctx context.Context, arg DeleteUserIdentityByUserIDParams) error
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
 	GetActiveUsersInTeam(ctx context.Context, teamID uuid.UUID) ([]User, error)
+	GetAssignmentEventsByPullRequestID(ctx context.Context, pullRequestID pgtype.UUID) ([]AssignmentEvent, error)
+	GetPendingOutboxEventsForUpdate(ctx context.Context, limit int32) ([]AssignmentEvent, error)
 	GetPullRequest(ctx context.Context, id uuid.UUID) (PullRequest, error)
+	GetPullRequestReviewStats(ctx context.Context, teamID pgtype.UUID) ([]GetPullRequestReviewStatsRow, error)
 	GetPullRequestReviewerReviewerIDs(ctx context.Context, pullRequestID uuid.UUID) ([]uuid.UUID, error)
+	GetPullRequestWithReviewersByExternalKey(ctx context.Context, externalKey string) ([]GetPullRequestWithReviewersByExternalKeyRow, error)
 	GetPullRequestWithReviewersByID(ctx context.Context, id uuid.UUID) ([]GetPullRequestWithReviewersByIDRow, error)
 	GetPullRequests(ctx context.Context) ([]PullRequest, error)
 	GetPullRequestsByReviewer(ctx context.Context, reviewerID uuid.UUID) ([]GetPullRequestsByReviewerRow, error)
 	GetPullRequestsWithReviewers(ctx context.Context) ([]GetPullRequestsWithReviewersRow, error)
 	GetPullRequestsWithReviewersByReviewerID(ctx context.Context, reviewerID uuid.UUID) ([]GetPullRequestsWithReviewersByReviewerIDRow, error)
+	GetReviewerRotation(ctx context.Context, teamID uuid.UUID) (pgtype.UUID, error)
 	GetTeam(ctx context.Context, id uuid.UUID) (Team, error)
+	GetTeamAncestors(ctx context.Context, id uuid.UUID) ([]Team, error)
+	GetTeamByExternalKey(ctx context.Context, externalKey string) (Team, error)
 	GetTeamByName(ctx context.Context, name string) (Team, error)
 	GetTeamForUser(ctx context.Context, userID uuid.UUID) (Team, error)
-	GetTeamIDForUser(ctx context.Context, userID uuid.UUID) (uuid.UUID, error)
 	GetTeams(ctx context.Context) ([]Team, error)
+	GetTeamsForUser(ctx context.Context, userID uuid.UUID) ([]Team, error)
 	GetTeamsWithUsers(ctx context.Context) ([]GetTeamsWithUsersRow, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	GetUserByExternalKey(ctx context.Context, externalKey string) (User, error)
 	GetUserByName(ctx context.Context, name string) (User, error)
 	GetUserIDsInTeam(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error)
+	GetUserIdentitiesByUserIDs(ctx context.Context, arg GetUserIdentitiesByUserIDsParams) ([]UserIdentity, error)
+	GetUserIdentityByLogin(ctx context.Context, arg GetUserIdentityByLoginParams) (UserIdentity, error)
+	GetUserReviewStats(ctx context.Context, teamID pgtype.UUID) ([]GetUserReviewStatsRow, error)
 	GetUsers(ctx context.Context) ([]User, error)
+	GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error)
 	GetUsersInTeam(ctx context.Context, teamID uuid.UUID) ([]User, error)
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	GetWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	InitReviewerRotation(ctx context.Context, teamID uuid.UUID) error
+	LockPullRequest(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	MarkOutboxMessagesPublished(ctx context.Context, arg MarkOutboxMessagesPublishedParams) error
 	RemoveUserFromTeam(ctx context.Context, arg RemoveUserFromTeamParams) error
-	UpdatePullRequest(ctx context.Context, arg UpdatePullRequestParams) error
+	RestorePrimaryTeams(ctx context.Context, userIds []uuid.UUID) error
+	SetPrimaryTeam(ctx context.Context, arg SetPrimaryTeamParams) (int64, error)
+	UpdateForgeCall(ctx context.Context, arg UpdateForgeCallParams) error
+	UpdatePullRequest(ctx context.Context, arg UpdatePullRequestParams) (int64, error)
 	UpdatePullRequestStatus(ctx context.Context, arg UpdatePullRequestStatusParams) error
-	UpdateTeam(ctx context.Context, arg UpdateTeamParams) error
+	UpdateTeam(ctx context.Context, arg UpdateTeamParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateWebhookDelivery(ctx context.Context, arg UpdateWebhookDeliveryParams) error
 	UpsertUser(ctx context.Context, arg UpsertUserParams) error
+	UpsertUserIdentity(ctx context.Context, arg UpsertUserIdentityParams) error
 }
 
 var _ Querier = (*Queries)(nil)
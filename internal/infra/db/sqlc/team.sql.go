@@ -9,25 +9,51 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createTeam = `-- name: CreateTeam :exec
-INSERT INTO team (id, name)
-VALUES ($1, $2)
+INSERT INTO team (
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreateTeamParams struct {
-	ID   uuid.UUID `db:"id" json:"id"`
-	Name string    `db:"name" json:"name"`
+	ID                uuid.UUID   `db:"id" json:"id"`
+	Name              string      `db:"name" json:"name"`
+	MinReviewers      int32       `db:"min_reviewers" json:"min_reviewers"`
+	MaxReviewers      int32       `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey       string      `db:"external_key" json:"external_key"`
+	Version           int64       `db:"version" json:"version"`
+	RequiredApprovals int32       `db:"required_approvals" json:"required_approvals"`
+	ParentID          pgtype.UUID `db:"parent_id" json:"parent_id"`
 }
 
 func (q *Queries) CreateTeam(ctx context.Context, arg CreateTeamParams) error {
-	_, err := q.db.Exec(ctx, createTeam, arg.ID, arg.Name)
+	_, err := q.db.Exec(ctx, createTeam,
+		arg.ID,
+		arg.Name,
+		arg.MinReviewers,
+		arg.MaxReviewers,
+		arg.ExternalKey,
+		arg.Version,
+		arg.RequiredApprovals,
+		arg.ParentID,
+	)
 	return err
 }
 
 const deleteTeam = `-- name: DeleteTeam :exec
-DELETE FROM team WHERE id = $1
+DELETE FROM team
+WHERE id = $1
 `
 
 func (q *Queries) DeleteTeam(ctx context.Context, id uuid.UUID) error {
@@ -35,30 +61,200 @@ func (q *Queries) DeleteTeam(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const deleteTeamWithVersion = `-- name: DeleteTeamWithVersion :execrows
+DELETE FROM team
+WHERE id = $1 AND version = $2
+`
+
+type DeleteTeamWithVersionParams struct {
+	ID      uuid.UUID `db:"id" json:"id"`
+	Version int64     `db:"version" json:"version"`
+}
+
+func (q *Queries) DeleteTeamWithVersion(ctx context.Context, arg DeleteTeamWithVersionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTeamWithVersion, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getTeam = `-- name: GetTeam :one
-SELECT id, name FROM team WHERE id = $1
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM team
+WHERE id = $1
 `
 
 func (q *Queries) GetTeam(ctx context.Context, id uuid.UUID) (Team, error) {
 	row := q.db.QueryRow(ctx, getTeam, id)
 	var i Team
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MinReviewers,
+		&i.MaxReviewers,
+		&i.ExternalKey,
+		&i.Version,
+		&i.RequiredApprovals,
+		&i.ParentID,
+	)
+	return i, err
+}
+
+const getTeamAncestors = `-- name: GetTeamAncestors :many
+WITH RECURSIVE ancestor AS (
+    SELECT
+        p.id,
+        p.name,
+        p.min_reviewers,
+        p.max_reviewers,
+        p.external_key,
+        p.version,
+        p.required_approvals,
+        p.parent_id,
+        1 AS depth,
+        ARRAY[c.id, p.id] AS path
+    FROM team AS c
+    INNER JOIN team AS p ON c.parent_id = p.id
+    WHERE c.id = $1
+    UNION ALL
+    SELECT
+        p.id,
+        p.name,
+        p.min_reviewers,
+        p.max_reviewers,
+        p.external_key,
+        p.version,
+        p.required_approvals,
+        p.parent_id,
+        a.depth + 1,
+        a.path || p.id
+    FROM ancestor AS a
+    INNER JOIN team AS p ON a.parent_id = p.id
+    -- guards against cycles, though they are rejected by application
+    WHERE NOT p.id = ANY(a.path)
+)
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM ancestor
+ORDER BY depth
+`
+
+func (q *Queries) GetTeamAncestors(ctx context.Context, id uuid.UUID) ([]Team, error) {
+	rows, err := q.db.Query(ctx, getTeamAncestors, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Team{}
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MinReviewers,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.RequiredApprovals,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTeamByExternalKey = `-- name: GetTeamByExternalKey :one
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM team
+WHERE external_key = $1
+`
+
+func (q *Queries) GetTeamByExternalKey(ctx context.Context, externalKey string) (Team, error) {
+	row := q.db.QueryRow(ctx, getTeamByExternalKey, externalKey)
+	var i Team
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MinReviewers,
+		&i.MaxReviewers,
+		&i.ExternalKey,
+		&i.Version,
+		&i.RequiredApprovals,
+		&i.ParentID,
+	)
 	return i, err
 }
 
 const getTeamByName = `-- name: GetTeamByName :one
-SELECT id, name FROM team WHERE name = $1
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM team
+WHERE name = $1
 `
 
 func (q *Queries) GetTeamByName(ctx context.Context, name string) (Team, error) {
 	row := q.db.QueryRow(ctx, getTeamByName, name)
 	var i Team
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MinReviewers,
+		&i.MaxReviewers,
+		&i.ExternalKey,
+		&i.Version,
+		&i.RequiredApprovals,
+		&i.ParentID,
+	)
 	return i, err
 }
 
 const getTeams = `-- name: GetTeams :many
-SELECT id, name FROM team
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM team
 `
 
 func (q *Queries) GetTeams(ctx context.Context) ([]Team, error) {
@@ -70,7 +266,16 @@ func (q *Queries) GetTeams(ctx context.Context) ([]Team, error) {
 	items := []Team{}
 	for rows.Next() {
 		var i Team
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MinReviewers,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.RequiredApprovals,
+			&i.ParentID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -81,18 +286,43 @@ func (q *Queries) GetTeams(ctx context.Context) ([]Team, error) {
 	return items, nil
 }
 
-const updateTeam = `-- name: UpdateTeam :exec
+const updateTeam = `-- name: UpdateTeam :execrows
 UPDATE team
-SET name = $2
-WHERE id = $1
+SET
+    name = $2,
+    min_reviewers = $3,
+    max_reviewers = $4,
+    external_key = $5,
+    required_approvals = $7,
+    parent_id = $8,
+    version = version + 1
+WHERE id = $1 AND version = $6
 `
 
 type UpdateTeamParams struct {
-	ID   uuid.UUID `db:"id" json:"id"`
-	Name string    `db:"name" json:"name"`
+	ID                uuid.UUID   `db:"id" json:"id"`
+	Name              string      `db:"name" json:"name"`
+	MinReviewers      int32       `db:"min_reviewers" json:"min_reviewers"`
+	MaxReviewers      int32       `db:"max_reviewers" json:"max_reviewers"`
+	ExternalKey       string      `db:"external_key" json:"external_key"`
+	Version           int64       `db:"version" json:"version"`
+	RequiredApprovals int32       `db:"required_approvals" json:"required_approvals"`
+	ParentID          pgtype.UUID `db:"parent_id" json:"parent_id"`
 }
 
-func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) error {
-	_, err := q.db.Exec(ctx, updateTeam, arg.ID, arg.Name)
-	return err
+func (q *Queries) UpdateTeam(ctx context.Context, arg UpdateTeamParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTeam,
+		arg.ID,
+		arg.Name,
+		arg.MinReviewers,
+		arg.MaxReviewers,
+		arg.ExternalKey,
+		arg.Version,
+		arg.RequiredApprovals,
+		arg.ParentID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
@@ -12,9 +12,25 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clearPrimaryTeam = `-- name: ClearPrimaryTeam :exec
+UPDATE team_user
+SET is_primary = FALSE
+WHERE user_id = $1 AND is_primary
+`
+
+func (q *Queries) ClearPrimaryTeam(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, clearPrimaryTeam, userID)
+	return err
+}
+
 const createTeamUser = `-- name: CreateTeamUser :exec
-INSERT INTO team_user (team_id, user_id)
-VALUES ($1, $2)
+INSERT INTO team_user (team_id, user_id, is_primary)
+VALUES (
+    $1,
+    $2,
+    NOT EXISTS (SELECT 1 FROM team_user WHERE user_id = $2)
+)
+ON CONFLICT (team_id, user_id) DO NOTHING
 `
 
 type CreateTeamUserParams struct {
@@ -37,11 +53,49 @@ func (q *Queries) DeleteTeamUsersByTeamID(ctx context.Context, teamID uuid.UUID)
 	return err
 }
 
+const deleteTeamUsersExcept = `-- name: DeleteTeamUsersExcept :many
+DELETE FROM team_user
+WHERE
+    team_id = $1
+    AND NOT (user_id = ANY($2::uuid []))
+RETURNING user_id
+`
+
+type DeleteTeamUsersExceptParams struct {
+	TeamID  uuid.UUID   `db:"team_id" json:"team_id"`
+	UserIds []uuid.UUID `db:"user_ids" json:"user_ids"`
+}
+
+func (q *Queries) DeleteTeamUsersExcept(ctx context.Context, arg DeleteTeamUsersExceptParams) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, deleteTeamUsersExcept, arg.TeamID, arg.UserIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var user_id uuid.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getActiveUsersInTeam = `-- name: GetActiveUsersInTeam :many
-SELECT u.id, u.name, u.active
+SELECT
+    u.id,
+    u.name,
+    u.active,
+    u.external_key
 FROM "user" u
 JOIN team_user tu ON u.id = tu.user_id
 WHERE tu.team_id = $1 AND u.active = true
+ORDER BY u.id
 `
 
 func (q *Queries) GetActiveUsersInTeam(ctx context.Context, teamID uuid.UUID) ([]User, error) {
@@ -53,7 +107,12 @@ func (q *Queries) GetActiveUsersInTeam(ctx context.Context, teamID uuid.UUID) ([
 	items := []User{}
 	for rows.Next() {
 		var i User
-		if err := rows.Scan(&i.ID, &i.Name, &i.Active); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Active,
+			&i.ExternalKey,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -65,40 +124,96 @@ func (q *Queries) GetActiveUsersInTeam(ctx context.Context, teamID uuid.UUID) ([
 }
 
 const getTeamForUser = `-- name: GetTeamForUser :one
-SELECT t.id, t.name
+SELECT
+    t.id,
+    t.name,
+    t.min_reviewers,
+    t.max_reviewers,
+    t.external_key,
+    t.version,
+    t.required_approvals,
+    t.parent_id
 FROM team t
 JOIN team_user tu ON t.id = tu.team_id
 WHERE tu.user_id = $1
+ORDER BY tu.is_primary DESC, t.name
+LIMIT 1
 `
 
 func (q *Queries) GetTeamForUser(ctx context.Context, userID uuid.UUID) (Team, error) {
 	row := q.db.QueryRow(ctx, getTeamForUser, userID)
 	var i Team
-	err := row.Scan(&i.ID, &i.Name)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.MinReviewers,
+		&i.MaxReviewers,
+		&i.ExternalKey,
+		&i.Version,
+		&i.RequiredApprovals,
+		&i.ParentID,
+	)
 	return i, err
 }
 
-const getTeamIDForUser = `-- name: GetTeamIDForUser :one
-SELECT t.id
+const getTeamsForUser = `-- name: GetTeamsForUser :many
+SELECT
+    t.id,
+    t.name,
+    t.min_reviewers,
+    t.max_reviewers,
+    t.external_key,
+    t.version,
+    t.required_approvals,
+    t.parent_id
 FROM team t
 JOIN team_user tu ON t.id = tu.team_id
 WHERE tu.user_id = $1
+ORDER BY tu.is_primary DESC, t.name
 `
 
-func (q *Queries) GetTeamIDForUser(ctx context.Context, userID uuid.UUID) (uuid.UUID, error) {
-	row := q.db.QueryRow(ctx, getTeamIDForUser, userID)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+func (q *Queries) GetTeamsForUser(ctx context.Context, userID uuid.UUID) ([]Team, error) {
+	rows, err := q.db.Query(ctx, getTeamsForUser, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Team{}
+	for rows.Next() {
+		var i Team
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.MinReviewers,
+			&i.MaxReviewers,
+			&i.ExternalKey,
+			&i.Version,
+			&i.RequiredApprovals,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getTeamsWithUsers = `-- name: GetTeamsWithUsers :many
-SELECT 
-    t.id as team_id,
-    t.name as team_name,
-    u.id as user_id,
-    u.name as user_name,
-    u.active as user_active
+SELECT
+    t.id AS team_id,
+    t.name AS team_name,
+    t.min_reviewers AS team_min_reviewers,
+    t.max_reviewers AS team_max_reviewers,
+    t.external_key AS team_external_key,
+    t.version AS team_version,
+    t.required_approvals AS team_required_approvals,
+    t.parent_id AS team_parent_id,
+    u.id AS user_id,
+    u.name AS user_name,
+    u.active AS user_active
 FROM team t
 LEFT JOIN team_user tu ON t.id = tu.team_id
 LEFT JOIN "user" u ON tu.user_id = u.id
@@ -106,11 +221,17 @@ ORDER BY t.id
 `
 
 type GetTeamsWithUsersRow struct {
-	TeamID     uuid.UUID   `db:"team_id" json:"team_id"`
-	TeamName   string      `db:"team_name" json:"team_name"`
-	UserID     pgtype.UUID `db:"user_id" json:"user_id"`
-	UserName   pgtype.Text `db:"user_name" json:"user_name"`
-	UserActive pgtype.Bool `db:"user_active" json:"user_active"`
+	TeamID                uuid.UUID   `db:"team_id" json:"team_id"`
+	TeamName              string      `db:"team_name" json:"team_name"`
+	TeamMinReviewers      int32       `db:"team_min_reviewers" json:"team_min_reviewers"`
+	TeamMaxReviewers      int32       `db:"team_max_reviewers" json:"team_max_reviewers"`
+	TeamExternalKey       string      `db:"team_external_key" json:"team_external_key"`
+	TeamVersion           int64       `db:"team_version" json:"team_version"`
+	TeamRequiredApprovals int32       `db:"team_required_approvals" json:"team_required_approvals"`
+	TeamParentID          pgtype.UUID `db:"team_parent_id" json:"team_parent_id"`
+	UserID                pgtype.UUID `db:"user_id" json:"user_id"`
+	UserName              pgtype.Text `db:"user_name" json:"user_name"`
+	UserActive            pgtype.Bool `db:"user_active" json:"user_active"`
 }
 
 func (q *Queries) GetTeamsWithUsers(ctx context.Context) ([]GetTeamsWithUsersRow, error) {
@@ -125,6 +246,12 @@ func (q *Queries) GetTeamsWithUsers(ctx context.Context) ([]GetTeamsWithUsersRow
 		if err := rows.Scan(
 			&i.TeamID,
 			&i.TeamName,
+			&i.TeamMinReviewers,
+			&i.TeamMaxReviewers,
+			&i.TeamExternalKey,
+			&i.TeamVersion,
+			&i.TeamRequiredApprovals,
+			&i.TeamParentID,
 			&i.UserID,
 			&i.UserName,
 			&i.UserActive,
@@ -143,6 +270,7 @@ const getUserIDsInTeam = `-- name: GetUserIDsInTeam :many
 SELECT user_id
 FROM team_user
 WHERE team_id = $1
+ORDER BY user_id
 `
 
 func (q *Queries) GetUserIDsInTeam(ctx context.Context, teamID uuid.UUID) ([]uuid.UUID, error) {
@@ -166,7 +294,11 @@ func (q *Queries) GetUserIDsInTeam(ctx context.Context, teamID uuid.UUID) ([]uui
 }
 
 const getUsersInTeam = `-- name: GetUsersInTeam :many
-SELECT u.id, u.name, u.active
+SELECT
+    u.id,
+    u.name,
+    u.active,
+    u.external_key
 FROM "user" u
 JOIN team_user tu ON u.id = tu.user_id
 WHERE tu.team_id = $1
@@ -181,7 +313,12 @@ func (q *Queries) GetUsersInTeam(ctx context.Context, teamID uuid.UUID) ([]User,
 	items := []User{}
 	for rows.Next() {
 		var i User
-		if err := rows.Scan(&i.ID, &i.Name, &i.Active); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Active,
+			&i.ExternalKey,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -206,3 +343,47 @@ func (q *Queries) RemoveUserFromTeam(ctx context.Context, arg RemoveUserFromTeam
 	_, err := q.db.Exec(ctx, removeUserFromTeam, arg.TeamID, arg.UserID)
 	return err
 }
+
+const restorePrimaryTeams = `-- name: RestorePrimaryTeams :exec
+UPDATE team_user AS tu
+SET is_primary = TRUE
+FROM (
+    SELECT DISTINCT ON (m.user_id)
+        m.team_id,
+        m.user_id
+    FROM team_user AS m
+    INNER JOIN team AS t ON m.team_id = t.id
+    WHERE
+        m.user_id = ANY($1::uuid [])
+        AND NOT EXISTS (
+            SELECT 1 FROM team_user AS p
+            WHERE p.user_id = m.user_id AND p.is_primary
+        )
+    ORDER BY m.user_id, t.name
+) AS next
+WHERE tu.team_id = next.team_id AND tu.user_id = next.user_id
+`
+
+func (q *Queries) RestorePrimaryTeams(ctx context.Context, userIds []uuid.UUID) error {
+	_, err := q.db.Exec(ctx, restorePrimaryTeams, userIds)
+	return err
+}
+
+const setPrimaryTeam = `-- name: SetPrimaryTeam :execrows
+UPDATE team_user
+SET is_primary = TRUE
+WHERE team_id = $1 AND user_id = $2
+`
+
+type SetPrimaryTeamParams struct {
+	TeamID uuid.UUID `db:"team_id" json:"team_id"`
+	UserID uuid.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) SetPrimaryTeam(ctx context.Context, arg SetPrimaryTeamParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setPrimaryTeam, arg.TeamID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
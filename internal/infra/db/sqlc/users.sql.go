@@ -12,23 +12,30 @@ import (
 )
 
 const createUser = `-- name: CreateUser :exec
-INSERT INTO "user" (id, name, active)
-VALUES ($1, $2, $3)
+INSERT INTO "user" (id, name, active, external_key)
+VALUES ($1, $2, $3, $4)
 `
 
 type CreateUserParams struct {
-	ID     uuid.UUID `db:"id" json:"id"`
-	Name   string    `db:"name" json:"name"`
-	Active bool      `db:"active" json:"active"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Active      bool      `db:"active" json:"active"`
+	ExternalKey string    `db:"external_key" json:"external_key"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) error {
-	_, err := q.db.Exec(ctx, createUser, arg.ID, arg.Name, arg.Active)
+	_, err := q.db.Exec(ctx, createUser,
+		arg.ID,
+		arg.Name,
+		arg.Active,
+		arg.ExternalKey,
+	)
 	return err
 }
 
 const deleteUser = `-- name: DeleteUser :exec
-DELETE FROM "user" WHERE id = $1
+DELETE FROM "user"
+WHERE id = $1
 `
 
 func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
@@ -37,29 +44,78 @@ func (q *Queries) DeleteUser(ctx context.Context, id uuid.UUID) error {
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, name, active FROM "user" WHERE id = $1
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE id = $1
 `
 
 func (q *Queries) GetUser(ctx context.Context, id uuid.UUID) (User, error) {
 	row := q.db.QueryRow(ctx, getUser, id)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.Active)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.ExternalKey,
+	)
+	return i, err
+}
+
+const getUserByExternalKey = `-- name: GetUserByExternalKey :one
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE external_key = $1
+`
+
+func (q *Queries) GetUserByExternalKey(ctx context.Context, externalKey string) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByExternalKey, externalKey)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.ExternalKey,
+	)
 	return i, err
 }
 
 const getUserByName = `-- name: GetUserByName :one
-SELECT id, name, active FROM "user" WHERE name = $1
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE name = $1
 `
 
 func (q *Queries) GetUserByName(ctx context.Context, name string) (User, error) {
 	row := q.db.QueryRow(ctx, getUserByName, name)
 	var i User
-	err := row.Scan(&i.ID, &i.Name, &i.Active)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Active,
+		&i.ExternalKey,
+	)
 	return i, err
 }
 
 const getUsers = `-- name: GetUsers :many
-SELECT id, name, active FROM "user"
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
 `
 
 func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
@@ -71,7 +127,47 @@ func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
 	items := []User{}
 	for rows.Next() {
 		var i User
-		if err := rows.Scan(&i.ID, &i.Name, &i.Active); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Active,
+			&i.ExternalKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUsersByIDs = `-- name: GetUsersByIDs :many
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE id = ANY($1::uuid [])
+`
+
+func (q *Queries) GetUsersByIDs(ctx context.Context, ids []uuid.UUID) ([]User, error) {
+	rows, err := q.db.Query(ctx, getUsersByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Active,
+			&i.ExternalKey,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -84,37 +180,50 @@ func (q *Queries) GetUsers(ctx context.Context) ([]User, error) {
 
 const updateUser = `-- name: UpdateUser :exec
 UPDATE "user"
-SET name = $2, active = $3
+SET name = $2, active = $3, external_key = $4
 WHERE id = $1
 `
 
 type UpdateUserParams struct {
-	ID     uuid.UUID `db:"id" json:"id"`
-	Name   string    `db:"name" json:"name"`
-	Active bool      `db:"active" json:"active"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Active      bool      `db:"active" json:"active"`
+	ExternalKey string    `db:"external_key" json:"external_key"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
-	_, err := q.db.Exec(ctx, updateUser, arg.ID, arg.Name, arg.Active)
+	_, err := q.db.Exec(ctx, updateUser,
+		arg.ID,
+		arg.Name,
+		arg.Active,
+		arg.ExternalKey,
+	)
 	return err
 }
 
 const upsertUser = `-- name: UpsertUser :exec
-INSERT INTO "user" (id, name, active) 
-VALUES ($1, $2, $3)
+INSERT INTO "user" (id, name, active, external_key)
+VALUES ($1, $2, $3, $4)
 ON CONFLICT (id)
-DO UPDATE SET 
-    name = EXCLUDED.name,
-    active = EXCLUDED.active
+DO UPDATE SET
+    name = excluded.name,
+    active = excluded.active,
+    external_key = excluded.external_key
 `
 
 type UpsertUserParams struct {
-	ID     uuid.UUID `db:"id" json:"id"`
-	Name   string    `db:"name" json:"name"`
-	Active bool      `db:"active" json:"active"`
+	ID          uuid.UUID `db:"id" json:"id"`
+	Name        string    `db:"name" json:"name"`
+	Active      bool      `db:"active" json:"active"`
+	ExternalKey string    `db:"external_key" json:"external_key"`
 }
 
 func (q *Queries) UpsertUser(ctx context.Context, arg UpsertUserParams) error {
-	_, err := q.db.Exec(ctx, upsertUser, arg.ID, arg.Name, arg.Active)
+	_, err := q.db.Exec(ctx, upsertUser,
+		arg.ID,
+		arg.Name,
+		arg.Active,
+		arg.ExternalKey,
+	)
 	return err
 }
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS reviewer_rotation (
+    team_id UUID PRIMARY KEY,
+    last_reviewer_id UUID,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (team_id) REFERENCES team (id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (last_reviewer_id) REFERENCES "user" (id)
+    ON DELETE SET NULL ON UPDATE CASCADE
+);
@@ -0,0 +1,10 @@
+-- +migrate Down
+
+ALTER TABLE pull_request
+DROP CONSTRAINT IF EXISTS pull_request_max_reviewers_check,
+DROP COLUMN IF EXISTS max_reviewers;
+
+ALTER TABLE team
+DROP CONSTRAINT IF EXISTS team_reviewers_range_check,
+DROP COLUMN IF EXISTS max_reviewers,
+DROP COLUMN IF EXISTS min_reviewers;
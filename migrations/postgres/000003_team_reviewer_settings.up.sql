@@ -0,0 +1,12 @@
+-- +migrate Up
+
+ALTER TABLE team
+ADD COLUMN IF NOT EXISTS min_reviewers INTEGER NOT NULL DEFAULT 0,
+ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2,
+ADD CONSTRAINT team_reviewers_range_check CHECK (
+    min_reviewers >= 0 AND min_reviewers <= max_reviewers
+);
+
+ALTER TABLE pull_request
+ADD COLUMN IF NOT EXISTS max_reviewers INTEGER NOT NULL DEFAULT 2,
+ADD CONSTRAINT pull_request_max_reviewers_check CHECK (max_reviewers >= 0);
@@ -0,0 +1,13 @@
+-- +migrate Down
+
+ALTER TABLE pull_request
+DROP CONSTRAINT IF EXISTS pull_request_external_key_key,
+DROP COLUMN IF EXISTS external_key;
+
+ALTER TABLE team
+DROP CONSTRAINT IF EXISTS team_external_key_key,
+DROP COLUMN IF EXISTS external_key;
+
+ALTER TABLE "user"
+DROP CONSTRAINT IF EXISTS user_external_key_key,
+DROP COLUMN IF EXISTS external_key;
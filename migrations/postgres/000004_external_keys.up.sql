@@ -0,0 +1,34 @@
+-- +migrate Up
+
+ALTER TABLE "user"
+ADD COLUMN IF NOT EXISTS external_key VARCHAR;
+
+UPDATE "user"
+SET external_key = id::text
+WHERE external_key IS NULL;
+
+ALTER TABLE "user"
+ALTER COLUMN external_key SET NOT NULL,
+ADD CONSTRAINT user_external_key_key UNIQUE (external_key);
+
+ALTER TABLE team
+ADD COLUMN IF NOT EXISTS external_key VARCHAR;
+
+UPDATE team
+SET external_key = name
+WHERE external_key IS NULL;
+
+ALTER TABLE team
+ALTER COLUMN external_key SET NOT NULL,
+ADD CONSTRAINT team_external_key_key UNIQUE (external_key);
+
+ALTER TABLE pull_request
+ADD COLUMN IF NOT EXISTS external_key VARCHAR;
+
+UPDATE pull_request
+SET external_key = id::text
+WHERE external_key IS NULL;
+
+ALTER TABLE pull_request
+ALTER COLUMN external_key SET NOT NULL,
+ADD CONSTRAINT pull_request_external_key_key UNIQUE (external_key);
@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE IF NOT EXISTS reviewer_reassignment (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    pull_request_id UUID NOT NULL,
+    old_reviewer_id UUID NOT NULL,
+    new_reviewer_id UUID NOT NULL,
+    reassigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_request (id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (old_reviewer_id) REFERENCES "user" (id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (new_reviewer_id) REFERENCES "user" (id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS reviewer_reassignment_old_reviewer_id_idx
+ON reviewer_reassignment (old_reviewer_id);
+
+CREATE INDEX IF NOT EXISTS reviewer_reassignment_pull_request_id_idx
+ON reviewer_reassignment (pull_request_id);
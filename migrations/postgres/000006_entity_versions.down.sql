@@ -0,0 +1,7 @@
+-- +migrate Down
+
+ALTER TABLE team
+DROP COLUMN IF EXISTS version;
+
+ALTER TABLE pull_request
+DROP COLUMN IF EXISTS version;
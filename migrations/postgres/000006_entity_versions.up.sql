@@ -0,0 +1,7 @@
+-- +migrate Up
+
+ALTER TABLE pull_request
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE team
+ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
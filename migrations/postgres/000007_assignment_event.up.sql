@@ -0,0 +1,18 @@
+-- +migrate Up
+
+-- Audit log is append-only and must outlive entities it refers to,
+-- so there are no foreign keys
+CREATE TABLE IF NOT EXISTS assignment_event (
+    id UUID PRIMARY KEY,
+    event_type VARCHAR(32) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    pull_request_id UUID,
+    team_id UUID,
+    user_id UUID,
+    old_reviewer_id UUID,
+    new_reviewer_id UUID
+);
+
+CREATE INDEX IF NOT EXISTS assignment_event_pull_request_id_idx
+ON assignment_event (pull_request_id, occurred_at);
@@ -0,0 +1,5 @@
+-- +migrate Down
+
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_subscription;
+DROP TABLE IF EXISTS outbox_message;
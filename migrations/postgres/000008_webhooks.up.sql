@@ -0,0 +1,46 @@
+-- +migrate Up
+
+-- Events waiting to be published to webhook subscribers. Rows are written
+-- in the same transaction as assignment events they refer to.
+CREATE TABLE IF NOT EXISTS outbox_message (
+    event_id UUID PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    published_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (event_id) REFERENCES assignment_event (id)
+    ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS outbox_message_pending_idx
+ON outbox_message (created_at) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_subscription (
+    id UUID PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    -- empty array means all event types
+    event_types TEXT [] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscription (id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+    CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_due_idx
+ON webhook_delivery (next_attempt_at) WHERE status = 'pending';
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_subscription_id_idx
+ON webhook_delivery (subscription_id);
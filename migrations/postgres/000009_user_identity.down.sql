@@ -0,0 +1,3 @@
+-- +migrate Down
+
+DROP TABLE IF EXISTS user_identity;
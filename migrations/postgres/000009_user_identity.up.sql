@@ -0,0 +1,10 @@
+-- +migrate Up
+
+-- Accounts of users on code hosting, used to resolve authors of incoming pull requests
+CREATE TABLE IF NOT EXISTS user_identity (
+    provider VARCHAR(32) NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES "user" (id) ON DELETE CASCADE,
+    PRIMARY KEY (provider, login),
+    UNIQUE (provider, user_id)
+);
@@ -0,0 +1,9 @@
+-- +migrate Up
+
+-- Ids of processed webhook deliveries of code hosting, used to skip retried ones
+CREATE TABLE IF NOT EXISTS inbound_delivery (
+    provider VARCHAR(32) NOT NULL,
+    delivery_id VARCHAR(255) NOT NULL,
+    received_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, delivery_id)
+);
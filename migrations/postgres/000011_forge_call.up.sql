@@ -0,0 +1,22 @@
+-- +migrate Up
+
+-- Calls to code hosting, which failed and are retried in background
+CREATE TABLE IF NOT EXISTS forge_call (
+    id UUID PRIMARY KEY,
+    pull_request_id UUID NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    reviewer_ids UUID [] NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (pull_request_id) REFERENCES pull_request (id)
+    ON DELETE CASCADE ON UPDATE CASCADE,
+    CHECK (action IN ('request_reviewers', 'unrequest_reviewers')),
+    CHECK (status IN ('pending', 'delivered', 'dead'))
+);
+
+CREATE INDEX IF NOT EXISTS forge_call_due_idx
+ON forge_call (next_attempt_at) WHERE status = 'pending';
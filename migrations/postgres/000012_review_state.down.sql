@@ -0,0 +1,11 @@
+-- +migrate Down
+
+ALTER TABLE team
+DROP CONSTRAINT IF EXISTS team_required_approvals_check,
+DROP COLUMN IF EXISTS required_approvals;
+
+ALTER TABLE pull_request_reviewer
+DROP CONSTRAINT IF EXISTS pull_request_reviewer_state_check,
+DROP COLUMN IF EXISTS reviewed_at,
+DROP COLUMN IF EXISTS assigned_at,
+DROP COLUMN IF EXISTS state;
@@ -0,0 +1,15 @@
+-- +migrate Up
+
+ALTER TABLE pull_request_reviewer
+ADD COLUMN IF NOT EXISTS state VARCHAR(32) NOT NULL DEFAULT 'pending',
+ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP WITH TIME ZONE,
+ADD CONSTRAINT pull_request_reviewer_state_check CHECK (
+    state IN ('pending', 'approved', 'changes_requested', 'commented')
+);
+
+ALTER TABLE team
+ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0,
+ADD CONSTRAINT team_required_approvals_check CHECK (
+    required_approvals >= 0 AND required_approvals <= max_reviewers
+);
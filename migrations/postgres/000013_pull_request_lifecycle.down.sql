@@ -0,0 +1,23 @@
+-- +migrate Down
+
+-- values cannot be removed from enum, so the type is recreated;
+-- draft and closed pull requests become open
+ALTER TABLE pull_request
+DROP CONSTRAINT IF EXISTS pull_request_merged_at_check;
+
+ALTER TABLE pull_request
+ALTER COLUMN status TYPE VARCHAR USING status::TEXT;
+
+UPDATE pull_request
+SET status = 'open'
+WHERE status IN ('draft', 'closed');
+
+DROP TYPE IF EXISTS pull_request_status;
+CREATE TYPE pull_request_status AS ENUM ('open', 'merged');
+
+ALTER TABLE pull_request
+ALTER COLUMN status TYPE PULL_REQUEST_STATUS USING status::PULL_REQUEST_STATUS,
+ADD CONSTRAINT pull_request_check CHECK (
+    (status = 'merged' AND merged_at IS NOT NULL)
+    OR (status = 'open' AND merged_at IS NULL)
+);
@@ -0,0 +1,11 @@
+-- +migrate Up
+
+ALTER TYPE pull_request_status ADD VALUE IF NOT EXISTS 'draft';
+ALTER TYPE pull_request_status ADD VALUE IF NOT EXISTS 'closed';
+
+-- draft and closed pull requests have no merge time as well as open ones
+ALTER TABLE pull_request
+DROP CONSTRAINT IF EXISTS pull_request_check,
+ADD CONSTRAINT pull_request_merged_at_check CHECK (
+    (status = 'merged') = (merged_at IS NOT NULL)
+);
@@ -0,0 +1,13 @@
+-- +migrate Down
+
+ALTER TABLE pull_request
+DROP COLUMN IF EXISTS team_id;
+
+-- users keep only their primary teams
+DELETE FROM team_user
+WHERE NOT is_primary;
+
+DROP INDEX IF EXISTS team_user_primary_idx;
+
+ALTER TABLE team_user
+DROP COLUMN IF EXISTS is_primary;
@@ -0,0 +1,23 @@
+-- +migrate Up
+
+-- user may belong to several teams, one of them is primary
+ALTER TABLE team_user
+ADD COLUMN IF NOT EXISTS is_primary BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE team_user
+SET is_primary = TRUE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS team_user_primary_idx
+ON team_user (user_id)
+WHERE is_primary;
+
+-- team, which pool reviewers of pull request are drawn from;
+-- NULL means primary team of the author
+ALTER TABLE pull_request
+ADD COLUMN IF NOT EXISTS team_id UUID
+REFERENCES team (id) ON DELETE SET NULL ON UPDATE CASCADE;
+
+UPDATE pull_request AS pr
+SET team_id = tu.team_id
+FROM team_user AS tu
+WHERE tu.user_id = pr.author_id AND tu.is_primary;
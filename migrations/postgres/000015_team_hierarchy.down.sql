@@ -0,0 +1,7 @@
+-- +migrate Down
+
+ALTER TABLE pull_request_reviewer
+DROP COLUMN IF EXISTS fallback_team_id;
+
+ALTER TABLE team
+DROP COLUMN IF EXISTS parent_id;
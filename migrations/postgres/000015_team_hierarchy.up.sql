@@ -0,0 +1,13 @@
+-- +migrate Up
+
+-- reviewers are drawn from parent team, when the team lacks candidates
+ALTER TABLE team
+ADD COLUMN IF NOT EXISTS parent_id UUID
+REFERENCES team (id) ON DELETE SET NULL ON UPDATE CASCADE,
+ADD CONSTRAINT team_parent_check CHECK (parent_id <> id);
+
+-- ancestor team, which reviewer was drawn from;
+-- NULL means team of pull request
+ALTER TABLE pull_request_reviewer
+ADD COLUMN IF NOT EXISTS fallback_team_id UUID
+REFERENCES team (id) ON DELETE SET NULL ON UPDATE CASCADE;
@@ -0,0 +1,4 @@
+-- +migrate Down
+
+ALTER TABLE assignment_event
+DROP COLUMN IF EXISTS missing_approvals;
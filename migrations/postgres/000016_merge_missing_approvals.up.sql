@@ -0,0 +1,5 @@
+-- +migrate Up
+
+-- approvals, which pull request merged on code hosting lacked
+ALTER TABLE assignment_event
+ADD COLUMN IF NOT EXISTS missing_approvals INTEGER;
@@ -0,0 +1,7 @@
+-- +migrate Down
+
+ALTER TABLE team
+DROP CONSTRAINT IF EXISTS team_required_approvals_check,
+ADD CONSTRAINT team_required_approvals_check CHECK (
+    required_approvals >= 0 AND required_approvals <= max_reviewers
+);
@@ -0,0 +1,13 @@
+-- +migrate Up
+
+-- pull request with fewer reviewers than required approvals cannot be merged,
+-- so teams require at least as many reviewers as approvals.
+-- Migration fails if some team violates the constraint: such teams must be fixed beforehand
+-- by raising min_reviewers or lowering required_approvals, for example:
+--   SELECT external_key, min_reviewers, required_approvals FROM team
+--   WHERE required_approvals > min_reviewers;
+ALTER TABLE team
+DROP CONSTRAINT IF EXISTS team_required_approvals_check,
+ADD CONSTRAINT team_required_approvals_check CHECK (
+    required_approvals >= 0 AND required_approvals <= min_reviewers
+);
@@ -0,0 +1,30 @@
+-- name: CreateAssignmentEvent :exec
+INSERT INTO assignment_event (
+    id,
+    event_type,
+    actor,
+    occurred_at,
+    pull_request_id,
+    team_id,
+    user_id,
+    old_reviewer_id,
+    new_reviewer_id,
+    missing_approvals
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: GetAssignmentEventsByPullRequestID :many
+SELECT
+    id,
+    event_type,
+    actor,
+    occurred_at,
+    pull_request_id,
+    team_id,
+    user_id,
+    old_reviewer_id,
+    new_reviewer_id,
+    missing_approvals
+FROM assignment_event
+WHERE pull_request_id = $1
+ORDER BY occurred_at, id;
@@ -0,0 +1,46 @@
+-- name: CreateForgeCall :exec
+INSERT INTO forge_call (
+    id,
+    pull_request_id,
+    action,
+    reviewer_ids,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    created_at,
+    completed_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+
+-- name: ClaimDueForgeCalls :many
+UPDATE forge_call
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT c.id FROM forge_call AS c
+    WHERE c.status = 'pending' AND c.next_attempt_at <= sqlc.arg(due_at)
+    ORDER BY c.next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    id,
+    pull_request_id,
+    action,
+    reviewer_ids,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    created_at,
+    completed_at;
+
+-- name: UpdateForgeCall :exec
+UPDATE forge_call
+SET
+    status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_error = $5,
+    completed_at = $6
+WHERE id = $1;
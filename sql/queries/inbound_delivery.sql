@@ -0,0 +1,4 @@
+-- name: ClaimInboundDelivery :execrows
+INSERT INTO inbound_delivery (provider, delivery_id, received_at)
+VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING;
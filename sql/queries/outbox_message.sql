@@ -0,0 +1,27 @@
+-- name: CreateOutboxMessage :exec
+INSERT INTO outbox_message (event_id, created_at)
+VALUES ($1, $2);
+
+-- name: GetPendingOutboxEventsForUpdate :many
+SELECT
+    e.id,
+    e.event_type,
+    e.actor,
+    e.occurred_at,
+    e.pull_request_id,
+    e.team_id,
+    e.user_id,
+    e.old_reviewer_id,
+    e.new_reviewer_id,
+    e.missing_approvals
+FROM outbox_message AS o
+JOIN assignment_event AS e ON o.event_id = e.id
+WHERE o.published_at IS NULL
+ORDER BY o.created_at, o.event_id
+LIMIT $1
+FOR UPDATE OF o SKIP LOCKED;
+
+-- name: MarkOutboxMessagesPublished :exec
+UPDATE outbox_message
+SET published_at = sqlc.arg(published_at)
+WHERE event_id = ANY(sqlc.arg(event_ids)::uuid []);
@@ -1,6 +1,17 @@
 -- name: CreatePullRequest :exec
-INSERT INTO pull_request (id, title, author_id, created_at, status, merged_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO pull_request (
+    id,
+    title,
+    author_id,
+    created_at,
+    status,
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
 
 -- name: GetPullRequests :many
 SELECT
@@ -9,7 +20,11 @@ SELECT
     author_id,
     created_at,
     status,
-    merged_at
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
 FROM pull_request;
 
 -- name: GetPullRequest :one
@@ -19,14 +34,27 @@ SELECT
     author_id,
     created_at,
     status,
-    merged_at
+    merged_at,
+    max_reviewers,
+    external_key,
+    version,
+    team_id
 FROM pull_request
 WHERE id = $1;
 
--- name: UpdatePullRequest :exec
+-- name: UpdatePullRequest :execrows
 UPDATE pull_request
-SET title = $2, author_id = $3, created_at = $4, status = $5, merged_at = $6
-WHERE id = $1;
+SET
+    title = $2,
+    author_id = $3,
+    created_at = $4,
+    status = $5,
+    merged_at = $6,
+    max_reviewers = $7,
+    external_key = $8,
+    version = version + 1,
+    team_id = $10
+WHERE id = $1 AND version = $9;
 
 -- name: UpdatePullRequestStatus :exec
 UPDATE pull_request
@@ -36,3 +64,14 @@ WHERE id = $1;
 -- name: DeletePullRequest :exec
 DELETE FROM pull_request
 WHERE id = $1;
+
+-- name: LockPullRequest :one
+SELECT id
+FROM pull_request
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountOpenPullRequestsByTeamID :one
+SELECT COUNT(id) AS open_pull_requests
+FROM pull_request
+WHERE team_id = $1 AND status = 'open';
@@ -1,6 +1,8 @@
 -- name: CreatePullRequestReviewer :exec
-INSERT INTO pull_request_reviewer (pull_request_id, reviewer_id)
-VALUES ($1, $2);
+INSERT INTO pull_request_reviewer (
+    pull_request_id, reviewer_id, state, assigned_at, reviewed_at, fallback_team_id
+)
+VALUES ($1, $2, $3, $4, $5, $6);
 
 -- name: GetPullRequestReviewerReviewerIDs :many
 SELECT reviewer_id FROM pull_request_reviewer
@@ -37,14 +39,22 @@ SELECT
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
 FROM
     pull_request AS pr
 LEFT JOIN
     pull_request_reviewer AS prr
     ON pr.id = prr.pull_request_id
 ORDER BY
-    pr.id, prr.reviewer_id;
+    pr.id, prr.assigned_at, prr.reviewer_id;
 
 -- name: GetPullRequestWithReviewersByID :many
 SELECT
@@ -54,7 +64,15 @@ SELECT
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
 FROM
     pull_request AS pr
 LEFT JOIN
@@ -63,7 +81,34 @@ LEFT JOIN
 WHERE
     pr.id = $1
 ORDER BY
-    pr.id, prr.reviewer_id;
+    pr.id, prr.assigned_at, prr.reviewer_id;
+
+-- name: GetPullRequestWithReviewersByExternalKey :many
+SELECT
+    pr.id,
+    pr.title,
+    pr.author_id,
+    pr.created_at,
+    pr.status,
+    pr.merged_at,
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
+FROM
+    pull_request AS pr
+LEFT JOIN
+    pull_request_reviewer AS prr
+    ON pr.id = prr.pull_request_id
+WHERE
+    pr.external_key = $1
+ORDER BY
+    pr.id, prr.assigned_at, prr.reviewer_id;
 
 -- name: GetPullRequestsWithReviewersByReviewerID :many
 SELECT
@@ -73,7 +118,15 @@ SELECT
     pr.created_at,
     pr.status,
     pr.merged_at,
-    prr.reviewer_id
+    pr.max_reviewers,
+    pr.external_key,
+    pr.version,
+    pr.team_id,
+    prr.reviewer_id,
+    prr.state AS reviewer_state,
+    prr.assigned_at AS reviewer_assigned_at,
+    prr.reviewed_at AS reviewer_reviewed_at,
+    prr.fallback_team_id AS reviewer_fallback_team_id
 FROM
     pull_request AS pr
 LEFT JOIN
@@ -82,4 +135,15 @@ LEFT JOIN
 WHERE
     prr.reviewer_id = $1
 ORDER BY
-    pr.id, prr.reviewer_id;
+    pr.id, prr.assigned_at, prr.reviewer_id;
+
+-- name: CountOpenReviewsByTeamID :many
+SELECT
+    tu.user_id,
+    COUNT(pr.id) AS open_reviews
+FROM team_user AS tu
+LEFT JOIN pull_request_reviewer AS prr ON tu.user_id = prr.reviewer_id
+LEFT JOIN pull_request AS pr
+    ON prr.pull_request_id = pr.id AND pr.status = 'open'
+WHERE tu.team_id = $1
+GROUP BY tu.user_id;
@@ -0,0 +1,5 @@
+-- name: CreateReviewerReassignment :exec
+INSERT INTO reviewer_reassignment (
+    pull_request_id, old_reviewer_id, new_reviewer_id, reassigned_at
+)
+VALUES ($1, $2, $3, $4);
@@ -0,0 +1,16 @@
+-- name: GetReviewerRotation :one
+SELECT last_reviewer_id
+FROM reviewer_rotation
+WHERE team_id = $1;
+
+-- name: InitReviewerRotation :exec
+INSERT INTO reviewer_rotation (team_id)
+VALUES ($1)
+ON CONFLICT (team_id) DO NOTHING;
+
+-- name: AdvanceReviewerRotation :execrows
+UPDATE reviewer_rotation
+SET last_reviewer_id = sqlc.arg(last_reviewer_id), updated_at = now()
+WHERE
+    team_id = sqlc.arg(team_id)
+    AND last_reviewer_id IS NOT DISTINCT FROM sqlc.narg(expected_reviewer_id)::uuid;
@@ -0,0 +1,65 @@
+-- name: GetUserReviewStats :many
+WITH team_pull_request AS (
+    -- pull request without team belongs to primary team of the author
+    SELECT pr.id, pr.status
+    FROM pull_request AS pr
+    LEFT JOIN team_user AS author_team
+        ON pr.author_id = author_team.user_id AND author_team.is_primary
+    WHERE
+        sqlc.narg(team_id)::uuid IS NULL
+        OR COALESCE(pr.team_id, author_team.team_id) = sqlc.narg(team_id)::uuid
+)
+SELECT
+    u.id AS user_id,
+    u.external_key AS user_external_key,
+    u.name AS user_name,
+    (
+        COUNT(pr.id) + COALESCE(MAX(ra.reassigned_away), 0)
+    )::bigint AS total_assignments,
+    COUNT(pr.id) FILTER (WHERE pr.status = 'open') AS open_assignments,
+    COUNT(pr.id) FILTER (WHERE pr.status = 'merged') AS merged_reviews,
+    COALESCE(MAX(ra.reassigned_away), 0)::bigint AS reassigned_away
+FROM "user" AS u
+LEFT JOIN pull_request_reviewer AS prr ON u.id = prr.reviewer_id
+LEFT JOIN team_pull_request AS pr ON prr.pull_request_id = pr.id
+LEFT JOIN (
+    SELECT
+        old_reviewer_id,
+        COUNT(*) AS reassigned_away
+    FROM reviewer_reassignment
+    WHERE pull_request_id IN (SELECT id FROM team_pull_request)
+    GROUP BY old_reviewer_id
+) AS ra ON u.id = ra.old_reviewer_id
+WHERE
+    sqlc.narg(team_id)::uuid IS NULL
+    OR u.id IN (
+        SELECT tu.user_id FROM team_user AS tu
+        WHERE tu.team_id = sqlc.narg(team_id)::uuid
+    )
+GROUP BY u.id, u.external_key, u.name
+ORDER BY u.external_key;
+
+-- name: GetPullRequestReviewStats :many
+SELECT
+    pr.id,
+    pr.external_key,
+    pr.title,
+    pr.status,
+    pr.created_at,
+    pr.merged_at,
+    (
+        SELECT COUNT(*) FROM pull_request_reviewer AS prr
+        WHERE prr.pull_request_id = pr.id
+    ) AS reviewers_assigned,
+    (
+        SELECT COUNT(*) FROM reviewer_reassignment AS ra
+        WHERE ra.pull_request_id = pr.id
+    ) AS reassignments
+FROM pull_request AS pr
+-- pull request without team belongs to primary team of the author
+LEFT JOIN team_user AS author_team
+    ON pr.author_id = author_team.user_id AND author_team.is_primary
+WHERE
+    sqlc.narg(team_id)::uuid IS NULL
+    OR COALESCE(pr.team_id, author_team.team_id) = sqlc.narg(team_id)::uuid
+ORDER BY pr.created_at, pr.id;
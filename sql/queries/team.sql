@@ -1,32 +1,128 @@
 -- name: CreateTeam :exec
-INSERT INTO team (id, name)
-VALUES ($1, $2);
+INSERT INTO team (
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
 
 -- name: GetTeams :many
 SELECT
     id,
-    name
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
 FROM team;
 
 -- name: GetTeam :one
 SELECT
     id,
-    name
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
 FROM team
 WHERE id = $1;
 
 -- name: GetTeamByName :one
 SELECT
     id,
-    name
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
 FROM team
 WHERE name = $1;
 
--- name: UpdateTeam :exec
+-- name: GetTeamByExternalKey :one
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM team
+WHERE external_key = $1;
+
+-- name: UpdateTeam :execrows
 UPDATE team
-SET name = $2
-WHERE id = $1;
+SET
+    name = $2,
+    min_reviewers = $3,
+    max_reviewers = $4,
+    external_key = $5,
+    required_approvals = $7,
+    parent_id = $8,
+    version = version + 1
+WHERE id = $1 AND version = $6;
 
 -- name: DeleteTeam :exec
 DELETE FROM team
 WHERE id = $1;
+
+-- name: DeleteTeamWithVersion :execrows
+DELETE FROM team
+WHERE id = $1 AND version = $2;
+
+-- name: GetTeamAncestors :many
+WITH RECURSIVE ancestor AS (
+    SELECT
+        p.id,
+        p.name,
+        p.min_reviewers,
+        p.max_reviewers,
+        p.external_key,
+        p.version,
+        p.required_approvals,
+        p.parent_id,
+        1 AS depth,
+        ARRAY[c.id, p.id] AS path
+    FROM team AS c
+    INNER JOIN team AS p ON c.parent_id = p.id
+    WHERE c.id = $1
+    UNION ALL
+    SELECT
+        p.id,
+        p.name,
+        p.min_reviewers,
+        p.max_reviewers,
+        p.external_key,
+        p.version,
+        p.required_approvals,
+        p.parent_id,
+        a.depth + 1,
+        a.path || p.id
+    FROM ancestor AS a
+    INNER JOIN team AS p ON a.parent_id = p.id
+    -- guards against cycles, though they are rejected by application
+    WHERE NOT p.id = ANY(a.path)
+)
+SELECT
+    id,
+    name,
+    min_reviewers,
+    max_reviewers,
+    external_key,
+    version,
+    required_approvals,
+    parent_id
+FROM ancestor
+ORDER BY depth;
@@ -1,6 +1,11 @@
 -- name: CreateTeamUser :exec
-INSERT INTO team_user (team_id, user_id)
-VALUES ($1, $2);
+INSERT INTO team_user (team_id, user_id, is_primary)
+VALUES (
+    $1,
+    $2,
+    NOT EXISTS (SELECT 1 FROM team_user WHERE user_id = $2)
+)
+ON CONFLICT (team_id, user_id) DO NOTHING;
 
 -- name: RemoveUserFromTeam :exec
 DELETE FROM team_user
@@ -10,11 +15,48 @@ WHERE team_id = $1 AND user_id = $2;
 DELETE FROM team_user
 WHERE team_id = $1;
 
+-- name: DeleteTeamUsersExcept :many
+DELETE FROM team_user
+WHERE
+    team_id = sqlc.arg(team_id)
+    AND NOT (user_id = ANY(sqlc.arg(user_ids)::uuid []))
+RETURNING user_id;
+
+-- name: RestorePrimaryTeams :exec
+UPDATE team_user AS tu
+SET is_primary = TRUE
+FROM (
+    SELECT DISTINCT ON (m.user_id)
+        m.team_id,
+        m.user_id
+    FROM team_user AS m
+    INNER JOIN team AS t ON m.team_id = t.id
+    WHERE
+        m.user_id = ANY(sqlc.arg(user_ids)::uuid [])
+        AND NOT EXISTS (
+            SELECT 1 FROM team_user AS p
+            WHERE p.user_id = m.user_id AND p.is_primary
+        )
+    ORDER BY m.user_id, t.name
+) AS next
+WHERE tu.team_id = next.team_id AND tu.user_id = next.user_id;
+
+-- name: ClearPrimaryTeam :exec
+UPDATE team_user
+SET is_primary = FALSE
+WHERE user_id = $1 AND is_primary;
+
+-- name: SetPrimaryTeam :execrows
+UPDATE team_user
+SET is_primary = TRUE
+WHERE team_id = $1 AND user_id = $2;
+
 -- name: GetUsersInTeam :many
 SELECT
     u.id,
     u.name,
-    u.active
+    u.active,
+    u.external_key
 FROM "user" u
 JOIN team_user tu ON u.id = tu.user_id
 WHERE tu.team_id = $1;
@@ -22,35 +64,61 @@ WHERE tu.team_id = $1;
 -- name: GetUserIDsInTeam :many
 SELECT user_id
 FROM team_user
-WHERE team_id = $1;
+WHERE team_id = $1
+ORDER BY user_id;
 
--- name: GetTeamIDForUser :one
-SELECT t.id
+-- name: GetTeamForUser :one
+SELECT
+    t.id,
+    t.name,
+    t.min_reviewers,
+    t.max_reviewers,
+    t.external_key,
+    t.version,
+    t.required_approvals,
+    t.parent_id
 FROM team t
 JOIN team_user tu ON t.id = tu.team_id
-WHERE tu.user_id = $1;
+WHERE tu.user_id = $1
+ORDER BY tu.is_primary DESC, t.name
+LIMIT 1;
 
--- name: GetTeamForUser :one
+-- name: GetTeamsForUser :many
 SELECT
     t.id,
-    t.name
+    t.name,
+    t.min_reviewers,
+    t.max_reviewers,
+    t.external_key,
+    t.version,
+    t.required_approvals,
+    t.parent_id
 FROM team t
 JOIN team_user tu ON t.id = tu.team_id
-WHERE tu.user_id = $1;
+WHERE tu.user_id = $1
+ORDER BY tu.is_primary DESC, t.name;
 
 -- name: GetActiveUsersInTeam :many
 SELECT
     u.id,
     u.name,
-    u.active
+    u.active,
+    u.external_key
 FROM "user" u
 JOIN team_user tu ON u.id = tu.user_id
-WHERE tu.team_id = $1 AND u.active = true;
+WHERE tu.team_id = $1 AND u.active = true
+ORDER BY u.id;
 
 -- name: GetTeamsWithUsers :many
 SELECT
     t.id AS team_id,
     t.name AS team_name,
+    t.min_reviewers AS team_min_reviewers,
+    t.max_reviewers AS team_max_reviewers,
+    t.external_key AS team_external_key,
+    t.version AS team_version,
+    t.required_approvals AS team_required_approvals,
+    t.parent_id AS team_parent_id,
     u.id AS user_id,
     u.name AS user_name,
     u.active AS user_active
@@ -0,0 +1,25 @@
+-- name: UpsertUserIdentity :exec
+INSERT INTO user_identity (provider, login, user_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (provider, login) DO UPDATE
+SET user_id = EXCLUDED.user_id;
+
+-- name: DeleteUserIdentityByUserID :exec
+DELETE FROM user_identity
+WHERE provider = $1 AND user_id = $2;
+
+-- name: GetUserIdentityByLogin :one
+SELECT
+    provider,
+    login,
+    user_id
+FROM user_identity
+WHERE provider = $1 AND login = $2;
+
+-- name: GetUserIdentitiesByUserIDs :many
+SELECT
+    provider,
+    login,
+    user_id
+FROM user_identity
+WHERE provider = sqlc.arg(provider) AND user_id = ANY(sqlc.arg(user_ids)::uuid []);
@@ -1,19 +1,21 @@
 -- name: CreateUser :exec
-INSERT INTO "user" (id, name, active)
-VALUES ($1, $2, $3);
+INSERT INTO "user" (id, name, active, external_key)
+VALUES ($1, $2, $3, $4);
 
 -- name: GetUsers :many
 SELECT
     id,
     name,
-    active
+    active,
+    external_key
 FROM "user";
 
 -- name: GetUser :one
 SELECT
     id,
     name,
-    active
+    active,
+    external_key
 FROM "user"
 WHERE id = $1;
 
@@ -21,13 +23,32 @@ WHERE id = $1;
 SELECT
     id,
     name,
-    active
+    active,
+    external_key
 FROM "user"
 WHERE name = $1;
 
+-- name: GetUserByExternalKey :one
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE external_key = $1;
+
+-- name: GetUsersByIDs :many
+SELECT
+    id,
+    name,
+    active,
+    external_key
+FROM "user"
+WHERE id = ANY(sqlc.arg(ids)::uuid []);
+
 -- name: UpdateUser :exec
 UPDATE "user"
-SET name = $2, active = $3
+SET name = $2, active = $3, external_key = $4
 WHERE id = $1;
 
 -- name: DeleteUser :exec
@@ -35,9 +56,10 @@ DELETE FROM "user"
 WHERE id = $1;
 
 -- name: UpsertUser :exec
-INSERT INTO "user" (id, name, active)
-VALUES ($1, $2, $3)
+INSERT INTO "user" (id, name, active, external_key)
+VALUES ($1, $2, $3, $4)
 ON CONFLICT (id)
 DO UPDATE SET
     name = excluded.name,
-    active = excluded.active;
+    active = excluded.active,
+    external_key = excluded.external_key;
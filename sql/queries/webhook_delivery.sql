@@ -0,0 +1,67 @@
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_delivery (
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    created_at,
+    delivered_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_delivery
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT d.id FROM webhook_delivery AS d
+    WHERE d.status = 'pending' AND d.next_attempt_at <= sqlc.arg(due_at)
+    ORDER BY d.next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    created_at,
+    delivered_at;
+
+-- name: UpdateWebhookDelivery :exec
+UPDATE webhook_delivery
+SET
+    status = $2,
+    attempts = $3,
+    next_attempt_at = $4,
+    last_error = $5,
+    delivered_at = $6
+WHERE id = $1;
+
+-- name: GetWebhookDeliveries :many
+SELECT
+    id,
+    subscription_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    attempts,
+    next_attempt_at,
+    last_error,
+    created_at,
+    delivered_at
+FROM webhook_delivery
+WHERE
+    (sqlc.narg(subscription_id)::uuid IS NULL OR subscription_id = sqlc.narg(subscription_id)::uuid)
+    AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+ORDER BY created_at, id;
@@ -0,0 +1,33 @@
+-- name: CreateWebhookSubscription :exec
+INSERT INTO webhook_subscription (
+    id,
+    url,
+    secret,
+    event_types,
+    created_at
+)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetWebhookSubscription :one
+SELECT
+    id,
+    url,
+    secret,
+    event_types,
+    created_at
+FROM webhook_subscription
+WHERE id = $1;
+
+-- name: GetWebhookSubscriptions :many
+SELECT
+    id,
+    url,
+    secret,
+    event_types,
+    created_at
+FROM webhook_subscription
+ORDER BY created_at, id;
+
+-- name: DeleteWebhookSubscription :exec
+DELETE FROM webhook_subscription
+WHERE id = $1;